@@ -329,7 +329,7 @@ const file_catalog_v1_category_proto_rawDesc = "" +
 	"\x16ListCategoriesResponse\x124\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x14.catalog.v1.CategoryR\n" +
-	"categoriesB\x0fZ\rcatalog/v1;v1b\x06proto3"
+	"categoriesB\x19Z\x17api/proto/catalog/v1;v1b\x06proto3"
 
 var (
 	file_catalog_v1_category_proto_rawDescOnce sync.Once
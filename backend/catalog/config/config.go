@@ -7,6 +7,8 @@ type Config struct {
 	MinIO        MinIO        `mapstructure:"minio"`
 	Database     Database     `mapstructure:"database"`
 	LocalStorage LocalStorage `mapstructure:"local_storage"`
+	Auth         Auth         `mapstructure:"auth"`
+	Services     Services     `mapstructure:"services"`
 }
 
 type General struct {
@@ -34,6 +36,16 @@ type LocalStorage struct {
 	StaticFilesPrefix  string `mapstructure:"static_files_prefix"`
 }
 
+type Auth struct {
+	JWTSecret string `mapstructure:"jwt_secret"`
+	// ServiceKey authenticates catalog's calls to internal methods of the other services.
+	ServiceKey string `mapstructure:"service_key"`
+}
+
+type Services struct {
+	OrderGRPCAddr string `mapstructure:"order_grpc_addr"`
+}
+
 func setDefault(v *viper.Viper) {
 	v.SetDefault("general.app_env", DefaultAppEnv)
 	v.SetDefault("general.grpc_port", DefaultGRPCPort)
@@ -51,6 +63,11 @@ func setDefault(v *viper.Viper) {
 	v.SetDefault("local_storage.public_storage_path", DefaultPublicStoragePath)
 	v.SetDefault("local_storage.private_storage_path", DefaultPrivateStoragePath)
 	v.SetDefault("local_storage.static_files_prefix", DefaultStaticFilesPrefix)
+
+	v.SetDefault("auth.jwt_secret", DefaultJWTSecret)
+	v.SetDefault("auth.service_key", DefaultServiceKey)
+
+	v.SetDefault("services.order_grpc_addr", DefaultOrderGRPCAddr)
 }
 
 func LoadConfig(path string) (*Config, error) {
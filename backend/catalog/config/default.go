@@ -17,4 +17,9 @@ var (
 	DefaultPublicStoragePath  = "/var/lib/blackshop/storage/public"
 	DefaultPrivateStoragePath = "/var/lib/blackshop/storage/private"
 	DefaultStaticFilesPrefix  = "public"
+
+	DefaultJWTSecret  = "a_very_secret_key"
+	DefaultServiceKey = "a_very_secret_service_key"
+
+	DefaultOrderGRPCAddr = "127.0.0.1:50053"
 )
@@ -10,8 +10,13 @@ import (
 )
 
 type Adapter struct {
-	ProductRepo  domain.ProductRepository
-	CategoryRepo domain.CategoryRepository
+	ProductRepo          domain.ProductRepository
+	ProductVariantRepo   domain.ProductVariantRepository
+	CategoryRepo         domain.CategoryRepository
+	BrandRepo            domain.BrandRepository
+	ReviewRepo           domain.ReviewRepository
+	TagRepo              domain.TagRepository
+	StockReservationRepo domain.StockReservationRepository
 }
 
 func NewAdapter(db *mongo.Database, logger *zap.Logger) (*Adapter, error) {
@@ -21,14 +26,44 @@ func NewAdapter(db *mongo.Database, logger *zap.Logger) (*Adapter, error) {
 		return nil, fmt.Errorf("failed to create product repository: %w", err)
 	}
 
+	productVariantRepo, err := mongodb.NewProductVariantRepository(db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product variant repository: %w", err)
+	}
+
 	categoryRepo, err := mongodb.NewCategoryRepository(db, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create category repository: %w", err)
 	}
 
+	brandRepo, err := mongodb.NewBrandRepository(db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create brand repository: %w", err)
+	}
+
+	reviewRepo, err := mongodb.NewReviewRepository(db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create review repository: %w", err)
+	}
+
+	tagRepo, err := mongodb.NewTagRepository(db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag repository: %w", err)
+	}
+
+	stockReservationRepo, err := mongodb.NewStockReservationRepository(db, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock reservation repository: %w", err)
+	}
+
 	return &Adapter{
-		ProductRepo:  productRepo,
-		CategoryRepo: categoryRepo,
+		ProductRepo:          productRepo,
+		ProductVariantRepo:   productVariantRepo,
+		CategoryRepo:         categoryRepo,
+		BrandRepo:            brandRepo,
+		ReviewRepo:           reviewRepo,
+		TagRepo:              tagRepo,
+		StockReservationRepo: stockReservationRepo,
 	}, nil
 
 }
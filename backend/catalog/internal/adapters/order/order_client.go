@@ -0,0 +1,41 @@
+package order
+
+import (
+	orderpb "api/proto/order/v1"
+	"catalog/internal/domain"
+	"context"
+	"fmt"
+	"pkg/auth"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type orderClient struct {
+	client     orderpb.OrderServiceClient
+	serviceKey string
+	logger     *zap.Logger
+}
+
+func NewOrderClient(conn grpc.ClientConnInterface, serviceKey string, logger *zap.Logger) domain.PurchaseVerifier {
+	return &orderClient{
+		client:     orderpb.NewOrderServiceClient(conn),
+		serviceKey: serviceKey,
+		logger:     logger.Named("grpc_order_client"),
+	}
+}
+
+func (c *orderClient) HasPurchased(ctx context.Context, userID string, productID domain.ProductID) (bool, error) {
+	c.logger.Info("checking product purchase with order-service", zap.String("user_id", userID), zap.String("product_id", string(productID)))
+
+	// HasPurchasedProduct is an internal method: catalog authenticates as a service and names the reviewer.
+	ctx = auth.WithServiceKey(ctx, c.serviceKey)
+
+	res, err := c.client.HasPurchasedProduct(ctx, &orderpb.HasPurchasedProductRequest{ProductId: string(productID), UserId: userID})
+	if err != nil {
+		c.logger.Error("failed to check product purchase with order-service", zap.Error(err))
+		return false, fmt.Errorf("failed to check product purchase with order-service: %w", err)
+	}
+
+	return res.GetPurchased(), nil
+}
@@ -0,0 +1,167 @@
+package mongodb
+
+import (
+	"catalog/internal/adapters/storage/mongodb/model"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+type brandRepo struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewBrandRepository(db *mongo.Database, logger *zap.Logger) (domain.BrandRepository, error) {
+	col := db.Collection(model.BrandsCollection)
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := col.Indexes().CreateOne(context.Background(), indexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unique index on brand name: %w", err)
+	}
+
+	return &brandRepo{
+		collection: col,
+		logger:     logger.Named("mongodb_brand_repo"),
+	}, nil
+}
+
+func (r *brandRepo) Create(ctx context.Context, brand *domain.Brand) error {
+	r.logger.Info("creating a new brand", zap.String("brand_name", brand.Name))
+
+	mb := model.MongoBrand{
+		Name:      brand.Name,
+		Logo:      brand.Logo,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, mb)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("brand name already exists", zap.String("brand_name", brand.Name))
+			return domain.ErrBrandAlreadyExists
+		}
+		r.logger.Error("failed to create brand", zap.Error(err))
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		brand.ID = domain.BrandID(oid.Hex())
+		brand.CreatedAt = mb.CreatedAt
+		brand.UpdatedAt = mb.UpdatedAt
+		r.logger.Info("successfully created brand", zap.String("brand_id", string(brand.ID)))
+	}
+
+	return nil
+}
+
+func (r *brandRepo) Update(ctx context.Context, brand *domain.Brand) error {
+	r.logger.Info("updating brand", zap.String("brand_id", string(brand.ID)))
+
+	oid, err := toObjectID(string(brand.ID))
+	if err != nil {
+		return domain.ErrBrandNotFound
+	}
+
+	brand.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"name":      brand.Name,
+		"logo":      brand.Logo,
+		"updatedAt": brand.UpdatedAt,
+	}}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("brand name already exists", zap.String("brand_name", brand.Name))
+			return domain.ErrBrandAlreadyExists
+		}
+		r.logger.Error("failed to update brand", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrBrandNotFound
+	}
+
+	return nil
+}
+
+func (r *brandRepo) FindByID(ctx context.Context, id domain.BrandID) (*domain.Brand, error) {
+	r.logger.Info("finding brand by id", zap.String("brand_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return nil, domain.ErrBrandNotFound
+	}
+
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+func (r *brandRepo) FindByName(ctx context.Context, name string) (*domain.Brand, error) {
+	r.logger.Info("finding brand by name", zap.String("brand_name", name))
+
+	return r.findOne(ctx, bson.M{"name": name})
+}
+
+func (r *brandRepo) FindAll(ctx context.Context) ([]*domain.Brand, error) {
+	r.logger.Info("finding all brands")
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		r.logger.Error("failed to execute find all brands query", zap.Error(err))
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	brands := make([]*domain.Brand, 0)
+	for cursor.Next(ctx) {
+		var mb model.MongoBrand
+		if err := cursor.Decode(&mb); err != nil {
+			r.logger.Error("failed to decode a brand document", zap.Error(err))
+			continue
+		}
+		brands = append(brands, toDomainBrand(&mb))
+	}
+
+	return brands, cursor.Err()
+}
+
+func (r *brandRepo) findOne(ctx context.Context, filter bson.M) (*domain.Brand, error) {
+	var mb model.MongoBrand
+	if err := r.collection.FindOne(ctx, filter).Decode(&mb); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			r.logger.Warn("brand not found", zap.Any("filter", filter))
+			return nil, domain.ErrBrandNotFound
+		}
+		r.logger.Error("failed to find brand", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainBrand(&mb), nil
+}
+
+func toDomainBrand(mb *model.MongoBrand) *domain.Brand {
+	return &domain.Brand{
+		ID:        domain.BrandID(mb.ID.Hex()),
+		Name:      mb.Name,
+		Logo:      mb.Logo,
+		CreatedAt: mb.CreatedAt,
+		UpdatedAt: mb.UpdatedAt,
+	}
+}
@@ -1,10 +1,11 @@
 package model
 
 const (
-	CategoriesCollection = "categories"
-	ProductsCollection   = "products"
-	//ProductVariantsCollection = "product_variants"
-	//BrandsCollection          = "brands"
-	//TagsCollection            = "tags"
-	//ReviewsCollection         = "reviews"
+	CategoriesCollection        = "categories"
+	ProductsCollection          = "products"
+	ProductVariantsCollection   = "product_variants"
+	BrandsCollection            = "brands"
+	ReviewsCollection           = "reviews"
+	TagsCollection              = "tags"
+	StockReservationsCollection = "stock_reservations"
 )
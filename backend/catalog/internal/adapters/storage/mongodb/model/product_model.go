@@ -1,8 +1,24 @@
 package model
 
-import "go.mongodb.org/mongo-driver/v2/bson"
+import (
+	"catalog/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
 
 type MongoProduct struct {
-	ID   bson.ObjectID `bson:"_id,omitempty"`
-	Name string        `bson:"name"`
+	ID              bson.ObjectID     `bson:"_id,omitempty"`
+	Name            string            `bson:"name"`
+	Description     string            `bson:"description"`
+	PrimaryImageURL *string           `bson:"primaryImageUrl,omitempty"`
+	CategoryID      domain.CategoryID `bson:"categoryId"`
+	BrandID         *domain.BrandID   `bson:"brandId,omitempty"`
+	Tags            []domain.TagID    `bson:"tags,omitempty"`
+	RatingSum       int               `bson:"ratingSum"`
+	ReviewCount     int               `bson:"reviewCount"`
+	AverageRating   float64           `bson:"averageRating"`
+	IsPublished     bool              `bson:"isPublished"`
+	CreatedAt       time.Time         `bson:"createdAt"`
+	UpdatedAt       time.Time         `bson:"updatedAt"`
 }
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"catalog/internal/domain"
+	"math"
+	"pkg/money"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MongoProductVariant struct {
+	ID          bson.ObjectID    `bson:"_id,omitempty"`
+	ProductID   domain.ProductID `bson:"productId"`
+	SKU         string           `bson:"sku"`
+	Price       *MongoMoney      `bson:"priceMoney,omitempty"`
+	LegacyPrice float64          `bson:"price,omitempty"` // Written before prices were kept in minor units; read only
+	Stock       int              `bson:"stock"`
+	Images      []MongoImage     `bson:"images,omitempty"`
+	Attributes  []MongoAttribute `bson:"attributes,omitempty"`
+	CreatedAt   time.Time        `bson:"createdAt"`
+	UpdatedAt   time.Time        `bson:"updatedAt"`
+}
+
+type MongoMoney struct {
+	Amount   int64  `bson:"amount"`
+	Currency string `bson:"currency"`
+}
+
+func ToMongoMoney(m money.Money) *MongoMoney {
+	return &MongoMoney{Amount: m.Amount, Currency: m.Currency}
+}
+
+// PriceMoney returns the variant price. Documents that still hold the old float price are
+// read as DefaultCurrency, rounded to the cent.
+func (mv *MongoProductVariant) PriceMoney() money.Money {
+	if mv.Price != nil {
+		return money.New(mv.Price.Amount, mv.Price.Currency)
+	}
+	return money.New(int64(math.Round(mv.LegacyPrice*100)), money.DefaultCurrency)
+}
+
+type MongoImage struct {
+	URL       string `bson:"url"`
+	AltText   string `bson:"altText,omitempty"`
+	IsPrimary bool   `bson:"isPrimary"`
+	Order     int    `bson:"order"`
+}
+
+type MongoAttribute struct {
+	Name  string `bson:"name"`
+	Value string `bson:"value"`
+}
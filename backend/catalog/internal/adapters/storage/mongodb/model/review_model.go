@@ -0,0 +1,18 @@
+package model
+
+import (
+	"catalog/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MongoReview struct {
+	ID        bson.ObjectID    `bson:"_id,omitempty"`
+	ProductID domain.ProductID `bson:"productId"`
+	UserID    string           `bson:"userId"`
+	Rating    int              `bson:"rating"`
+	Comment   string           `bson:"comment"`
+	CreatedAt time.Time        `bson:"createdAt"`
+	UpdatedAt time.Time        `bson:"updatedAt"`
+}
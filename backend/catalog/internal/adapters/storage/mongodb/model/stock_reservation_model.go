@@ -0,0 +1,27 @@
+package model
+
+import (
+	"catalog/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type MongoStockReservation struct {
+	ID        bson.ObjectID            `bson:"_id,omitempty"`
+	OrderID   string                   `bson:"orderId"`
+	Items     []MongoReservedItem      `bson:"items"`
+	Status    domain.ReservationStatus `bson:"status"`
+	CreatedAt time.Time                `bson:"createdAt"`
+	UpdatedAt time.Time                `bson:"updatedAt"`
+}
+
+type MongoReservedItem struct {
+	VariantID domain.ProductVariantID `bson:"variantId"`
+	Quantity  int                     `bson:"quantity"`
+	// Pending is set until the quantity is taken off the variant stock; reservations written before it
+	// existed were always applied.
+	Pending bool `bson:"pending,omitempty"`
+	// Released is set once the item is handed back to the stock, or will never be taken, for a release.
+	Released bool `bson:"released,omitempty"`
+}
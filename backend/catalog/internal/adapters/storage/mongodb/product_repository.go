@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -28,6 +29,22 @@ func NewProductRepository(db *mongo.Database, logger *zap.Logger) (domain.Produc
 		return nil, fmt.Errorf("failed to create index on categoryId: %w", err)
 	}
 
+	brandIndexModel := mongo.IndexModel{
+		Keys: bson.M{"brandId": 1},
+	}
+	_, err = col.Indexes().CreateOne(context.Background(), brandIndexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index on brandId: %w", err)
+	}
+
+	tagsIndexModel := mongo.IndexModel{
+		Keys: bson.M{"tags": 1},
+	}
+	_, err = col.Indexes().CreateOne(context.Background(), tagsIndexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index on tags: %w", err)
+	}
+
 	return &productRepo{
 		collection: col,
 		logger:     logger.Named("mongodb_product_repo"),
@@ -56,10 +73,46 @@ func (r *productRepo) FindByID(ctx context.Context, id domain.ProductID) (*domai
 		return nil, fmt.Errorf("failed to execute find query: %w", err)
 	}
 
-	return &domain.Product{
-		ID:   domain.ProductID(p.ID.Hex()),
-		Name: p.Name,
-	}, nil
+	return toDomainProduct(&p), nil
+}
+
+func (r *productRepo) FindByIDs(ctx context.Context, ids []domain.ProductID) ([]*domain.Product, error) {
+	r.logger.Info("finding products by ids", zap.Int("count", len(ids)))
+
+	objectIDs := make([]bson.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := bson.ObjectIDFromHex(string(id))
+		if err != nil {
+			r.logger.Warn("skipping invalid product id", zap.String("product_id", string(id)))
+			continue
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	if len(objectIDs) == 0 {
+		return []*domain.Product{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		r.logger.Error("failed to execute find products by ids query", zap.Error(err))
+		return nil, fmt.Errorf("failed to execute find query: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	products := make([]*domain.Product, 0, len(objectIDs))
+	for cursor.Next(ctx) {
+		var p model.MongoProduct
+		if err := cursor.Decode(&p); err != nil {
+			r.logger.Error("failed to decode a product document", zap.Error(err))
+			continue
+		}
+		products = append(products, toDomainProduct(&p))
+	}
+
+	return products, cursor.Err()
 }
 
 func (r *productRepo) FindAll(ctx context.Context, filterQuery bson.M, sortOptions bson.D, page, limit int) ([]*domain.Product, int64, error) {
@@ -117,10 +170,7 @@ func (r *productRepo) FindAll(ctx context.Context, filterQuery bson.M, sortOptio
 
 	products := make([]*domain.Product, 0, len(results.Data))
 	for _, mongoProd := range results.Data {
-		products = append(products, &domain.Product{
-			ID:   domain.ProductID(mongoProd.ID.Hex()),
-			Name: mongoProd.Name,
-		})
+		products = append(products, toDomainProduct(&mongoProd))
 	}
 
 	return products, total, nil
@@ -130,7 +180,15 @@ func (r *productRepo) Create(ctx context.Context, product *domain.Product) error
 	r.logger.Info("creating a new product", zap.String("product_name", product.Name))
 
 	p := model.MongoProduct{
-		Name: product.Name,
+		Name:            product.Name,
+		Description:     product.Description,
+		PrimaryImageURL: product.PrimaryImageURL,
+		CategoryID:      product.CategoryID,
+		BrandID:         product.BrandID,
+		Tags:            product.Tags,
+		IsPublished:     product.IsPublished,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
 
 	res, err := r.collection.InsertOne(ctx, p)
@@ -141,6 +199,8 @@ func (r *productRepo) Create(ctx context.Context, product *domain.Product) error
 
 	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
 		product.ID = domain.ProductID(oid.Hex())
+		product.CreatedAt = p.CreatedAt
+		product.UpdatedAt = p.UpdatedAt
 		r.logger.Info("successfully created product", zap.String("product_id", string(product.ID)))
 	}
 
@@ -159,7 +219,148 @@ func (r *productRepo) CategoryHasProducts(ctx context.Context, id domain.Categor
 	return count > 0, nil
 }
 
+func (r *productRepo) UpdateRating(ctx context.Context, id domain.ProductID, ratingDelta, countDelta int) error {
+	r.logger.Info("updating product rating", zap.String("product_id", string(id)), zap.Int("rating_delta", ratingDelta), zap.Int("count_delta", countDelta))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	// The pipeline runs server-side on a single document, so concurrent reviews cannot lose updates.
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"ratingSum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingSum", 0}}, ratingDelta}},
+			"reviewCount": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$reviewCount", 0}}, countDelta}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"averageRating": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$reviewCount", 0}},
+				bson.M{"$divide": bson.A{"$ratingSum", "$reviewCount"}},
+				0,
+			}},
+		}}},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		r.logger.Error("failed to update product rating", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *productRepo) AddTags(ctx context.Context, id domain.ProductID, tagIDs []domain.TagID) error {
+	r.logger.Info("adding tags to product", zap.String("product_id", string(id)), zap.Int("count", len(tagIDs)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	update := bson.M{"$addToSet": bson.M{"tags": bson.M{"$each": tagIDs}}}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		r.logger.Error("failed to add tags to product", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *productRepo) RemoveTag(ctx context.Context, id domain.ProductID, tagID domain.TagID) error {
+	r.logger.Info("removing tag from product", zap.String("product_id", string(id)), zap.String("tag_id", string(tagID)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$pull": bson.M{"tags": tagID}})
+	if err != nil {
+		r.logger.Error("failed to remove tag from product", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *productRepo) RemoveTagFromAll(ctx context.Context, tagID domain.TagID) error {
+	r.logger.Info("removing tag from all products", zap.String("tag_id", string(tagID)))
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"tags": tagID}, bson.M{"$pull": bson.M{"tags": tagID}})
+	if err != nil {
+		r.logger.Error("failed to remove tag from products", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 func (r *productRepo) Update(ctx context.Context, product *domain.Product) error {
-	//TODO implement me
-	panic("implement me")
+	r.logger.Info("updating product", zap.String("product_id", string(product.ID)))
+
+	oid, err := toObjectID(string(product.ID))
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	// Tags and rating fields are owned by their own atomic updates and are left untouched here.
+	product.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"name":            product.Name,
+		"description":     product.Description,
+		"primaryImageUrl": product.PrimaryImageURL,
+		"categoryId":      product.CategoryID,
+		"brandId":         product.BrandID,
+		"isPublished":     product.IsPublished,
+		"updatedAt":       product.UpdatedAt,
+	}}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		r.logger.Error("failed to update product", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func toDomainProduct(p *model.MongoProduct) *domain.Product {
+	tags := p.Tags
+	if tags == nil {
+		tags = []domain.TagID{}
+	}
+
+	return &domain.Product{
+		ID:              domain.ProductID(p.ID.Hex()),
+		Name:            p.Name,
+		Description:     p.Description,
+		PrimaryImageURL: p.PrimaryImageURL,
+		AverageRating:   p.AverageRating,
+		ReviewCount:     p.ReviewCount,
+		CategoryID:      p.CategoryID,
+		BrandID:         p.BrandID,
+		Tags:            tags,
+		IsPublished:     p.IsPublished,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
 }
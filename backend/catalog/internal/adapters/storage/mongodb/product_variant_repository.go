@@ -0,0 +1,290 @@
+package mongodb
+
+import (
+	"catalog/internal/adapters/storage/mongodb/model"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+type productVariantRepo struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewProductVariantRepository(db *mongo.Database, logger *zap.Logger) (domain.ProductVariantRepository, error) {
+	col := db.Collection(model.ProductVariantsCollection)
+
+	if err := ensureProductVariantIndexes(context.Background(), col); err != nil {
+		return nil, err
+	}
+
+	return &productVariantRepo{
+		collection: col,
+		logger:     logger.Named("mongodb_product_variant_repo"),
+	}, nil
+}
+
+func ensureProductVariantIndexes(ctx context.Context, collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "productId", Value: 1},
+			{Key: "createdAt", Value: 1},
+		},
+	}
+	_, err := collection.Indexes().CreateOne(ctx, indexModel)
+	if err != nil {
+		return fmt.Errorf("failed to create index on productId: %w", err)
+	}
+
+	skuIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "sku", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err = collection.Indexes().CreateOne(ctx, skuIndexModel)
+	if err != nil {
+		return fmt.Errorf("failed to create unique index on sku: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productVariantRepo) Create(ctx context.Context, variant *domain.ProductVariant) error {
+	r.logger.Info("creating a new product variant", zap.String("product_id", string(variant.ProductID)), zap.String("sku", variant.SKU))
+
+	mv := toMongoProductVariant(variant)
+	mv.CreatedAt = time.Now()
+	mv.UpdatedAt = time.Now()
+
+	res, err := r.collection.InsertOne(ctx, mv)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("product variant sku already exists", zap.String("sku", variant.SKU))
+			return domain.ErrSKUAlreadyExists
+		}
+		r.logger.Error("failed to save product variant", zap.Error(err))
+		return fmt.Errorf("failed to insert product variant into db: %w", err)
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		variant.ID = domain.ProductVariantID(oid.Hex())
+		variant.CreatedAt = mv.CreatedAt
+		variant.UpdatedAt = mv.UpdatedAt
+		r.logger.Info("successfully created product variant", zap.String("variant_id", string(variant.ID)))
+	}
+
+	return nil
+}
+
+func (r *productVariantRepo) Update(ctx context.Context, variant *domain.ProductVariant) error {
+	r.logger.Info("updating product variant", zap.String("variant_id", string(variant.ID)))
+
+	oid, err := toObjectID(string(variant.ID))
+	if err != nil {
+		return domain.ErrVariantNotFound
+	}
+
+	// The stock is left alone: it only moves through AdjustStock, so reservations are never overwritten.
+	variant.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"sku":        variant.SKU,
+			"priceMoney": model.ToMongoMoney(variant.Price),
+			"images":     toMongoImages(variant.Images),
+			"attributes": toMongoAttributes(variant.Attributes),
+			"updatedAt":  variant.UpdatedAt,
+		},
+		"$unset": bson.M{"price": ""},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("product variant sku already exists", zap.String("sku", variant.SKU))
+			return domain.ErrSKUAlreadyExists
+		}
+		r.logger.Error("failed to update product variant", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *productVariantRepo) FindByID(ctx context.Context, id domain.ProductVariantID) (*domain.ProductVariant, error) {
+	r.logger.Info("finding product variant by id", zap.String("variant_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return nil, domain.ErrVariantNotFound
+	}
+
+	var mv model.MongoProductVariant
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&mv); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			r.logger.Warn("product variant not found", zap.String("variant_id", string(id)))
+			return nil, domain.ErrVariantNotFound
+		}
+		r.logger.Error("failed to find product variant by id", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainProductVariant(&mv), nil
+}
+
+func (r *productVariantRepo) FindByProductID(ctx context.Context, id domain.ProductID) ([]*domain.ProductVariant, error) {
+	r.logger.Info("finding product variants by product id", zap.String("product_id", string(id)))
+
+	return r.find(ctx, bson.M{"productId": id})
+}
+
+func (r *productVariantRepo) FindByProductIDs(ctx context.Context, ids []domain.ProductID) ([]*domain.ProductVariant, error) {
+	r.logger.Info("finding product variants by product ids", zap.Int("count", len(ids)))
+
+	return r.find(ctx, bson.M{"productId": bson.M{"$in": ids}})
+}
+
+func (r *productVariantRepo) find(ctx context.Context, filter bson.M) ([]*domain.ProductVariant, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to execute find product variants query", zap.Error(err))
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	variants := make([]*domain.ProductVariant, 0)
+	for cursor.Next(ctx) {
+		var mv model.MongoProductVariant
+		if err := cursor.Decode(&mv); err != nil {
+			r.logger.Error("failed to decode a product variant document", zap.Error(err))
+			continue
+		}
+		variants = append(variants, toDomainProductVariant(&mv))
+	}
+
+	return variants, cursor.Err()
+}
+
+func (r *productVariantRepo) Delete(ctx context.Context, id domain.ProductVariantID) error {
+	r.logger.Info("deleting product variant", zap.String("variant_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrVariantNotFound
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		r.logger.Error("failed to delete product variant", zap.Error(err))
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *productVariantRepo) AdjustStock(ctx context.Context, id domain.ProductVariantID, delta int) error {
+	r.logger.Info("adjusting product variant stock", zap.String("variant_id", string(id)), zap.Int("delta", delta))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrVariantNotFound
+	}
+
+	// The stock guard is part of the filter, so the check and the increment happen in one atomic update.
+	filter := bson.M{"_id": oid}
+	if delta < 0 {
+		filter["stock"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"stock": delta},
+		"$set": bson.M{"updatedAt": time.Now()},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to adjust product variant stock", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return domain.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+func toMongoProductVariant(v *domain.ProductVariant) model.MongoProductVariant {
+	return model.MongoProductVariant{
+		ProductID:  v.ProductID,
+		SKU:        v.SKU,
+		Price:      model.ToMongoMoney(v.Price),
+		Stock:      v.Stock,
+		Images:     toMongoImages(v.Images),
+		Attributes: toMongoAttributes(v.Attributes),
+		CreatedAt:  v.CreatedAt,
+		UpdatedAt:  v.UpdatedAt,
+	}
+}
+
+func toDomainProductVariant(mv *model.MongoProductVariant) *domain.ProductVariant {
+	images := make([]domain.Image, len(mv.Images))
+	for i, img := range mv.Images {
+		images[i] = domain.Image{URL: img.URL, AltText: img.AltText, IsPrimary: img.IsPrimary, Order: img.Order}
+	}
+
+	attributes := make([]domain.Attribute, len(mv.Attributes))
+	for i, attr := range mv.Attributes {
+		attributes[i] = domain.Attribute{Name: attr.Name, Value: attr.Value}
+	}
+
+	return &domain.ProductVariant{
+		ID:         domain.ProductVariantID(mv.ID.Hex()),
+		ProductID:  mv.ProductID,
+		SKU:        mv.SKU,
+		Price:      mv.PriceMoney(),
+		Stock:      mv.Stock,
+		Images:     images,
+		Attributes: attributes,
+		CreatedAt:  mv.CreatedAt,
+		UpdatedAt:  mv.UpdatedAt,
+	}
+}
+
+func toMongoImages(images []domain.Image) []model.MongoImage {
+	result := make([]model.MongoImage, len(images))
+	for i, img := range images {
+		result[i] = model.MongoImage{URL: img.URL, AltText: img.AltText, IsPrimary: img.IsPrimary, Order: img.Order}
+	}
+	return result
+}
+
+func toMongoAttributes(attributes []domain.Attribute) []model.MongoAttribute {
+	result := make([]model.MongoAttribute, len(attributes))
+	for i, attr := range attributes {
+		result[i] = model.MongoAttribute{Name: attr.Name, Value: attr.Value}
+	}
+	return result
+}
@@ -0,0 +1,213 @@
+package mongodb
+
+import (
+	"catalog/internal/adapters/storage/mongodb/model"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+type reviewRepo struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewReviewRepository(db *mongo.Database, logger *zap.Logger) (domain.ReviewRepository, error) {
+	col := db.Collection(model.ReviewsCollection)
+
+	if err := ensureReviewIndexes(context.Background(), col); err != nil {
+		return nil, err
+	}
+
+	return &reviewRepo{
+		collection: col,
+		logger:     logger.Named("mongodb_review_repo"),
+	}, nil
+}
+
+func ensureReviewIndexes(ctx context.Context, collection *mongo.Collection) error {
+	// One review per user and product
+	uniqueIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "productId", Value: 1},
+			{Key: "userId", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := collection.Indexes().CreateOne(ctx, uniqueIndexModel)
+	if err != nil {
+		return fmt.Errorf("failed to create unique index on productId and userId: %w", err)
+	}
+
+	listIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "productId", Value: 1},
+			{Key: "createdAt", Value: -1},
+		},
+	}
+	_, err = collection.Indexes().CreateOne(ctx, listIndexModel)
+	if err != nil {
+		return fmt.Errorf("failed to create index on productId and createdAt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepo) Create(ctx context.Context, review *domain.Review) error {
+	r.logger.Info("creating a new review", zap.String("product_id", string(review.ProductID)), zap.String("user_id", review.UserID))
+
+	mr := model.MongoReview{
+		ProductID: review.ProductID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, mr)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("review already exists", zap.String("product_id", string(review.ProductID)), zap.String("user_id", review.UserID))
+			return domain.ErrReviewAlreadyExists
+		}
+		r.logger.Error("failed to create review", zap.Error(err))
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		review.ID = domain.ReviewID(oid.Hex())
+		review.CreatedAt = mr.CreatedAt
+		review.UpdatedAt = mr.UpdatedAt
+		r.logger.Info("successfully created review", zap.String("review_id", string(review.ID)))
+	}
+
+	return nil
+}
+
+func (r *reviewRepo) Update(ctx context.Context, review *domain.Review) (*domain.Review, error) {
+	r.logger.Info("updating review", zap.String("review_id", string(review.ID)))
+
+	oid, err := toObjectID(string(review.ID))
+	if err != nil {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	review.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"rating":    review.Rating,
+		"comment":   review.Comment,
+		"updatedAt": review.UpdatedAt,
+	}}
+
+	var previous model.MongoReview
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update, opts).Decode(&previous); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrReviewNotFound
+		}
+		r.logger.Error("failed to update review", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainReview(&previous), nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, id domain.ReviewID) (*domain.Review, error) {
+	r.logger.Info("deleting review", zap.String("review_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	var deleted model.MongoReview
+	if err := r.collection.FindOneAndDelete(ctx, bson.M{"_id": oid}).Decode(&deleted); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrReviewNotFound
+		}
+		r.logger.Error("failed to delete review", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainReview(&deleted), nil
+}
+
+func (r *reviewRepo) FindByID(ctx context.Context, id domain.ReviewID) (*domain.Review, error) {
+	r.logger.Info("finding review by id", zap.String("review_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	var mr model.MongoReview
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&mr); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			r.logger.Warn("review not found", zap.String("review_id", string(id)))
+			return nil, domain.ErrReviewNotFound
+		}
+		r.logger.Error("failed to find review by id", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainReview(&mr), nil
+}
+
+func (r *reviewRepo) FindByProductID(ctx context.Context, id domain.ProductID, page, limit int) ([]*domain.Review, int64, error) {
+	r.logger.Info("finding reviews by product id", zap.String("product_id", string(id)))
+
+	filter := bson.M{"productId": id}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("failed to count reviews", zap.Error(err))
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to execute find reviews query", zap.Error(err))
+		return nil, 0, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	reviews := make([]*domain.Review, 0, limit)
+	for cursor.Next(ctx) {
+		var mr model.MongoReview
+		if err := cursor.Decode(&mr); err != nil {
+			r.logger.Error("failed to decode a review document", zap.Error(err))
+			continue
+		}
+		reviews = append(reviews, toDomainReview(&mr))
+	}
+
+	return reviews, total, cursor.Err()
+}
+
+func toDomainReview(mr *model.MongoReview) *domain.Review {
+	return &domain.Review{
+		ID:        domain.ReviewID(mr.ID.Hex()),
+		ProductID: mr.ProductID,
+		UserID:    mr.UserID,
+		Rating:    mr.Rating,
+		Comment:   mr.Comment,
+		CreatedAt: mr.CreatedAt,
+		UpdatedAt: mr.UpdatedAt,
+	}
+}
@@ -0,0 +1,199 @@
+package mongodb
+
+import (
+	"catalog/internal/adapters/storage/mongodb/model"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+type stockReservationRepo struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewStockReservationRepository(db *mongo.Database, logger *zap.Logger) (domain.StockReservationRepository, error) {
+	col := db.Collection(model.StockReservationsCollection)
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "orderId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := col.Indexes().CreateOne(context.Background(), indexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unique index on orderId: %w", err)
+	}
+
+	return &stockReservationRepo{
+		collection: col,
+		logger:     logger.Named("mongodb_stock_reservation_repo"),
+	}, nil
+}
+
+func (r *stockReservationRepo) Create(ctx context.Context, reservation *domain.StockReservation) error {
+	r.logger.Info("creating a new stock reservation", zap.String("order_id", reservation.OrderID))
+
+	items := make([]model.MongoReservedItem, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = model.MongoReservedItem{VariantID: item.VariantID, Quantity: item.Quantity, Pending: !item.Applied, Released: item.Released}
+	}
+
+	mr := model.MongoStockReservation{
+		OrderID:   reservation.OrderID,
+		Items:     items,
+		Status:    reservation.Status,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, mr)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("stock reservation already exists", zap.String("order_id", reservation.OrderID))
+			return domain.ErrReservationAlreadyExists
+		}
+		r.logger.Error("failed to create stock reservation", zap.Error(err))
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		reservation.ID = oid.Hex()
+		reservation.CreatedAt = mr.CreatedAt
+		reservation.UpdatedAt = mr.UpdatedAt
+	}
+
+	return nil
+}
+
+func (r *stockReservationRepo) FindByOrderID(ctx context.Context, orderID string) (*domain.StockReservation, error) {
+	r.logger.Info("finding stock reservation by order id", zap.String("order_id", orderID))
+
+	var mr model.MongoStockReservation
+	if err := r.collection.FindOne(ctx, bson.M{"orderId": orderID}).Decode(&mr); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrReservationNotFound
+		}
+		r.logger.Error("failed to find stock reservation", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainStockReservation(&mr), nil
+}
+
+func (r *stockReservationRepo) UpdateStatus(ctx context.Context, orderID string, from, to domain.ReservationStatus) (bool, error) {
+	r.logger.Info("updating stock reservation status", zap.String("order_id", orderID), zap.String("from", string(from)), zap.String("to", string(to)))
+
+	filter := bson.M{"orderId": orderID, "status": from}
+	update := bson.M{"$set": bson.M{"status": to, "updatedAt": time.Now()}}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to update stock reservation status", zap.Error(err))
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *stockReservationRepo) ClaimItem(ctx context.Context, orderID string, index int) (bool, error) {
+	r.logger.Info("claiming stock reservation item", zap.String("order_id", orderID), zap.Int("index", index))
+
+	pending := fmt.Sprintf("items.%d.pending", index)
+	filter := bson.M{"orderId": orderID, pending: true, fmt.Sprintf("items.%d.released", index): bson.M{"$ne": true}}
+	update := bson.M{"$unset": bson.M{pending: ""}, "$set": bson.M{"updatedAt": time.Now()}}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to claim stock reservation item", zap.Error(err))
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (r *stockReservationRepo) UnclaimItem(ctx context.Context, orderID string, index int) error {
+	r.logger.Info("unclaiming stock reservation item", zap.String("order_id", orderID), zap.Int("index", index))
+
+	pending := fmt.Sprintf("items.%d.pending", index)
+	filter := bson.M{"orderId": orderID}
+	update := bson.M{"$set": bson.M{pending: true, "updatedAt": time.Now()}}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to unclaim stock reservation item", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func (r *stockReservationRepo) ReleaseItem(ctx context.Context, orderID string, index int) (*domain.ReservedItem, error) {
+	r.logger.Info("releasing stock reservation item", zap.String("order_id", orderID), zap.Int("index", index))
+
+	released := fmt.Sprintf("items.%d.released", index)
+	filter := bson.M{"orderId": orderID, "status": domain.ReservationReserved, released: bson.M{"$ne": true}}
+	update := bson.M{"$set": bson.M{released: true, "updatedAt": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var mr model.MongoStockReservation
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&mr); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		r.logger.Error("failed to release stock reservation item", zap.Error(err))
+		return nil, err
+	}
+
+	if index >= len(mr.Items) {
+		return nil, fmt.Errorf("stock reservation of order %s has no item %d", orderID, index)
+	}
+	item := toDomainStockReservation(&mr).Items[index]
+	return &item, nil
+}
+
+func (r *stockReservationRepo) UnreleaseItem(ctx context.Context, orderID string, index int) error {
+	r.logger.Info("unreleasing stock reservation item", zap.String("order_id", orderID), zap.Int("index", index))
+
+	released := fmt.Sprintf("items.%d.released", index)
+	filter := bson.M{"orderId": orderID}
+	update := bson.M{"$unset": bson.M{released: ""}, "$set": bson.M{"updatedAt": time.Now()}}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("failed to unrelease stock reservation item", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrReservationNotFound
+	}
+
+	return nil
+}
+
+func toDomainStockReservation(mr *model.MongoStockReservation) *domain.StockReservation {
+	items := make([]domain.ReservedItem, len(mr.Items))
+	for i, item := range mr.Items {
+		items[i] = domain.ReservedItem{VariantID: item.VariantID, Quantity: item.Quantity, Applied: !item.Pending, Released: item.Released}
+	}
+
+	return &domain.StockReservation{
+		ID:        mr.ID.Hex(),
+		OrderID:   mr.OrderID,
+		Items:     items,
+		Status:    mr.Status,
+		CreatedAt: mr.CreatedAt,
+		UpdatedAt: mr.UpdatedAt,
+	}
+}
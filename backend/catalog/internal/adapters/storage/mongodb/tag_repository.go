@@ -0,0 +1,175 @@
+package mongodb
+
+import (
+	"catalog/internal/adapters/storage/mongodb/model"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.uber.org/zap"
+)
+
+type tagRepo struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+func NewTagRepository(db *mongo.Database, logger *zap.Logger) (domain.TagRepository, error) {
+	col := db.Collection(model.TagsCollection)
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := col.Indexes().CreateOne(context.Background(), indexModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unique index on tag name: %w", err)
+	}
+
+	return &tagRepo{
+		collection: col,
+		logger:     logger.Named("mongodb_tag_repo"),
+	}, nil
+}
+
+func (r *tagRepo) Create(ctx context.Context, tag *domain.Tag) error {
+	r.logger.Info("creating a new tag", zap.String("tag_name", tag.Name))
+
+	mt := model.MongoTag{
+		Name:      tag.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	res, err := r.collection.InsertOne(ctx, mt)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("tag name already exists", zap.String("tag_name", tag.Name))
+			return domain.ErrTagAlreadyExists
+		}
+		r.logger.Error("failed to create tag", zap.Error(err))
+		return err
+	}
+
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		tag.ID = domain.TagID(oid.Hex())
+		tag.CreatedAt = mt.CreatedAt
+		tag.UpdatedAt = mt.UpdatedAt
+		r.logger.Info("successfully created tag", zap.String("tag_id", string(tag.ID)))
+	}
+
+	return nil
+}
+
+func (r *tagRepo) Update(ctx context.Context, tag *domain.Tag) error {
+	r.logger.Info("updating tag", zap.String("tag_id", string(tag.ID)))
+
+	oid, err := toObjectID(string(tag.ID))
+	if err != nil {
+		return domain.ErrTagNotFound
+	}
+
+	tag.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"name":      tag.Name,
+		"updatedAt": tag.UpdatedAt,
+	}}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			r.logger.Warn("tag name already exists", zap.String("tag_name", tag.Name))
+			return domain.ErrTagAlreadyExists
+		}
+		r.logger.Error("failed to update tag", zap.Error(err))
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *tagRepo) Delete(ctx context.Context, id domain.TagID) error {
+	r.logger.Info("deleting tag", zap.String("tag_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return domain.ErrTagNotFound
+	}
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		r.logger.Error("failed to delete tag", zap.Error(err))
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *tagRepo) FindByID(ctx context.Context, id domain.TagID) (*domain.Tag, error) {
+	r.logger.Info("finding tag by id", zap.String("tag_id", string(id)))
+
+	oid, err := toObjectID(string(id))
+	if err != nil {
+		return nil, domain.ErrTagNotFound
+	}
+
+	var mt model.MongoTag
+	if err := r.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&mt); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			r.logger.Warn("tag not found", zap.String("tag_id", string(id)))
+			return nil, domain.ErrTagNotFound
+		}
+		r.logger.Error("failed to find tag by id", zap.Error(err))
+		return nil, err
+	}
+
+	return toDomainTag(&mt), nil
+}
+
+func (r *tagRepo) FindAll(ctx context.Context) ([]*domain.Tag, error) {
+	r.logger.Info("finding all tags")
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		r.logger.Error("failed to execute find all tags query", zap.Error(err))
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	tags := make([]*domain.Tag, 0)
+	for cursor.Next(ctx) {
+		var mt model.MongoTag
+		if err := cursor.Decode(&mt); err != nil {
+			r.logger.Error("failed to decode a tag document", zap.Error(err))
+			continue
+		}
+		tags = append(tags, toDomainTag(&mt))
+	}
+
+	return tags, cursor.Err()
+}
+
+func toDomainTag(mt *model.MongoTag) *domain.Tag {
+	return &domain.Tag{
+		ID:        domain.TagID(mt.ID.Hex()),
+		Name:      mt.Name,
+		CreatedAt: mt.CreatedAt,
+		UpdatedAt: mt.UpdatedAt,
+	}
+}
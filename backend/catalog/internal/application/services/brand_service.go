@@ -0,0 +1,139 @@
+package services
+
+import (
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"io"
+	"pkg/minio"
+
+	"go.uber.org/zap"
+)
+
+const (
+	brandLogoBucket = "media"
+	brandLogoPrefix = "brands"
+)
+
+type brandService struct {
+	brandRepo    domain.BrandRepository
+	minioService *minio.Service
+	logger       *zap.Logger
+}
+
+func NewBrandService(brandRepo domain.BrandRepository, minioService *minio.Service, logger *zap.Logger) BrandService {
+	return &brandService{
+		brandRepo:    brandRepo,
+		minioService: minioService,
+		logger:       logger.Named("brand_service"),
+	}
+}
+
+func (s *brandService) CreateBrand(ctx context.Context, name string) (*domain.Brand, error) {
+	s.logger.Info("creating a new brand", zap.String("name", name))
+
+	if err := s.checkDuplicateBrandName(ctx, name, ""); err != nil {
+		return nil, err
+	}
+
+	newBrand, err := domain.NewBrand(name)
+	if err != nil {
+		s.logger.Warn("failed to create new object brand from domain factory", zap.String("name", name))
+		return nil, err
+	}
+
+	if err := s.brandRepo.Create(ctx, newBrand); err != nil {
+		s.logger.Error("failed to create brand via repository", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("brand created successfully", zap.String("brand_id", string(newBrand.ID)), zap.String("name", newBrand.Name))
+	return newBrand, nil
+}
+
+func (s *brandService) UpdateBrand(ctx context.Context, brand *domain.Brand) error {
+	s.logger.Info("updating brand", zap.String("brand_id", string(brand.ID)))
+
+	if err := s.checkDuplicateBrandName(ctx, brand.Name, brand.ID); err != nil {
+		return err
+	}
+
+	if err := s.brandRepo.Update(ctx, brand); err != nil {
+		s.logger.Error("failed to update brand via repository", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("brand updated successfully", zap.String("brand_id", string(brand.ID)))
+	return nil
+}
+
+func (s *brandService) GetBrand(ctx context.Context, id domain.BrandID) (*domain.Brand, error) {
+	s.logger.Info("getting brand", zap.String("brand_id", string(id)))
+	return s.brandRepo.FindByID(ctx, id)
+}
+
+func (s *brandService) ListBrands(ctx context.Context) ([]*domain.Brand, error) {
+	s.logger.Info("getting all brands")
+	return s.brandRepo.FindAll(ctx)
+}
+
+func (s *brandService) UploadLogo(ctx context.Context, id domain.BrandID, filename string, file io.Reader, size int64, contentType string) (*domain.Brand, error) {
+	s.logger.Info("uploading brand logo", zap.String("brand_id", string(id)), zap.String("filename", filename))
+
+	brand, err := s.brandRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	oldLogo := brand.Logo
+
+	objectKey, err := s.minioService.UploadFile(ctx, brandLogoBucket, brandLogoPrefix, filename, file, size, contentType)
+	if err != nil {
+		s.logger.Error("failed to upload brand logo to MinIO", zap.Error(err))
+		return nil, err
+	}
+
+	brand.Logo = &objectKey
+	if err := s.brandRepo.Update(ctx, brand); err != nil {
+		s.logger.Error("failed to update brand with logo", zap.Error(err))
+		return nil, err
+	}
+
+	if oldLogo != nil {
+		if err := s.minioService.DeleteFile(ctx, brandLogoBucket, *oldLogo); err != nil {
+			s.logger.Error("failed to delete old brand logo", zap.Error(err))
+		}
+	}
+
+	return brand, nil
+}
+
+func (s *brandService) DownloadLogo(ctx context.Context, id domain.BrandID) (io.Reader, error) {
+	s.logger.Info("downloading brand logo", zap.String("brand_id", string(id)))
+
+	brand, err := s.brandRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if brand.Logo == nil {
+		return nil, domain.ErrBrandLogoNotFound
+	}
+
+	return s.minioService.DownloadFile(ctx, brandLogoBucket, *brand.Logo)
+}
+
+func (s *brandService) checkDuplicateBrandName(ctx context.Context, name string, currentID domain.BrandID) error {
+	existing, err := s.brandRepo.FindByName(ctx, name)
+	if err == nil {
+		if existing.ID == currentID {
+			return nil
+		}
+		return domain.ErrBrandAlreadyExists
+	}
+	if !errors.Is(err, domain.ErrBrandNotFound) {
+		s.logger.Error("service: failed to check for duplicate brand name", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
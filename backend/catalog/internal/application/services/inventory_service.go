@@ -0,0 +1,192 @@
+package services
+
+import (
+	"catalog/internal/domain"
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+type inventoryService struct {
+	reservationRepo    domain.StockReservationRepository
+	productVariantRepo domain.ProductVariantRepository
+	logger             *zap.Logger
+}
+
+func NewInventoryService(reservationRepo domain.StockReservationRepository, productVariantRepo domain.ProductVariantRepository, logger *zap.Logger) InventoryService {
+	return &inventoryService{
+		reservationRepo:    reservationRepo,
+		productVariantRepo: productVariantRepo,
+		logger:             logger.Named("inventory_service"),
+	}
+}
+
+func (s *inventoryService) ReserveStock(ctx context.Context, orderID string, items []domain.ReservedItem) (*domain.StockReservation, error) {
+	s.logger.Info("reserving stock", zap.String("order_id", orderID), zap.Int("items", len(items)))
+
+	reservation, err := domain.NewStockReservation(orderID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reservation document is written first with every item pending; its unique order id makes a
+	// retried call pick up the existing reservation, which then applies whatever an earlier call left pending.
+	if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+		if !errors.Is(err, domain.ErrReservationAlreadyExists) {
+			s.logger.Error("failed to create stock reservation via repository", zap.Error(err))
+			return nil, err
+		}
+
+		existing, err := s.reservationRepo.FindByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Released() {
+			return nil, domain.ErrReservationReleased
+		}
+		s.logger.Info("stock already reserved for order", zap.String("order_id", orderID))
+		reservation = existing
+	}
+
+	if err := s.applyReservation(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stock reserved successfully", zap.String("order_id", orderID))
+	return reservation, nil
+}
+
+// applyReservation takes the pending items of a reservation off the variant stock. Each item is claimed
+// before its stock is adjusted, so concurrent calls for the same order never take it twice.
+func (s *inventoryService) applyReservation(ctx context.Context, reservation *domain.StockReservation) error {
+	var applied []int // Items this call took off the stock
+	for i, item := range reservation.Items {
+		if item.Applied {
+			continue
+		}
+
+		claimed, err := s.reservationRepo.ClaimItem(ctx, reservation.OrderID, i)
+		if err != nil {
+			s.rollbackReservation(ctx, reservation, applied)
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.productVariantRepo.AdjustStock(ctx, item.VariantID, -item.Quantity); err != nil {
+			s.logger.Warn("failed to reserve variant stock, rolling back", zap.String("variant_id", string(item.VariantID)), zap.Error(err))
+			s.unclaimItem(ctx, reservation.OrderID, i)
+			s.rollbackReservation(ctx, reservation, applied)
+			return err
+		}
+		reservation.Items[i].Applied = true
+		applied = append(applied, i)
+	}
+
+	return nil
+}
+
+// rollbackReservation restocks the items at the applied indexes and marks them pending again, so a
+// retry can reserve them later. Items other calls for the same order applied are theirs to undo; the
+// reservation itself is kept, as those calls may still be working on it.
+func (s *inventoryService) rollbackReservation(ctx context.Context, reservation *domain.StockReservation, applied []int) {
+	for _, i := range applied {
+		item := reservation.Items[i]
+		if err := s.productVariantRepo.AdjustStock(ctx, item.VariantID, item.Quantity); err != nil {
+			s.logger.Error("failed to restock variant during rollback", zap.String("variant_id", string(item.VariantID)), zap.Error(err))
+			continue
+		}
+		reservation.Items[i].Applied = false
+		s.unclaimItem(ctx, reservation.OrderID, i)
+	}
+}
+
+func (s *inventoryService) unclaimItem(ctx context.Context, orderID string, index int) {
+	if err := s.reservationRepo.UnclaimItem(ctx, orderID, index); err != nil {
+		s.logger.Error("failed to unclaim stock reservation item", zap.String("order_id", orderID), zap.Int("index", index), zap.Error(err))
+	}
+}
+
+func (s *inventoryService) CommitStock(ctx context.Context, orderID string) error {
+	s.logger.Info("committing stock", zap.String("order_id", orderID))
+
+	committed, err := s.reservationRepo.UpdateStatus(ctx, orderID, domain.ReservationReserved, domain.ReservationCommitted)
+	if err != nil {
+		s.logger.Error("failed to mark stock reservation committed", zap.Error(err))
+		return err
+	}
+	if committed {
+		s.logger.Info("stock committed successfully", zap.String("order_id", orderID))
+		return nil
+	}
+
+	reservation, err := s.reservationRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if reservation.Released() {
+		return domain.ErrReservationReleased
+	}
+
+	return nil
+}
+
+func (s *inventoryService) ReleaseStock(ctx context.Context, orderID string) error {
+	s.logger.Info("releasing stock", zap.String("order_id", orderID))
+
+	reservation, err := s.reservationRepo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrReservationNotFound) {
+			s.logger.Info("no stock reservation for order, nothing to release", zap.String("order_id", orderID))
+			return nil
+		}
+		s.logger.Error("failed to find stock reservation via repository", zap.Error(err))
+		return err
+	}
+	if reservation.Status != domain.ReservationReserved {
+		s.logger.Info("stock reservation is not active, nothing to release", zap.String("order_id", orderID), zap.String("status", string(reservation.Status)))
+		return nil
+	}
+
+	// Items are handed back one by one and the reservation is only marked released once all of them are,
+	// so a release that fails half way is retried with the items that are left. Only the caller that
+	// marks an item released restocks it, so a repeated release cannot double the stock.
+	for i := range reservation.Items {
+		if reservation.Items[i].Released {
+			continue
+		}
+
+		item, err := s.reservationRepo.ReleaseItem(ctx, orderID, i)
+		if err != nil {
+			s.logger.Error("failed to mark stock reservation item released", zap.Error(err))
+			return err
+		}
+		// Items still pending were never taken off the stock.
+		if item == nil || !item.Applied {
+			continue
+		}
+
+		if err := s.productVariantRepo.AdjustStock(ctx, item.VariantID, item.Quantity); err != nil {
+			s.logger.Error("failed to restock variant", zap.String("variant_id", string(item.VariantID)), zap.Error(err))
+			if err := s.reservationRepo.UnreleaseItem(ctx, orderID, i); err != nil {
+				s.logger.Error("failed to unrelease stock reservation item", zap.String("order_id", orderID), zap.Int("index", i), zap.Error(err))
+			}
+			return err
+		}
+	}
+
+	released, err := s.reservationRepo.UpdateStatus(ctx, orderID, domain.ReservationReserved, domain.ReservationReleased)
+	if err != nil {
+		s.logger.Error("failed to mark stock reservation released", zap.Error(err))
+		return err
+	}
+	if !released {
+		s.logger.Info("stock reservation was released concurrently", zap.String("order_id", orderID))
+		return nil
+	}
+
+	s.logger.Info("stock released successfully", zap.String("order_id", orderID))
+	return nil
+}
@@ -0,0 +1,365 @@
+package services_test
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+)
+
+var errUnavailable = errors.New("database unavailable")
+
+func newTestInventory(stock map[domain.ProductVariantID]int) (services.InventoryService, *fakeReservationRepo, *fakeVariantRepo) {
+	reservations := &fakeReservationRepo{reservations: make(map[string]*domain.StockReservation)}
+	variants := &fakeVariantRepo{stock: stock, failures: make(map[domain.ProductVariantID]error)}
+	return services.NewInventoryService(reservations, variants, zap.NewNop()), reservations, variants
+}
+
+func TestInventoryService_ReserveTakesTheStock(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5, "v2": 3})
+	ctx := context.Background()
+
+	_, err := inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}})
+	is.NoErr(err)
+
+	is.Equal(variants.get("v1"), 3)
+	is.Equal(variants.get("v2"), 0)
+	reservation := reservations.get("order-1")
+	is.Equal(reservation.Status, domain.ReservationReserved)
+	is.True(reservation.Items[0].Applied && reservation.Items[1].Applied)
+}
+
+func TestInventoryService_RetriedReserveTakesTheStockOnce(t *testing.T) {
+	is := is.New(t)
+	inventory, _, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5})
+	ctx := context.Background()
+
+	items := []domain.ReservedItem{{VariantID: "v1", Quantity: 2}}
+	for i := 0; i < 3; i++ {
+		_, err := inventory.ReserveStock(ctx, "order-1", items)
+		is.NoErr(err)
+	}
+
+	is.Equal(variants.get("v1"), 3)
+}
+
+func TestInventoryService_ConcurrentReservesTakeTheStockOnce(t *testing.T) {
+	is := is.New(t)
+	inventory, _, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 50, "v2": 50})
+	ctx := context.Background()
+
+	items := []domain.ReservedItem{{VariantID: "v1", Quantity: 1}, {VariantID: "v2", Quantity: 2}}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			is := is.NewRelaxed(t)
+			_, err := inventory.ReserveStock(ctx, "order-1", items)
+			is.NoErr(err)
+		}()
+	}
+	wg.Wait()
+
+	is.Equal(variants.get("v1"), 49)
+	is.Equal(variants.get("v2"), 48)
+}
+
+func TestInventoryService_FailedReserveRollsBackAndCanBeRetried(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5, "v2": 1})
+	ctx := context.Background()
+
+	items := []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}}
+	_, err := inventory.ReserveStock(ctx, "order-1", items)
+	is.True(errors.Is(err, domain.ErrInsufficientStock))
+
+	is.Equal(variants.get("v1"), 5) // the first item is restocked
+	is.Equal(variants.get("v2"), 1)
+	reservation := reservations.get("order-1")
+	is.True(reservation != nil) // the reservation is kept for a retry
+	is.True(!reservation.Items[0].Applied && !reservation.Items[1].Applied)
+
+	variants.set("v2", 4)
+	_, err = inventory.ReserveStock(ctx, "order-1", items)
+	is.NoErr(err)
+	is.Equal(variants.get("v1"), 3)
+	is.Equal(variants.get("v2"), 1)
+}
+
+func TestInventoryService_FailedReserveLeavesItemsOfOtherCalls(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 3, "v2": 1})
+	ctx := context.Background()
+
+	// Another call for the order already took the first item off the stock.
+	reservations.put(&domain.StockReservation{
+		OrderID: "order-1",
+		Items:   []domain.ReservedItem{{VariantID: "v1", Quantity: 2, Applied: true}, {VariantID: "v2", Quantity: 3}},
+		Status:  domain.ReservationReserved,
+	})
+
+	_, err := inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}})
+	is.True(errors.Is(err, domain.ErrInsufficientStock))
+
+	is.Equal(variants.get("v1"), 3) // not restocked by the call that did not take it
+	reservation := reservations.get("order-1")
+	is.True(reservation != nil)
+	is.True(reservation.Items[0].Applied)
+	is.True(!reservation.Items[1].Applied)
+}
+
+func TestInventoryService_CommitStock(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5})
+	ctx := context.Background()
+
+	_, err := inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}})
+	is.NoErr(err)
+
+	is.NoErr(inventory.CommitStock(ctx, "order-1"))
+	is.NoErr(inventory.CommitStock(ctx, "order-1")) // committing again is a no-op
+	is.Equal(reservations.get("order-1").Status, domain.ReservationCommitted)
+
+	is.NoErr(inventory.ReleaseStock(ctx, "order-1")) // a committed reservation is not released
+	is.Equal(variants.get("v1"), 3)
+	is.Equal(reservations.get("order-1").Status, domain.ReservationCommitted)
+}
+
+func TestInventoryService_ReleaseRestocksOnce(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5})
+	ctx := context.Background()
+
+	items := []domain.ReservedItem{{VariantID: "v1", Quantity: 2}}
+	_, err := inventory.ReserveStock(ctx, "order-1", items)
+	is.NoErr(err)
+
+	is.NoErr(inventory.ReleaseStock(ctx, "order-1"))
+	is.NoErr(inventory.ReleaseStock(ctx, "order-1"))
+	is.Equal(variants.get("v1"), 5)
+	is.Equal(reservations.get("order-1").Status, domain.ReservationReleased)
+
+	_, err = inventory.ReserveStock(ctx, "order-1", items)
+	is.True(errors.Is(err, domain.ErrReservationReleased)) // a released order cannot be reserved again
+	err = inventory.CommitStock(ctx, "order-1")
+	is.True(errors.Is(err, domain.ErrReservationReleased)) // nor committed
+}
+
+func TestInventoryService_FailedReleaseIsRetriedWithTheItemsLeft(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5, "v2": 5})
+	ctx := context.Background()
+
+	_, err := inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}})
+	is.NoErr(err)
+
+	variants.failNext("v2", errUnavailable)
+	err = inventory.ReleaseStock(ctx, "order-1")
+	is.True(errors.Is(err, errUnavailable))
+	is.Equal(variants.get("v1"), 5)
+	is.Equal(variants.get("v2"), 2)
+	reservation := reservations.get("order-1")
+	is.Equal(reservation.Status, domain.ReservationReserved) // not released before every item is
+	is.True(reservation.Items[0].Released)
+	is.True(!reservation.Items[1].Released)
+
+	_, err = inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}})
+	is.True(errors.Is(err, domain.ErrReservationReleased)) // a half released order cannot be reserved again
+
+	is.NoErr(inventory.ReleaseStock(ctx, "order-1"))
+	is.Equal(variants.get("v1"), 5) // restocked once
+	is.Equal(variants.get("v2"), 5)
+	is.Equal(reservations.get("order-1").Status, domain.ReservationReleased)
+}
+
+func TestInventoryService_ReleaseSkipsItemsNeverTaken(t *testing.T) {
+	is := is.New(t)
+	inventory, reservations, variants := newTestInventory(map[domain.ProductVariantID]int{"v1": 5, "v2": 1})
+	ctx := context.Background()
+
+	_, err := inventory.ReserveStock(ctx, "order-1", []domain.ReservedItem{{VariantID: "v1", Quantity: 2}, {VariantID: "v2", Quantity: 3}})
+	is.True(errors.Is(err, domain.ErrInsufficientStock))
+
+	is.NoErr(inventory.ReleaseStock(ctx, "order-1"))
+	is.Equal(variants.get("v1"), 5)
+	is.Equal(variants.get("v2"), 1)
+	is.Equal(reservations.get("order-1").Status, domain.ReservationReleased)
+}
+
+func TestInventoryService_ReleaseWithoutReservation(t *testing.T) {
+	is := is.New(t)
+	inventory, _, _ := newTestInventory(map[domain.ProductVariantID]int{})
+
+	is.NoErr(inventory.ReleaseStock(context.Background(), "order-1"))
+}
+
+// fakeReservationRepo keeps reservations in memory with the same conditional updates as the MongoDB one.
+type fakeReservationRepo struct {
+	mu           sync.Mutex
+	reservations map[string]*domain.StockReservation // By order id
+}
+
+func copyReservation(r *domain.StockReservation) *domain.StockReservation {
+	c := *r
+	c.Items = append([]domain.ReservedItem(nil), r.Items...)
+	return &c
+}
+
+func (r *fakeReservationRepo) get(orderID string) *domain.StockReservation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if reservation, ok := r.reservations[orderID]; ok {
+		return copyReservation(reservation)
+	}
+	return nil
+}
+
+func (r *fakeReservationRepo) put(reservation *domain.StockReservation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reservations[reservation.OrderID] = copyReservation(reservation)
+}
+
+func (r *fakeReservationRepo) Create(_ context.Context, reservation *domain.StockReservation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.reservations[reservation.OrderID]; ok {
+		return domain.ErrReservationAlreadyExists
+	}
+	r.reservations[reservation.OrderID] = copyReservation(reservation)
+	return nil
+}
+
+func (r *fakeReservationRepo) FindByOrderID(_ context.Context, orderID string) (*domain.StockReservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return nil, domain.ErrReservationNotFound
+	}
+	return copyReservation(reservation), nil
+}
+
+func (r *fakeReservationRepo) UpdateStatus(_ context.Context, orderID string, from, to domain.ReservationStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok || reservation.Status != from {
+		return false, nil
+	}
+	reservation.Status = to
+	return true, nil
+}
+
+func (r *fakeReservationRepo) ClaimItem(_ context.Context, orderID string, index int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok || reservation.Items[index].Applied || reservation.Items[index].Released {
+		return false, nil
+	}
+	reservation.Items[index].Applied = true
+	return true, nil
+}
+
+func (r *fakeReservationRepo) UnclaimItem(_ context.Context, orderID string, index int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return domain.ErrReservationNotFound
+	}
+	reservation.Items[index].Applied = false
+	return nil
+}
+
+func (r *fakeReservationRepo) ReleaseItem(_ context.Context, orderID string, index int) (*domain.ReservedItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok || reservation.Status != domain.ReservationReserved || reservation.Items[index].Released {
+		return nil, nil
+	}
+	before := reservation.Items[index]
+	reservation.Items[index].Released = true
+	return &before, nil
+}
+
+func (r *fakeReservationRepo) UnreleaseItem(_ context.Context, orderID string, index int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reservation, ok := r.reservations[orderID]
+	if !ok {
+		return domain.ErrReservationNotFound
+	}
+	reservation.Items[index].Released = false
+	return nil
+}
+
+// fakeVariantRepo keeps variant stock in memory and refuses to take it below zero, like the MongoDB one.
+type fakeVariantRepo struct {
+	domain.ProductVariantRepository
+	mu       sync.Mutex
+	stock    map[domain.ProductVariantID]int
+	variants []*domain.ProductVariant // Oldest first
+	failures map[domain.ProductVariantID]error
+}
+
+func (r *fakeVariantRepo) get(id domain.ProductVariantID) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stock[id]
+}
+
+func (r *fakeVariantRepo) set(id domain.ProductVariantID, stock int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stock[id] = stock
+}
+
+func (r *fakeVariantRepo) FindByProductIDs(_ context.Context, ids []domain.ProductID) ([]*domain.ProductVariant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var variants []*domain.ProductVariant
+	for _, v := range r.variants {
+		for _, id := range ids {
+			if v.ProductID == id {
+				variants = append(variants, v)
+				break
+			}
+		}
+	}
+	return variants, nil
+}
+
+// failNext makes the next stock adjustment of the variant fail with err.
+func (r *fakeVariantRepo) failNext(id domain.ProductVariantID, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures[id] = err
+}
+
+func (r *fakeVariantRepo) AdjustStock(_ context.Context, id domain.ProductVariantID, delta int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err, ok := r.failures[id]; ok {
+		delete(r.failures, id)
+		return err
+	}
+	stock, ok := r.stock[id]
+	if !ok {
+		return domain.ErrVariantNotFound
+	}
+	if stock+delta < 0 {
+		return domain.ErrInsufficientStock
+	}
+	r.stock[id] = stock + delta
+	return nil
+}
@@ -8,13 +8,34 @@ import (
 	"go.uber.org/zap"
 )
 
+// PriceLookup identifies a purchasable item. An empty VariantID selects the product's default (first) variant.
+type PriceLookup struct {
+	ProductID domain.ProductID
+	VariantID domain.ProductVariantID
+}
+
+// ItemPrice is the resolved product and variant for a PriceLookup; Variant is nil when nothing sellable was found.
+type ItemPrice struct {
+	Product *domain.Product
+	Variant *domain.ProductVariant
+}
+
 type productService struct {
-	productRepo domain.ProductRepository
-	logger      *zap.Logger
+	productRepo        domain.ProductRepository
+	productVariantRepo domain.ProductVariantRepository
+	categoryRepo       domain.CategoryRepository
+	brandRepo          domain.BrandRepository
+	logger             *zap.Logger
 }
 
-func NewProductService(productRepo domain.ProductRepository, logger *zap.Logger) ProductService {
-	return &productService{productRepo: productRepo, logger: logger.Named("product_service")}
+func NewProductService(productRepo domain.ProductRepository, productVariantRepo domain.ProductVariantRepository, categoryRepo domain.CategoryRepository, brandRepo domain.BrandRepository, logger *zap.Logger) ProductService {
+	return &productService{
+		productRepo:        productRepo,
+		productVariantRepo: productVariantRepo,
+		categoryRepo:       categoryRepo,
+		brandRepo:          brandRepo,
+		logger:             logger.Named("product_service"),
+	}
 }
 
 func (s *productService) GetProduct(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
@@ -43,11 +64,21 @@ func (s *productService) FindAllProducts(ctx context.Context, filterQuery bson.M
 	return products, total, nil
 }
 
-func (s *productService) CreateProduct(ctx context.Context, name string) (*domain.Product, error) {
-	s.logger.Info("creating a new product", zap.String("name", name))
+func (s *productService) CreateProduct(ctx context.Context, name, description string, categoryID domain.CategoryID, brandID *domain.BrandID, isPublished bool) (*domain.Product, error) {
+	s.logger.Info("creating a new product", zap.String("name", name), zap.String("category_id", string(categoryID)))
+
+	if err := s.validateCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateBrand(ctx, brandID); err != nil {
+		return nil, err
+	}
 
-	newProduct := &domain.Product{
-		Name: name,
+	newProduct, err := domain.NewProduct(name, description, categoryID, brandID, isPublished)
+	if err != nil {
+		s.logger.Warn("failed to create new object product from domain factory", zap.String("name", name))
+		return nil, err
 	}
 
 	if err := s.productRepo.Create(ctx, newProduct); err != nil {
@@ -58,3 +89,126 @@ func (s *productService) CreateProduct(ctx context.Context, name string) (*domai
 	s.logger.Info("successfully created a new product", zap.String("product_id", string(newProduct.ID)))
 	return newProduct, nil
 }
+
+func (s *productService) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	s.logger.Info("updating product", zap.String("product_id", string(product.ID)))
+
+	if err := s.validateCategory(ctx, product.CategoryID); err != nil {
+		return err
+	}
+
+	if err := s.validateBrand(ctx, product.BrandID); err != nil {
+		return err
+	}
+
+	if err := s.productRepo.Update(ctx, product); err != nil {
+		s.logger.Error("failed to update product via repository", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("product updated successfully", zap.String("product_id", string(product.ID)))
+	return nil
+}
+
+// validateCategory mirrors the ErrCategoryHasProducts rule: products may only live in leaf categories.
+func (s *productService) validateCategory(ctx context.Context, categoryID domain.CategoryID) error {
+	if _, err := s.categoryRepo.FindByID(ctx, categoryID); err != nil {
+		s.logger.Warn("category not found for product", zap.String("category_id", string(categoryID)))
+		return err
+	}
+
+	hasChildren, err := s.categoryRepo.HasChildren(ctx, categoryID)
+	if err != nil {
+		s.logger.Error("failed to check for sub-categories", zap.Error(err))
+		return err
+	}
+
+	if hasChildren {
+		s.logger.Warn("attempted to add product to a non-leaf category", zap.String("category_id", string(categoryID)))
+		return domain.ErrCategoryNotLeaf
+	}
+
+	return nil
+}
+
+func (s *productService) validateBrand(ctx context.Context, brandID *domain.BrandID) error {
+	if brandID == nil {
+		return nil
+	}
+
+	if _, err := s.brandRepo.FindByID(ctx, *brandID); err != nil {
+		s.logger.Warn("brand not found for product", zap.String("brand_id", string(*brandID)))
+		return err
+	}
+
+	return nil
+}
+
+func (s *productService) ResolvePrices(ctx context.Context, lookups []PriceLookup) ([]ItemPrice, error) {
+	s.logger.Info("resolving prices", zap.Int("count", len(lookups)))
+
+	productIDs := make([]domain.ProductID, 0, len(lookups))
+	for _, lookup := range lookups {
+		productIDs = append(productIDs, lookup.ProductID)
+	}
+
+	products, err := s.productRepo.FindByIDs(ctx, productIDs)
+	if err != nil {
+		s.logger.Error("failed to find products from repository", zap.Error(err))
+		return nil, err
+	}
+
+	productsByID := make(map[domain.ProductID]*domain.Product, len(products))
+	for _, p := range products {
+		productsByID[p.ID] = p
+	}
+
+	// The variants of all the products are loaded at once instead of one query per line.
+	variants, err := s.productVariantRepo.FindByProductIDs(ctx, productIDs)
+	if err != nil {
+		s.logger.Error("failed to find product variants from repository", zap.Error(err))
+		return nil, err
+	}
+
+	variantsByProduct := make(map[domain.ProductID][]*domain.ProductVariant, len(products))
+	for _, v := range variants {
+		variantsByProduct[v.ProductID] = append(variantsByProduct[v.ProductID], v)
+	}
+
+	prices := make([]ItemPrice, len(lookups))
+	for i, lookup := range lookups {
+		product, ok := productsByID[lookup.ProductID]
+		if !ok {
+			s.logger.Warn("product not found while resolving price", zap.String("product_id", string(lookup.ProductID)))
+			continue
+		}
+		prices[i].Product = product
+
+		variant := resolveVariant(variantsByProduct[lookup.ProductID], lookup.VariantID)
+		if variant == nil {
+			s.logger.Warn("variant not found while resolving price", zap.String("product_id", string(lookup.ProductID)), zap.String("variant_id", string(lookup.VariantID)))
+			continue
+		}
+		prices[i].Variant = variant
+	}
+
+	return prices, nil
+}
+
+// resolveVariant picks the requested variant among the product's variants, or the oldest one when no
+// variant is requested. It returns nil if there is no such variant.
+func resolveVariant(variants []*domain.ProductVariant, id domain.ProductVariantID) *domain.ProductVariant {
+	if id == "" {
+		if len(variants) == 0 {
+			return nil
+		}
+		return variants[0]
+	}
+
+	for _, v := range variants {
+		if v.ID == id {
+			return v
+		}
+	}
+	return nil
+}
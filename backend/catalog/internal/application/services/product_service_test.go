@@ -0,0 +1,104 @@
+package services_test
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"pkg/money"
+	"testing"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+)
+
+func newTestProductService(products []*domain.Product, variants []*domain.ProductVariant) (services.ProductService, *fakeProductRepo) {
+	productRepo := &fakeProductRepo{products: products}
+	variantRepo := &fakeVariantRepo{variants: variants}
+	return services.NewProductService(productRepo, variantRepo, nil, nil, zap.NewNop()), productRepo
+}
+
+func variant(id, productID, price string) *domain.ProductVariant {
+	return &domain.ProductVariant{ID: domain.ProductVariantID(id), ProductID: domain.ProductID(productID), SKU: "SKU-" + id, Price: money.MustParse(price, "USD"), Stock: 5}
+}
+
+func TestProductService_ResolvePrices(t *testing.T) {
+	is := is.New(t)
+	products := []*domain.Product{
+		{ID: "p1", Name: "Sneakers", IsPublished: true},
+		{ID: "p2", Name: "Draft", IsPublished: false},
+	}
+	// Variants are stored oldest first.
+	variants := []*domain.ProductVariant{variant("v1", "p1", "50.00"), variant("v2", "p1", "55.00"), variant("v3", "p2", "9.99")}
+	service, productRepo := newTestProductService(products, variants)
+
+	prices, err := service.ResolvePrices(context.Background(), []services.PriceLookup{
+		{ProductID: "p1", VariantID: "v2"},
+		{ProductID: "p1"},
+		{ProductID: "p1", VariantID: "v3"},
+		{ProductID: "p9"},
+		{ProductID: "p2"},
+	})
+	is.NoErr(err)
+	is.Equal(len(prices), 5) // one price per lookup, in order
+
+	is.Equal(prices[0].Product.ID, domain.ProductID("p1"))
+	is.Equal(prices[0].Variant.ID, domain.ProductVariantID("v2"))
+
+	is.Equal(prices[1].Variant.ID, domain.ProductVariantID("v1")) // no variant asked for falls back to the oldest
+
+	is.True(prices[2].Product != nil)
+	is.True(prices[2].Variant == nil) // a variant of another product is not sold under this one
+
+	is.True(prices[3].Product == nil) // a missing product resolves to nothing
+	is.True(prices[3].Variant == nil)
+
+	is.True(prices[4].Variant != nil)
+	is.True(!prices[4].Product.IsPublished) // unpublished products are resolved, for the caller to refuse
+
+	is.Equal(productRepo.lookups, 1) // products are loaded in one query
+}
+
+func TestProductService_ResolvePricesOfAProductWithoutVariants(t *testing.T) {
+	is := is.New(t)
+	service, _ := newTestProductService([]*domain.Product{{ID: "p1", IsPublished: true}}, nil)
+
+	prices, err := service.ResolvePrices(context.Background(), []services.PriceLookup{{ProductID: "p1"}})
+	is.NoErr(err)
+	is.True(prices[0].Product != nil)
+	is.True(prices[0].Variant == nil)
+}
+
+func TestProductService_ResolvePricesFailsWithTheRepository(t *testing.T) {
+	is := is.New(t)
+	service, productRepo := newTestProductService(nil, nil)
+	productRepo.err = errUnavailable
+
+	_, err := service.ResolvePrices(context.Background(), []services.PriceLookup{{ProductID: "p1"}})
+	is.True(errors.Is(err, errUnavailable))
+}
+
+// fakeProductRepo finds products in memory and counts the lookups made.
+type fakeProductRepo struct {
+	domain.ProductRepository
+	products []*domain.Product
+	lookups  int
+	err      error
+}
+
+func (r *fakeProductRepo) FindByIDs(_ context.Context, ids []domain.ProductID) ([]*domain.Product, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	var products []*domain.Product
+	for _, p := range r.products {
+		for _, id := range ids {
+			if p.ID == id {
+				products = append(products, p)
+				break
+			}
+		}
+	}
+	return products, nil
+}
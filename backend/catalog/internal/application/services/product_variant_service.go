@@ -0,0 +1,123 @@
+package services
+
+import (
+	"catalog/internal/domain"
+	"context"
+	"pkg/money"
+
+	"go.uber.org/zap"
+)
+
+type productVariantService struct {
+	productVariantRepo domain.ProductVariantRepository
+	productRepo        domain.ProductRepository
+	logger             *zap.Logger
+}
+
+func NewProductVariantService(productVariantRepo domain.ProductVariantRepository, productRepo domain.ProductRepository, logger *zap.Logger) ProductVariantService {
+	return &productVariantService{
+		productVariantRepo: productVariantRepo,
+		productRepo:        productRepo,
+		logger:             logger.Named("product_variant_service"),
+	}
+}
+
+func (s *productVariantService) CreateVariant(ctx context.Context, productID domain.ProductID, sku string, price money.Money, stock int, attributes []domain.Attribute) (*domain.ProductVariant, error) {
+	s.logger.Info("creating a new product variant", zap.String("product_id", string(productID)), zap.String("sku", sku))
+
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		s.logger.Warn("product not found for new variant", zap.String("product_id", string(productID)))
+		return nil, err
+	}
+
+	newVariant, err := domain.NewProductVariant(productID, sku, price, stock, attributes)
+	if err != nil {
+		s.logger.Warn("failed to create new object product variant from domain factory", zap.String("sku", sku))
+		return nil, err
+	}
+
+	if err := s.productVariantRepo.Create(ctx, newVariant); err != nil {
+		s.logger.Error("failed to create product variant via repository", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("product variant created successfully", zap.String("variant_id", string(newVariant.ID)))
+	return newVariant, nil
+}
+
+func (s *productVariantService) UpdateVariant(ctx context.Context, variant *domain.ProductVariant) error {
+	s.logger.Info("updating product variant", zap.String("variant_id", string(variant.ID)))
+
+	if err := s.productVariantRepo.Update(ctx, variant); err != nil {
+		s.logger.Error("failed to update product variant via repository", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("product variant updated successfully", zap.String("variant_id", string(variant.ID)))
+	return nil
+}
+
+// SetVariantStock moves the stock of variant to stock by the difference to the stock it was read with,
+// so units reserved for orders in the meantime stay reserved.
+func (s *productVariantService) SetVariantStock(ctx context.Context, variant *domain.ProductVariant, stock int) error {
+	s.logger.Info("setting product variant stock", zap.String("variant_id", string(variant.ID)), zap.Int("stock", stock))
+
+	delta := stock - variant.Stock
+	if delta == 0 {
+		return nil
+	}
+
+	if err := s.productVariantRepo.AdjustStock(ctx, variant.ID, delta); err != nil {
+		s.logger.Error("failed to adjust product variant stock via repository", zap.Error(err))
+		return err
+	}
+
+	variant.Stock = stock
+	s.logger.Info("product variant stock adjusted successfully", zap.String("variant_id", string(variant.ID)), zap.Int("delta", delta))
+	return nil
+}
+
+func (s *productVariantService) DeleteVariant(ctx context.Context, productID domain.ProductID, id domain.ProductVariantID) error {
+	s.logger.Info("deleting product variant", zap.String("product_id", string(productID)), zap.String("variant_id", string(id)))
+
+	if _, err := s.GetVariant(ctx, productID, id); err != nil {
+		return err
+	}
+
+	if err := s.productVariantRepo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete product variant via repository", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("product variant deleted successfully", zap.String("variant_id", string(id)))
+	return nil
+}
+
+func (s *productVariantService) GetVariant(ctx context.Context, productID domain.ProductID, id domain.ProductVariantID) (*domain.ProductVariant, error) {
+	s.logger.Info("getting product variant", zap.String("product_id", string(productID)), zap.String("variant_id", string(id)))
+
+	variant, err := s.productVariantRepo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Warn("failed to find product variant", zap.String("variant_id", string(id)))
+		return nil, err
+	}
+
+	// A variant is only addressable through the product that owns it
+	if variant.ProductID != productID {
+		s.logger.Warn("product variant does not belong to product", zap.String("product_id", string(productID)), zap.String("variant_id", string(id)))
+		return nil, domain.ErrVariantNotFound
+	}
+
+	return variant, nil
+}
+
+func (s *productVariantService) ListVariants(ctx context.Context, productID domain.ProductID) ([]*domain.ProductVariant, error) {
+	s.logger.Info("listing product variants", zap.String("product_id", string(productID)))
+
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		s.logger.Warn("product not found for listing variants", zap.String("product_id", string(productID)))
+		return nil, err
+	}
+
+	return s.productVariantRepo.FindByProductID(ctx, productID)
+}
@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+)
+
+func TestProductVariantService_SetStockKeepsReservedUnits(t *testing.T) {
+	is := is.New(t)
+	variants := &fakeVariantRepo{stock: map[domain.ProductVariantID]int{"v1": 5}, failures: make(map[domain.ProductVariantID]error)}
+	service := services.NewProductVariantService(variants, nil, zap.NewNop())
+	ctx := context.Background()
+
+	variant := &domain.ProductVariant{ID: "v1", ProductID: "p1", Stock: 5}
+	variants.set("v1", 1) // 4 units reserved for orders after the variant was read
+
+	is.NoErr(service.SetVariantStock(ctx, variant, 8))
+	is.Equal(variants.get("v1"), 4) // moved by +3, the reserved units stay reserved
+	is.Equal(variant.Stock, 8)
+}
+
+func TestProductVariantService_SetStockRefusesToGoBelowZero(t *testing.T) {
+	is := is.New(t)
+	variants := &fakeVariantRepo{stock: map[domain.ProductVariantID]int{"v1": 5}, failures: make(map[domain.ProductVariantID]error)}
+	service := services.NewProductVariantService(variants, nil, zap.NewNop())
+	ctx := context.Background()
+
+	variant := &domain.ProductVariant{ID: "v1", ProductID: "p1", Stock: 5}
+	variants.set("v1", 1) // 4 units reserved for orders after the variant was read
+
+	err := service.SetVariantStock(ctx, variant, 0)
+	is.True(errors.Is(err, domain.ErrInsufficientStock)) // -5 would leave -4 in stock
+	is.Equal(variants.get("v1"), 1)                      // untouched
+	is.Equal(variant.Stock, 5)
+
+	is.NoErr(service.SetVariantStock(ctx, variant, 5)) // no change needs no adjustment
+	is.Equal(variants.get("v1"), 1)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"catalog/internal/domain"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type reviewService struct {
+	reviewRepo       domain.ReviewRepository
+	productRepo      domain.ProductRepository
+	purchaseVerifier domain.PurchaseVerifier
+	logger           *zap.Logger
+}
+
+func NewReviewService(reviewRepo domain.ReviewRepository, productRepo domain.ProductRepository, purchaseVerifier domain.PurchaseVerifier, logger *zap.Logger) ReviewService {
+	return &reviewService{
+		reviewRepo:       reviewRepo,
+		productRepo:      productRepo,
+		purchaseVerifier: purchaseVerifier,
+		logger:           logger.Named("review_service"),
+	}
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, userID string, productID domain.ProductID, rating int, comment string) (*domain.Review, error) {
+	s.logger.Info("creating a new review", zap.String("user_id", userID), zap.String("product_id", string(productID)))
+
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	purchased, err := s.purchaseVerifier.HasPurchased(ctx, userID, productID)
+	if err != nil {
+		s.logger.Error("failed to verify product purchase", zap.Error(err))
+		return nil, err
+	}
+	if !purchased {
+		s.logger.Warn("user has not purchased product", zap.String("user_id", userID), zap.String("product_id", string(productID)))
+		return nil, domain.ErrReviewNotAllowed
+	}
+
+	newReview, err := domain.NewReview(productID, userID, rating, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepo.Create(ctx, newReview); err != nil {
+		s.logger.Error("failed to create review via repository", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.productRepo.UpdateRating(ctx, productID, newReview.Rating, 1); err != nil {
+		s.logger.Error("failed to update product rating", zap.String("product_id", string(productID)), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("review created successfully", zap.String("review_id", string(newReview.ID)))
+	return newReview, nil
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, userID string, productID domain.ProductID, id domain.ReviewID, rating *int, comment *string) (*domain.Review, error) {
+	s.logger.Info("updating review", zap.String("user_id", userID), zap.String("review_id", string(id)))
+
+	review, err := s.findOwnReview(ctx, userID, productID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rating != nil {
+		if *rating < domain.MinReviewRating || *rating > domain.MaxReviewRating {
+			return nil, domain.ErrInvalidRating
+		}
+		review.Rating = *rating
+	}
+	if comment != nil {
+		review.Comment = *comment
+	}
+
+	// The delta comes from the review the write replaced, not the one read above, so concurrent edits of
+	// the same review keep the product's rating sum in step.
+	previous, err := s.reviewRepo.Update(ctx, review)
+	if err != nil {
+		s.logger.Error("failed to update review via repository", zap.Error(err))
+		return nil, err
+	}
+
+	if review.Rating != previous.Rating {
+		if err := s.productRepo.UpdateRating(ctx, productID, review.Rating-previous.Rating, 0); err != nil {
+			s.logger.Error("failed to update product rating", zap.String("product_id", string(productID)), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	s.logger.Info("review updated successfully", zap.String("review_id", string(review.ID)))
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, userID string, productID domain.ProductID, id domain.ReviewID) error {
+	s.logger.Info("deleting review", zap.String("user_id", userID), zap.String("review_id", string(id)))
+
+	review, err := s.findOwnReview(ctx, userID, productID, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.reviewRepo.Delete(ctx, review.ID)
+	if err != nil {
+		s.logger.Error("failed to delete review via repository", zap.Error(err))
+		return err
+	}
+
+	if err := s.productRepo.UpdateRating(ctx, productID, -deleted.Rating, -1); err != nil {
+		s.logger.Error("failed to update product rating", zap.String("product_id", string(productID)), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("review deleted successfully", zap.String("review_id", string(review.ID)))
+	return nil
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, productID domain.ProductID, page, limit int) ([]*domain.Review, int64, error) {
+	s.logger.Info("listing reviews", zap.String("product_id", string(productID)))
+
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.reviewRepo.FindByProductID(ctx, productID, page, limit)
+}
+
+func (s *reviewService) findOwnReview(ctx context.Context, userID string, productID domain.ProductID, id domain.ReviewID) (*domain.Review, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.ProductID != productID {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	if review.UserID != userID {
+		s.logger.Warn("user attempted to modify another user's review", zap.String("user_id", userID), zap.String("review_id", string(id)))
+		return nil, domain.ErrReviewForbidden
+	}
+
+	return review, nil
+}
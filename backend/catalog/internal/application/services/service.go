@@ -3,30 +3,59 @@ package services
 import (
 	"catalog/internal/domain"
 	"context"
+	"io"
+	"pkg/minio"
+	"pkg/money"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.uber.org/zap"
 )
 
 type Service struct {
-	ProductService  ProductService
-	CategoryService CategoryService
+	ProductService        ProductService
+	ProductVariantService ProductVariantService
+	CategoryService       CategoryService
+	BrandService          BrandService
+	ReviewService         ReviewService
+	TagService            TagService
+	InventoryService      InventoryService
 }
 
-func NewService(productRepo domain.ProductRepository, categoryRepo domain.CategoryRepository, logger *zap.Logger) *Service {
-	productSvc := NewProductService(productRepo, logger)
+func NewService(productRepo domain.ProductRepository, productVariantRepo domain.ProductVariantRepository, categoryRepo domain.CategoryRepository, brandRepo domain.BrandRepository, reviewRepo domain.ReviewRepository, tagRepo domain.TagRepository, reservationRepo domain.StockReservationRepository, purchaseVerifier domain.PurchaseVerifier, minioService *minio.Service, logger *zap.Logger) *Service {
+	productSvc := NewProductService(productRepo, productVariantRepo, categoryRepo, brandRepo, logger)
+	productVariantSvc := NewProductVariantService(productVariantRepo, productRepo, logger)
 	categorySvc := NewCategoryService(categoryRepo, productRepo, logger)
+	brandSvc := NewBrandService(brandRepo, minioService, logger)
+	reviewSvc := NewReviewService(reviewRepo, productRepo, purchaseVerifier, logger)
+	tagSvc := NewTagService(tagRepo, productRepo, logger)
+	inventorySvc := NewInventoryService(reservationRepo, productVariantRepo, logger)
 
 	return &Service{
-		ProductService:  productSvc,
-		CategoryService: categorySvc,
+		ProductService:        productSvc,
+		ProductVariantService: productVariantSvc,
+		CategoryService:       categorySvc,
+		BrandService:          brandSvc,
+		ReviewService:         reviewSvc,
+		TagService:            tagSvc,
+		InventoryService:      inventorySvc,
 	}
 }
 
 type ProductService interface {
 	GetProduct(ctx context.Context, id domain.ProductID) (*domain.Product, error)
 	FindAllProducts(ctx context.Context, filterQuery bson.M, sortOptions bson.D, page, limit int) ([]*domain.Product, int64, error)
-	CreateProduct(ctx context.Context, name string) (*domain.Product, error)
+	CreateProduct(ctx context.Context, name, description string, categoryID domain.CategoryID, brandID *domain.BrandID, isPublished bool) (*domain.Product, error)
+	UpdateProduct(ctx context.Context, product *domain.Product) error
+	ResolvePrices(ctx context.Context, lookups []PriceLookup) ([]ItemPrice, error)
+}
+
+type ProductVariantService interface {
+	CreateVariant(ctx context.Context, productID domain.ProductID, sku string, price money.Money, stock int, attributes []domain.Attribute) (*domain.ProductVariant, error)
+	UpdateVariant(ctx context.Context, variant *domain.ProductVariant) error
+	SetVariantStock(ctx context.Context, variant *domain.ProductVariant, stock int) error
+	DeleteVariant(ctx context.Context, productID domain.ProductID, id domain.ProductVariantID) error
+	GetVariant(ctx context.Context, productID domain.ProductID, id domain.ProductVariantID) (*domain.ProductVariant, error)
+	ListVariants(ctx context.Context, productID domain.ProductID) ([]*domain.ProductVariant, error)
 }
 
 type CategoryService interface {
@@ -36,3 +65,35 @@ type CategoryService interface {
 	FindByID(ctx context.Context, id domain.CategoryID) (*domain.Category, error)
 	FindBySlug(ctx context.Context, slug string) (*domain.Category, error)
 }
+
+type BrandService interface {
+	CreateBrand(ctx context.Context, name string) (*domain.Brand, error)
+	UpdateBrand(ctx context.Context, brand *domain.Brand) error
+	GetBrand(ctx context.Context, id domain.BrandID) (*domain.Brand, error)
+	ListBrands(ctx context.Context) ([]*domain.Brand, error)
+	UploadLogo(ctx context.Context, id domain.BrandID, filename string, file io.Reader, size int64, contentType string) (*domain.Brand, error)
+	DownloadLogo(ctx context.Context, id domain.BrandID) (io.Reader, error)
+}
+
+type TagService interface {
+	CreateTag(ctx context.Context, name string) (*domain.Tag, error)
+	UpdateTag(ctx context.Context, tag *domain.Tag) error
+	DeleteTag(ctx context.Context, id domain.TagID) error
+	GetTag(ctx context.Context, id domain.TagID) (*domain.Tag, error)
+	ListTags(ctx context.Context) ([]*domain.Tag, error)
+	AttachTags(ctx context.Context, productID domain.ProductID, tagIDs []domain.TagID) (*domain.Product, error)
+	DetachTag(ctx context.Context, productID domain.ProductID, tagID domain.TagID) (*domain.Product, error)
+}
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, userID string, productID domain.ProductID, rating int, comment string) (*domain.Review, error)
+	UpdateReview(ctx context.Context, userID string, productID domain.ProductID, id domain.ReviewID, rating *int, comment *string) (*domain.Review, error)
+	DeleteReview(ctx context.Context, userID string, productID domain.ProductID, id domain.ReviewID) error
+	ListReviews(ctx context.Context, productID domain.ProductID, page, limit int) ([]*domain.Review, int64, error)
+}
+
+type InventoryService interface {
+	ReserveStock(ctx context.Context, orderID string, items []domain.ReservedItem) (*domain.StockReservation, error)
+	CommitStock(ctx context.Context, orderID string) error
+	ReleaseStock(ctx context.Context, orderID string) error
+}
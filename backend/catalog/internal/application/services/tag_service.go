@@ -0,0 +1,108 @@
+package services
+
+import (
+	"catalog/internal/domain"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type tagService struct {
+	tagRepo     domain.TagRepository
+	productRepo domain.ProductRepository
+	logger      *zap.Logger
+}
+
+func NewTagService(tagRepo domain.TagRepository, productRepo domain.ProductRepository, logger *zap.Logger) TagService {
+	return &tagService{
+		tagRepo:     tagRepo,
+		productRepo: productRepo,
+		logger:      logger.Named("tag_service"),
+	}
+}
+
+func (s *tagService) CreateTag(ctx context.Context, name string) (*domain.Tag, error) {
+	s.logger.Info("creating a new tag", zap.String("name", name))
+
+	newTag, err := domain.NewTag(name)
+	if err != nil {
+		s.logger.Warn("failed to create new object tag from domain factory", zap.String("name", name))
+		return nil, err
+	}
+
+	if err := s.tagRepo.Create(ctx, newTag); err != nil {
+		s.logger.Error("failed to create tag via repository", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("tag created successfully", zap.String("tag_id", string(newTag.ID)), zap.String("name", newTag.Name))
+	return newTag, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, tag *domain.Tag) error {
+	s.logger.Info("updating tag", zap.String("tag_id", string(tag.ID)))
+
+	if err := s.tagRepo.Update(ctx, tag); err != nil {
+		s.logger.Error("failed to update tag via repository", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("tag updated successfully", zap.String("tag_id", string(tag.ID)))
+	return nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id domain.TagID) error {
+	s.logger.Info("deleting tag", zap.String("tag_id", string(id)))
+
+	if err := s.tagRepo.Delete(ctx, id); err != nil {
+		s.logger.Error("failed to delete tag via repository", zap.Error(err))
+		return err
+	}
+
+	if err := s.productRepo.RemoveTagFromAll(ctx, id); err != nil {
+		s.logger.Error("failed to detach deleted tag from products", zap.String("tag_id", string(id)), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("tag deleted successfully", zap.String("tag_id", string(id)))
+	return nil
+}
+
+func (s *tagService) GetTag(ctx context.Context, id domain.TagID) (*domain.Tag, error) {
+	s.logger.Info("getting tag", zap.String("tag_id", string(id)))
+	return s.tagRepo.FindByID(ctx, id)
+}
+
+func (s *tagService) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+	s.logger.Info("getting all tags")
+	return s.tagRepo.FindAll(ctx)
+}
+
+func (s *tagService) AttachTags(ctx context.Context, productID domain.ProductID, tagIDs []domain.TagID) (*domain.Product, error) {
+	s.logger.Info("attaching tags to product", zap.String("product_id", string(productID)), zap.Int("count", len(tagIDs)))
+
+	for _, tagID := range tagIDs {
+		if _, err := s.tagRepo.FindByID(ctx, tagID); err != nil {
+			s.logger.Warn("tag to attach does not exist", zap.String("tag_id", string(tagID)))
+			return nil, err
+		}
+	}
+
+	if err := s.productRepo.AddTags(ctx, productID, tagIDs); err != nil {
+		s.logger.Error("failed to attach tags to product", zap.Error(err))
+		return nil, err
+	}
+
+	return s.productRepo.FindByID(ctx, productID)
+}
+
+func (s *tagService) DetachTag(ctx context.Context, productID domain.ProductID, tagID domain.TagID) (*domain.Product, error) {
+	s.logger.Info("detaching tag from product", zap.String("product_id", string(productID)), zap.String("tag_id", string(tagID)))
+
+	if err := s.productRepo.RemoveTag(ctx, productID, tagID); err != nil {
+		s.logger.Error("failed to detach tag from product", zap.Error(err))
+		return nil, err
+	}
+
+	return s.productRepo.FindByID(ctx, productID)
+}
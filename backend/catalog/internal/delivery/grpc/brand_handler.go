@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	pb "api/proto/catalog/v1"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateBrand(ctx context.Context, req *pb.CreateBrandRequest) (*pb.CreateBrandResponse, error) {
+	s.logger.Info("received CreateBrand request", zap.String("name", req.GetName()))
+
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "brand name cannot be empty")
+	}
+
+	brand, err := s.brandService.CreateBrand(ctx, req.GetName())
+	if err != nil {
+		if errors.Is(err, domain.ErrBrandAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err.Error())
+		}
+		s.logger.Error("failed to create brand via service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to create brand")
+	}
+
+	return &pb.CreateBrandResponse{Brand: toPBBrand(brand)}, nil
+}
+
+func (s *Server) GetBrand(ctx context.Context, req *pb.GetBrandRequest) (*pb.Brand, error) {
+	s.logger.Info("received GetBrand request", zap.String("brand_id", req.GetId()))
+
+	brand, err := s.brandService.GetBrand(ctx, domain.BrandID(req.GetId()))
+	if err != nil {
+		if errors.Is(err, domain.ErrBrandNotFound) {
+			return nil, status.Errorf(codes.NotFound, "brand with id '%s' not found", req.GetId())
+		}
+		s.logger.Error("failed to get brand from service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "internal server error while getting brand")
+	}
+
+	return toPBBrand(brand), nil
+}
+
+func (s *Server) ListBrands(ctx context.Context, _ *pb.ListBrandsRequest) (*pb.ListBrandsResponse, error) {
+	s.logger.Info("received ListBrands request")
+
+	brands, err := s.brandService.ListBrands(ctx)
+	if err != nil {
+		s.logger.Error("failed to get all brands from service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve brand list")
+	}
+
+	pbBrands := make([]*pb.Brand, len(brands))
+	for i, b := range brands {
+		pbBrands[i] = toPBBrand(b)
+	}
+
+	return &pb.ListBrandsResponse{Brands: pbBrands}, nil
+}
+
+func toPBBrand(brand *domain.Brand) *pb.Brand {
+	var logoURL *string
+	if brand.Logo != nil {
+		url := fmt.Sprintf("/v1/brands/%s/logo", brand.ID)
+		logoURL = &url
+	}
+
+	return &pb.Brand{
+		Id:      string(brand.ID),
+		Name:    brand.Name,
+		LogoUrl: logoURL,
+	}
+}
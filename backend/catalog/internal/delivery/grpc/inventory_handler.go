@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	pb "api/proto/catalog/v1"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	s.logger.Info("received ReserveStock request", zap.String("order_id", req.GetOrderId()), zap.Int("count", len(req.GetItems())))
+
+	items := make([]domain.ReservedItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = domain.ReservedItem{
+			VariantID: domain.ProductVariantID(item.GetVariantId()),
+			Quantity:  int(item.GetQuantity()),
+		}
+	}
+
+	if _, err := s.inventoryService.ReserveStock(ctx, req.GetOrderId(), items); err != nil {
+		s.logger.Error("failed to reserve stock via service", zap.Error(err))
+		switch {
+		case errors.Is(err, domain.ErrInvalidReservation):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case errors.Is(err, domain.ErrVariantNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
+		case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrReservationReleased):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reserve stock")
+	}
+
+	return &pb.ReserveStockResponse{}, nil
+}
+
+func (s *Server) CommitStock(ctx context.Context, req *pb.CommitStockRequest) (*pb.CommitStockResponse, error) {
+	s.logger.Info("received CommitStock request", zap.String("order_id", req.GetOrderId()))
+
+	if req.GetOrderId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order id cannot be empty")
+	}
+
+	if err := s.inventoryService.CommitStock(ctx, req.GetOrderId()); err != nil {
+		s.logger.Error("failed to commit stock via service", zap.Error(err))
+		switch {
+		case errors.Is(err, domain.ErrReservationNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
+		case errors.Is(err, domain.ErrReservationReleased):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to commit stock")
+	}
+
+	return &pb.CommitStockResponse{}, nil
+}
+
+func (s *Server) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	s.logger.Info("received ReleaseStock request", zap.String("order_id", req.GetOrderId()))
+
+	if req.GetOrderId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order id cannot be empty")
+	}
+
+	if err := s.inventoryService.ReleaseStock(ctx, req.GetOrderId()); err != nil {
+		s.logger.Error("failed to release stock via service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to release stock")
+	}
+
+	return &pb.ReleaseStockResponse{}, nil
+}
@@ -1,27 +1,44 @@
 package grpc
 
-//func (s *Server) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
-//	s.logger.Info("received GetProduct request", zap.String("product_id", req.GetId()))
-//
-//	productID := domain.ProductID(req.GetId())
-//	foundProduct, err := s.productService.GetProduct(ctx, productID)
-//	if err != nil {
-//		s.logger.Error("failed to get product from service",
-//			zap.String("product_id", req.GetId()),
-//			zap.Error(err),
-//		)
-//
-//		if errors.Is(err, domain.ErrProductNotFound) {
-//			return nil, status.Errorf(codes.NotFound, "product with id '%s' not found", req.GetId())
-//		}
-//		return nil, status.Errorf(codes.Internal, "internal server error while getting product")
-//	}
-//
-//	return &pb.Product{
-//		Id:   string(foundProduct.ID),
-//		Name: foundProduct.Name,
-//	}, nil
-//}
+import (
+	pb "api/proto/catalog/v1"
+	"catalog/internal/application/services"
+	"catalog/internal/domain"
+	"context"
+	"errors"
+	"pkg/money"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	s.logger.Info("received GetProduct request", zap.String("product_id", req.GetId()))
+
+	productID := domain.ProductID(req.GetId())
+	foundProduct, err := s.productService.GetProduct(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to get product from service",
+			zap.String("product_id", req.GetId()),
+			zap.Error(err),
+		)
+
+		if errors.Is(err, domain.ErrProductNotFound) {
+			return nil, status.Errorf(codes.NotFound, "product with id '%s' not found", req.GetId())
+		}
+		return nil, status.Errorf(codes.Internal, "internal server error while getting product")
+	}
+
+	variants, err := s.productVariantService.ListVariants(ctx, productID)
+	if err != nil {
+		s.logger.Error("failed to list product variants from service", zap.String("product_id", req.GetId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "internal server error while getting product variants")
+	}
+
+	return toPBProduct(foundProduct, variants), nil
+}
 
 //func (s *Server) ListProducts(ctx context.Context, _ *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
 //	s.logger.Info("received ListProducts request")
@@ -44,23 +61,143 @@ package grpc
 //	return &pb.ListProductsResponse{Products: pbProducts}, nil
 //}
 
-//func (s *Server) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
-//	s.logger.Info("received CreateProduct request", zap.String("name", req.GetName()))
-//
-//	if req.GetName() == "" {
-//		return nil, status.Errorf(codes.InvalidArgument, "product name cannot be empty")
-//	}
-//
-//	product, err := s.productService.CreateProduct(ctx, req.GetName())
-//	if err != nil {
-//		s.logger.Error("failed to create product via service", zap.Error(err))
-//		return nil, status.Errorf(codes.Internal, "failed to create product")
-//	}
-//
-//	return &pb.CreateProductResponse{
-//		Product: &pb.Product{
-//			Id:   string(product.ID),
-//			Name: product.Name,
-//		},
-//	}, nil
-//}
+func (s *Server) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
+	s.logger.Info("received CreateProduct request", zap.String("name", req.GetName()))
+
+	if req.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product name cannot be empty")
+	}
+
+	if req.GetCategoryId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product category cannot be empty")
+	}
+
+	product, err := s.productService.CreateProduct(ctx, req.GetName(), req.GetDescription(), domain.CategoryID(req.GetCategoryId()), (*domain.BrandID)(req.BrandId), req.GetIsPublished())
+	if err != nil {
+		s.logger.Error("failed to create product via service", zap.Error(err))
+
+		switch {
+		case errors.Is(err, domain.ErrCategoryNotFound), errors.Is(err, domain.ErrBrandNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s", err.Error())
+		case errors.Is(err, domain.ErrCategoryNotLeaf):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create product")
+	}
+
+	return &pb.CreateProductResponse{Product: toPBProduct(product, nil)}, nil
+}
+
+func (s *Server) ResolvePrices(ctx context.Context, req *pb.ResolvePricesRequest) (*pb.ResolvePricesResponse, error) {
+	s.logger.Info("received ResolvePrices request", zap.Int("count", len(req.GetItems())))
+
+	lookups := make([]services.PriceLookup, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		if item.GetProductId() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "product id cannot be empty")
+		}
+		lookups[i] = services.PriceLookup{
+			ProductID: domain.ProductID(item.GetProductId()),
+			VariantID: domain.ProductVariantID(item.GetVariantId()),
+		}
+	}
+
+	prices, err := s.productService.ResolvePrices(ctx, lookups)
+	if err != nil {
+		s.logger.Error("failed to resolve prices via service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to resolve prices")
+	}
+
+	pbItems := make([]*pb.ItemPrice, len(prices))
+	for i, price := range prices {
+		pbItem := &pb.ItemPrice{
+			ProductId: req.GetItems()[i].GetProductId(),
+			VariantId: req.GetItems()[i].GetVariantId(),
+		}
+		if price.Product != nil && price.Variant != nil {
+			pbItem.VariantId = string(price.Variant.ID)
+			pbItem.Sku = price.Variant.SKU
+			pbItem.Name = price.Product.Name
+			pbItem.UnitPrice = toPBMoney(price.Variant.Price)
+			pbItem.Stock = int32(price.Variant.Stock)
+			pbItem.IsPublished = price.Product.IsPublished
+			pbItem.ImageUrl = itemImageURL(price.Product, price.Variant)
+			pbItem.CategoryId = string(price.Product.CategoryID)
+			pbItem.Found = true
+		}
+		pbItems[i] = pbItem
+	}
+
+	return &pb.ResolvePricesResponse{Items: pbItems}, nil
+}
+
+// itemImageURL picks the image shown for a purchasable item: the variant's primary image,
+// then its first image, then the product's primary image.
+func itemImageURL(product *domain.Product, variant *domain.ProductVariant) string {
+	for _, img := range variant.Images {
+		if img.IsPrimary {
+			return img.URL
+		}
+	}
+	if len(variant.Images) > 0 {
+		return variant.Images[0].URL
+	}
+	if product.PrimaryImageURL != nil {
+		return *product.PrimaryImageURL
+	}
+	return ""
+}
+
+func toPBProduct(product *domain.Product, variants []*domain.ProductVariant) *pb.Product {
+	pbVariants := make([]*pb.ProductVariant, len(variants))
+	for i, v := range variants {
+		pbVariants[i] = toPBProductVariant(v)
+	}
+
+	tagIDs := make([]string, len(product.Tags))
+	for i, t := range product.Tags {
+		tagIDs[i] = string(t)
+	}
+
+	return &pb.Product{
+		Id:              string(product.ID),
+		Name:            product.Name,
+		Variants:        pbVariants,
+		BrandId:         (*string)(product.BrandID),
+		AverageRating:   product.AverageRating,
+		ReviewCount:     int32(product.ReviewCount),
+		TagIds:          tagIDs,
+		Description:     product.Description,
+		PrimaryImageUrl: product.PrimaryImageURL,
+		CategoryId:      string(product.CategoryID),
+		IsPublished:     product.IsPublished,
+		CreatedAt:       timestamppb.New(product.CreatedAt),
+		UpdatedAt:       timestamppb.New(product.UpdatedAt),
+	}
+}
+
+func toPBProductVariant(variant *domain.ProductVariant) *pb.ProductVariant {
+	pbImages := make([]*pb.Image, len(variant.Images))
+	for i, img := range variant.Images {
+		pbImages[i] = &pb.Image{Url: img.URL, AltText: img.AltText, IsPrimary: img.IsPrimary, Order: int32(img.Order)}
+	}
+
+	pbAttributes := make([]*pb.Attribute, len(variant.Attributes))
+	for i, attr := range variant.Attributes {
+		pbAttributes[i] = &pb.Attribute{Name: attr.Name, Value: attr.Value}
+	}
+
+	return &pb.ProductVariant{
+		Id:         string(variant.ID),
+		ProductId:  string(variant.ProductID),
+		Sku:        variant.SKU,
+		Price:      toPBMoney(variant.Price),
+		Stock:      int32(variant.Stock),
+		Images:     pbImages,
+		Attributes: pbAttributes,
+	}
+}
+
+func toPBMoney(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
@@ -1,30 +1,39 @@
 package grpc
 
 import (
-	pb "catalog/api/proto/v1"
-	"catalog/internal/application"
+	pb "api/proto/catalog/v1"
+	"catalog/internal/application/services"
 	"go.uber.org/zap"
 )
 
 //var _ pb.CatalogServiceServer = (*Server)(nil)
 
 type ServerDependencies struct {
-	ProductService  application.ProductService
-	CategoryService application.CategoryService
-	Logger          *zap.Logger
+	ProductService        services.ProductService
+	ProductVariantService services.ProductVariantService
+	CategoryService       services.CategoryService
+	BrandService          services.BrandService
+	InventoryService      services.InventoryService
+	Logger                *zap.Logger
 }
 
 type Server struct {
 	pb.UnimplementedCatalogServiceServer
-	productService  application.ProductService
-	categoryService application.CategoryService
-	logger          *zap.Logger
+	productService        services.ProductService
+	productVariantService services.ProductVariantService
+	categoryService       services.CategoryService
+	brandService          services.BrandService
+	inventoryService      services.InventoryService
+	logger                *zap.Logger
 }
 
 func NewServer(deps ServerDependencies, logger *zap.Logger) *Server {
 	return &Server{
-		productService:  deps.ProductService,
-		categoryService: deps.CategoryService,
-		logger:          logger.Named("catalog_grpc_handler"),
+		productService:        deps.ProductService,
+		productVariantService: deps.ProductVariantService,
+		categoryService:       deps.CategoryService,
+		brandService:          deps.BrandService,
+		inventoryService:      deps.InventoryService,
+		logger:                logger.Named("catalog_grpc_handler"),
 	}
 }
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"mime/multipart"
+	"pkg/validation"
+	"time"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateBrandRequest struct {
+	Name string                `form:"name"`
+	Logo *multipart.FileHeader `form:"logo,omitempty"`
+}
+
+type UpdateBrandRequest struct {
+	Name *string               `form:"name"`
+	Logo *multipart.FileHeader `form:"logo,omitempty"`
+}
+
+type BrandResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Logo      *string   `json:"logo,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (r *CreateBrandRequest) Validate() error {
+	return ozzo.ValidateStruct(r,
+		ozzo.Field(&r.Name, ozzo.Required, ozzo.Length(2, 100)),
+		ozzo.Field(&r.Logo,
+			ozzo.When(r.Logo != nil,
+				ozzo.By(validation.ImageRule(
+					2*1024*1024, // 2 MB
+					[]string{".jpg", ".jpeg", ".png", ".svg"},
+				)),
+			),
+		),
+	)
+}
+
+func (r *UpdateBrandRequest) Validate() error {
+	return ozzo.ValidateStruct(r,
+		ozzo.Field(&r.Name, ozzo.NilOrNotEmpty, ozzo.Length(2, 100)),
+		ozzo.Field(&r.Logo,
+			ozzo.When(r.Logo != nil,
+				ozzo.By(validation.ImageRule(
+					2*1024*1024, // 2 MB
+					[]string{".jpg", ".jpeg", ".png", ".svg"},
+				)),
+			),
+		),
+	)
+}
@@ -1,20 +1,58 @@
 package dto
 
-import ozzo "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	"time"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type CreateProductRequest struct {
-	Name  string `json:"name"`
-	Price string `json:"price"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	CategoryID  string  `json:"categoryId"`
+	BrandID     *string `json:"brandId"`
+	IsPublished bool    `json:"isPublished"`
+}
+
+type UpdateProductRequest struct {
+	Name            *string `json:"name"`
+	Description     *string `json:"description"`
+	PrimaryImageURL *string `json:"primaryImageUrl"`
+	CategoryID      *string `json:"categoryId"`
+	BrandID         *string `json:"brandId"`
+	IsPublished     *bool   `json:"isPublished"`
 }
 
 type ProductResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	PrimaryImageURL *string   `json:"primaryImageUrl,omitempty"`
+	CategoryID      string    `json:"categoryId"`
+	BrandID         *string   `json:"brandId,omitempty"`
+	TagIDs          []string  `json:"tagIds"`
+	AverageRating   float64   `json:"averageRating"`
+	ReviewCount     int       `json:"reviewCount"`
+	IsPublished     bool      `json:"isPublished"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
 }
 
 func (r CreateProductRequest) Validate() error {
 	return ozzo.ValidateStruct(&r,
 		ozzo.Field(&r.Name, ozzo.Required, ozzo.Length(3, 100)),
-		ozzo.Field(&r.Price, ozzo.Required),
+		ozzo.Field(&r.Description, ozzo.Length(0, 5000)),
+		ozzo.Field(&r.CategoryID, ozzo.Required),
+		ozzo.Field(&r.BrandID, ozzo.NilOrNotEmpty),
+	)
+}
+
+func (r UpdateProductRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.NilOrNotEmpty, ozzo.Length(3, 100)),
+		ozzo.Field(&r.Description, ozzo.Length(0, 5000)),
+		ozzo.Field(&r.PrimaryImageURL, ozzo.NilOrNotEmpty, ozzo.Length(1, 2048)),
+		ozzo.Field(&r.CategoryID, ozzo.NilOrNotEmpty),
+		ozzo.Field(&r.BrandID, ozzo.NilOrNotEmpty),
 	)
 }
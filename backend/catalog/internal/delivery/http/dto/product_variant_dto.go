@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"errors"
+	"pkg/money"
+	"time"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type AttributeRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CreateProductVariantRequest struct {
+	SKU        string             `json:"sku"`
+	Price      money.Money        `json:"price"` // {"amount": <minor units>, "currency": "USD"}
+	Stock      int                `json:"stock"`
+	Attributes []AttributeRequest `json:"attributes"`
+}
+
+type UpdateProductVariantRequest struct {
+	SKU        *string             `json:"sku"`
+	Price      *money.Money        `json:"price"`
+	Stock      *int                `json:"stock"`
+	Attributes *[]AttributeRequest `json:"attributes"`
+}
+
+type ImageResponse struct {
+	URL       string `json:"url"`
+	AltText   string `json:"altText,omitempty"`
+	IsPrimary bool   `json:"isPrimary"`
+	Order     int    `json:"order"`
+}
+
+type AttributeResponse struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ProductVariantResponse struct {
+	ID         string              `json:"id"`
+	ProductID  string              `json:"productId"`
+	SKU        string              `json:"sku"`
+	Price      money.Money         `json:"price"`
+	Stock      int                 `json:"stock"`
+	Images     []ImageResponse     `json:"images"`
+	Attributes []AttributeResponse `json:"attributes"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+func (r AttributeRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required, ozzo.Length(1, 50)),
+		ozzo.Field(&r.Value, ozzo.Required, ozzo.Length(1, 100)),
+	)
+}
+
+func (r CreateProductVariantRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.SKU, ozzo.Required, ozzo.Length(1, 64)),
+		ozzo.Field(&r.Price, ozzo.By(validatePrice)),
+		ozzo.Field(&r.Stock, ozzo.Min(0)),
+		ozzo.Field(&r.Attributes),
+	)
+}
+
+func (r UpdateProductVariantRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.SKU, ozzo.NilOrNotEmpty, ozzo.Length(1, 64)),
+		ozzo.Field(&r.Price, ozzo.By(validatePrice)),
+		ozzo.Field(&r.Stock, ozzo.Min(0)),
+		ozzo.Field(&r.Attributes),
+	)
+}
+
+// validatePrice accepts a money.Money or a nil-able *money.Money that is non-negative and in a supported currency.
+func validatePrice(value interface{}) error {
+	var price money.Money
+	switch v := value.(type) {
+	case money.Money:
+		price = v
+	case *money.Money:
+		if v == nil {
+			return nil
+		}
+		price = *v
+	}
+
+	if price.IsNegative() {
+		return errors.New("must be no less than 0")
+	}
+	return money.ValidateCurrency(price.Currency)
+}
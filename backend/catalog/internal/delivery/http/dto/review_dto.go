@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"catalog/internal/domain"
+	"time"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating"`
+	Comment string `json:"comment"`
+}
+
+type UpdateReviewRequest struct {
+	Rating  *int    `json:"rating"`
+	Comment *string `json:"comment"`
+}
+
+type ReviewResponse struct {
+	ID        string    `json:"id"`
+	ProductID string    `json:"productId"`
+	UserID    string    `json:"userId"`
+	Rating    int       `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (r CreateReviewRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Rating, ozzo.Required, ozzo.Min(domain.MinReviewRating), ozzo.Max(domain.MaxReviewRating)),
+		ozzo.Field(&r.Comment, ozzo.Length(0, 2000)),
+	)
+}
+
+func (r UpdateReviewRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Rating, ozzo.Min(domain.MinReviewRating), ozzo.Max(domain.MaxReviewRating)),
+		ozzo.Field(&r.Comment, ozzo.Length(0, 2000)),
+	)
+}
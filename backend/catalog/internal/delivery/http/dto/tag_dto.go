@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	ozzo "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateTagRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateTagRequest struct {
+	Name *string `json:"name"`
+}
+
+type AttachTagsRequest struct {
+	TagIDs []string `json:"tagIds"`
+}
+
+type TagResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (r CreateTagRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.Required, ozzo.Length(2, 50)),
+	)
+}
+
+func (r UpdateTagRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.Name, ozzo.NilOrNotEmpty, ozzo.Length(2, 50)),
+	)
+}
+
+func (r AttachTagsRequest) Validate() error {
+	return ozzo.ValidateStruct(&r,
+		ozzo.Field(&r.TagIDs, ozzo.Required, ozzo.Each(ozzo.Required)),
+	)
+}
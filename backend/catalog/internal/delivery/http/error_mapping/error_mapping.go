@@ -10,8 +10,21 @@ func GetDomainErrorMappings() map[error]error_handler.DomainErrorMapping {
 	domainErrorMappings := map[error]error_handler.DomainErrorMapping{
 		domain.ErrProductNotFound:            {StatusCode: http.StatusNotFound, Message: domain.ErrProductNotFound.Error()},
 		domain.ErrCategoryNotFound:           {StatusCode: http.StatusNotFound, Message: domain.ErrCategoryNotFound.Error()},
+		domain.ErrBrandNotFound:              {StatusCode: http.StatusNotFound, Message: domain.ErrBrandNotFound.Error()},
+		domain.ErrBrandAlreadyExists:         {StatusCode: http.StatusConflict, Message: domain.ErrBrandAlreadyExists.Error()},
+		domain.ErrVariantNotFound:            {StatusCode: http.StatusNotFound, Message: domain.ErrVariantNotFound.Error()},
+		domain.ErrSKUAlreadyExists:           {StatusCode: http.StatusConflict, Message: domain.ErrSKUAlreadyExists.Error()},
+		domain.ErrInsufficientStock:          {StatusCode: http.StatusConflict, Message: domain.ErrInsufficientStock.Error()},
+		domain.ErrTagNotFound:                {StatusCode: http.StatusNotFound, Message: domain.ErrTagNotFound.Error()},
+		domain.ErrTagAlreadyExists:           {StatusCode: http.StatusConflict, Message: domain.ErrTagAlreadyExists.Error()},
+		domain.ErrReviewNotFound:             {StatusCode: http.StatusNotFound, Message: domain.ErrReviewNotFound.Error()},
+		domain.ErrReviewAlreadyExists:        {StatusCode: http.StatusConflict, Message: domain.ErrReviewAlreadyExists.Error()},
+		domain.ErrReviewNotAllowed:           {StatusCode: http.StatusForbidden, Message: domain.ErrReviewNotAllowed.Error()},
+		domain.ErrReviewForbidden:            {StatusCode: http.StatusForbidden, Message: domain.ErrReviewForbidden.Error()},
+		domain.ErrInvalidRating:              {StatusCode: http.StatusBadRequest, Message: domain.ErrInvalidRating.Error()},
 		domain.ErrCategoryAlreadyExists:      {StatusCode: http.StatusConflict, Message: domain.ErrCategoryAlreadyExists.Error()},
 		domain.ErrCategoryDepthLimitExceeded: {StatusCode: http.StatusBadRequest, Message: domain.ErrCategoryDepthLimitExceeded.Error()},
+		domain.ErrCategoryNotLeaf:            {StatusCode: http.StatusBadRequest, Message: domain.ErrCategoryNotLeaf.Error()},
 		domain.ErrCategoryHasProducts:        {StatusCode: http.StatusBadRequest, Message: domain.ErrCategoryHasProducts.Error()},
 	}
 
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/delivery/http/dto"
+	"catalog/internal/domain"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"pkg/validation"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+var errInvalidImageType = errors.New("invalid file type, only images are allowed")
+
+type BrandHandler struct {
+	service services.BrandService
+	logger  *zap.Logger
+}
+
+func NewBrandHandler(service services.BrandService, logger *zap.Logger) *BrandHandler {
+	return &BrandHandler{
+		service: service,
+		logger:  logger.Named("brand_http_handler"),
+	}
+}
+
+func (h *BrandHandler) CreateBrand(c echo.Context) error {
+	var req dto.CreateBrandRequest
+
+	req.Name = c.FormValue("name")
+
+	if file, err := c.FormFile("logo"); err == nil {
+		req.Logo = file
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	brand, err := h.service.CreateBrand(c.Request().Context(), req.Name)
+	if err != nil {
+		return err
+	}
+
+	if req.Logo != nil {
+		brand, err = h.uploadLogo(c, brand.ID, req.Logo)
+		if errors.Is(err, errInvalidImageType) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{"brand": h.toBrandResponse(c, brand)})
+}
+
+func (h *BrandHandler) UpdateBrand(c echo.Context) error {
+	brandID := domain.BrandID(c.Param("id"))
+
+	var req dto.UpdateBrandRequest
+
+	name := c.FormValue("name")
+	if name != "" {
+		req.Name = &name
+	}
+
+	if file, err := c.FormFile("logo"); err == nil {
+		req.Logo = file
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	brand, err := h.service.GetBrand(c.Request().Context(), brandID)
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		brand.Name = *req.Name
+		if err := h.service.UpdateBrand(c.Request().Context(), brand); err != nil {
+			return err
+		}
+	}
+
+	if req.Logo != nil {
+		brand, err = h.uploadLogo(c, brand.ID, req.Logo)
+		if errors.Is(err, errInvalidImageType) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"brand": h.toBrandResponse(c, brand)})
+}
+
+func (h *BrandHandler) GetBrand(c echo.Context) error {
+	brand, err := h.service.GetBrand(c.Request().Context(), domain.BrandID(c.Param("id")))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"brand": h.toBrandResponse(c, brand)})
+}
+
+func (h *BrandHandler) ListBrands(c echo.Context) error {
+	brands, err := h.service.ListBrands(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	results := make([]dto.BrandResponse, len(brands))
+	for i, b := range brands {
+		results[i] = h.toBrandResponse(c, b)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"brands": results})
+}
+
+func (h *BrandHandler) GetLogo(c echo.Context) error {
+	object, err := h.service.DownloadLogo(c.Request().Context(), domain.BrandID(c.Param("id")))
+	if err != nil {
+		h.logger.Error("failed to get brand logo", zap.String("brand_id", c.Param("id")), zap.Error(err))
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	return c.Stream(http.StatusOK, "image/png", object)
+}
+
+func (h *BrandHandler) uploadLogo(c echo.Context, brandID domain.BrandID, logo *multipart.FileHeader) (*domain.Brand, error) {
+	src, err := logo.Open()
+	if err != nil {
+		h.logger.Error("failed to open uploaded file", zap.Error(err))
+		return nil, err
+	}
+	defer func(src multipart.File) {
+		_ = src.Close()
+	}(src)
+
+	contentType, err := validation.GetFileContentType(src)
+	if err != nil {
+		h.logger.Error("failed to detect file content type", zap.Error(err))
+		return nil, err
+	}
+
+	// http.DetectContentType reports svg files as text/xml
+	if !strings.HasPrefix(contentType, "image/") && !strings.HasSuffix(logo.Filename, ".svg") {
+		return nil, errInvalidImageType
+	}
+
+	return h.service.UploadLogo(c.Request().Context(), brandID, logo.Filename, src, logo.Size, contentType)
+}
+
+func (h *BrandHandler) toBrandResponse(c echo.Context, brand *domain.Brand) dto.BrandResponse {
+	var logoURL *string
+	if brand.Logo != nil {
+		fullURL := fmt.Sprintf("%s://%s/v1/brands/%s/logo", c.Scheme(), c.Request().Host, brand.ID)
+		logoURL = &fullURL
+	}
+
+	return dto.BrandResponse{
+		ID:        string(brand.ID),
+		Name:      brand.Name,
+		Logo:      logoURL,
+		CreatedAt: brand.CreatedAt,
+	}
+}
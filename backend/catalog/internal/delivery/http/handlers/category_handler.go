@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"catalog/config"
-	"catalog/internal/application"
+	"catalog/internal/application/services"
 	"catalog/internal/delivery/http/dto"
 	"catalog/internal/domain"
 	"errors"
@@ -20,13 +20,13 @@ import (
 )
 
 type CategoryHandler struct {
-	service      application.CategoryService
+	service      services.CategoryService
 	minioService *minio.Service
 	config       *config.Config
 	logger       *zap.Logger
 }
 
-func NewCategoryHandler(service application.CategoryService, minioService *minio.Service, cfg *config.Config, logger *zap.Logger) *CategoryHandler {
+func NewCategoryHandler(service services.CategoryService, minioService *minio.Service, cfg *config.Config, logger *zap.Logger) *CategoryHandler {
 	return &CategoryHandler{
 		service:      service,
 		minioService: minioService,
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"catalog/internal/application"
+	"catalog/internal/application/services"
 	"catalog/internal/delivery/http/dto"
 	"catalog/internal/domain"
 	"net/http"
@@ -24,16 +24,19 @@ var productFilterSet = &filter.FilterSet{
 		"category": "categoryId",
 		"brand":    "brandId",
 	},
+	ListFilterFields: map[string]string{
+		"tags": "tags",
+	},
 	SearchFields:   []string{"name", "description"},
 	OrderingFields: []string{"price", "created_at"},
 }
 
 type ProductHandler struct {
-	service application.ProductService
+	service services.ProductService
 	logger  *zap.Logger
 }
 
-func NewProductHandler(service application.ProductService, logger *zap.Logger) *ProductHandler {
+func NewProductHandler(service services.ProductService, logger *zap.Logger) *ProductHandler {
 	return &ProductHandler{
 		service: service,
 		logger:  logger.Named("product_http_handler"),
@@ -50,16 +53,67 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		return err
 	}
 
-	productDomain, err := h.service.CreateProduct(c.Request().Context(), req.Name)
+	productDomain, err := h.service.CreateProduct(c.Request().Context(), req.Name, req.Description, domain.CategoryID(req.CategoryID), (*domain.BrandID)(req.BrandID), req.IsPublished)
 	if err != nil {
 		h.logger.Error("failed to create product", zap.Error(err))
-		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		return err
 	}
 
 	return c.JSON(http.StatusCreated, echo.Map{"product": toProductResponse(productDomain)})
 
 }
 
+func (h *ProductHandler) GetProduct(c echo.Context) error {
+	product, err := h.service.GetProduct(c.Request().Context(), domain.ProductID(c.Param("id")))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"product": toProductResponse(product)})
+}
+
+func (h *ProductHandler) UpdateProduct(c echo.Context) error {
+	var req dto.UpdateProductRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	product, err := h.service.GetProduct(c.Request().Context(), domain.ProductID(c.Param("id")))
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		product.Name = *req.Name
+	}
+	if req.Description != nil {
+		product.Description = *req.Description
+	}
+	if req.PrimaryImageURL != nil {
+		product.PrimaryImageURL = req.PrimaryImageURL
+	}
+	if req.CategoryID != nil {
+		product.CategoryID = domain.CategoryID(*req.CategoryID)
+	}
+	if req.BrandID != nil {
+		product.BrandID = (*domain.BrandID)(req.BrandID)
+	}
+	if req.IsPublished != nil {
+		product.IsPublished = *req.IsPublished
+	}
+
+	if err := h.service.UpdateProduct(c.Request().Context(), product); err != nil {
+		h.logger.Error("failed to update product", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"product": toProductResponse(product)})
+}
+
 func (h *ProductHandler) ListProducts(c echo.Context) error {
 
 	page := c.Get("page").(int)
@@ -85,7 +139,25 @@ func (h *ProductHandler) ListProducts(c echo.Context) error {
 
 func toProductResponse(product *domain.Product) dto.ProductResponse {
 	return dto.ProductResponse{
-		ID:   string(product.ID),
-		Name: product.Name,
+		ID:              string(product.ID),
+		Name:            product.Name,
+		Description:     product.Description,
+		PrimaryImageURL: product.PrimaryImageURL,
+		CategoryID:      string(product.CategoryID),
+		BrandID:         (*string)(product.BrandID),
+		TagIDs:          toTagIDStrings(product.Tags),
+		AverageRating:   product.AverageRating,
+		ReviewCount:     product.ReviewCount,
+		IsPublished:     product.IsPublished,
+		CreatedAt:       product.CreatedAt,
+		UpdatedAt:       product.UpdatedAt,
+	}
+}
+
+func toTagIDStrings(tags []domain.TagID) []string {
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = string(t)
 	}
+	return result
 }
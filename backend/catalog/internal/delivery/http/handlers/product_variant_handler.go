@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/delivery/http/dto"
+	"catalog/internal/domain"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type ProductVariantHandler struct {
+	service services.ProductVariantService
+	logger  *zap.Logger
+}
+
+func NewProductVariantHandler(service services.ProductVariantService, logger *zap.Logger) *ProductVariantHandler {
+	return &ProductVariantHandler{
+		service: service,
+		logger:  logger.Named("product_variant_http_handler"),
+	}
+}
+
+func (h *ProductVariantHandler) CreateVariant(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+
+	var req dto.CreateProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	variant, err := h.service.CreateVariant(c.Request().Context(), productID, req.SKU, req.Price, req.Stock, toDomainAttributes(req.Attributes))
+	if err != nil {
+		h.logger.Error("failed to create product variant", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{"variant": toProductVariantResponse(variant)})
+}
+
+func (h *ProductVariantHandler) ListVariants(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+
+	variants, err := h.service.ListVariants(c.Request().Context(), productID)
+	if err != nil {
+		h.logger.Error("failed to list product variants", zap.Error(err))
+		return err
+	}
+
+	results := make([]dto.ProductVariantResponse, len(variants))
+	for i, v := range variants {
+		results[i] = toProductVariantResponse(v)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"variants": results})
+}
+
+func (h *ProductVariantHandler) GetVariant(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+	variantID := domain.ProductVariantID(c.Param("variantId"))
+
+	variant, err := h.service.GetVariant(c.Request().Context(), productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"variant": toProductVariantResponse(variant)})
+}
+
+func (h *ProductVariantHandler) UpdateVariant(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+	variantID := domain.ProductVariantID(c.Param("variantId"))
+
+	var req dto.UpdateProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	variant, err := h.service.GetVariant(c.Request().Context(), productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	if req.SKU != nil {
+		variant.SKU = *req.SKU
+	}
+	if req.Price != nil {
+		variant.Price = *req.Price
+	}
+	if req.Attributes != nil {
+		variant.Attributes = toDomainAttributes(*req.Attributes)
+	}
+
+	if err := h.service.UpdateVariant(c.Request().Context(), variant); err != nil {
+		h.logger.Error("failed to update product variant", zap.Error(err))
+		return err
+	}
+
+	if req.Stock != nil {
+		if err := h.service.SetVariantStock(c.Request().Context(), variant, *req.Stock); err != nil {
+			h.logger.Error("failed to set product variant stock", zap.Error(err))
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"variant": toProductVariantResponse(variant)})
+}
+
+func (h *ProductVariantHandler) DeleteVariant(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+	variantID := domain.ProductVariantID(c.Param("variantId"))
+
+	if err := h.service.DeleteVariant(c.Request().Context(), productID, variantID); err != nil {
+		h.logger.Error("failed to delete product variant", zap.Error(err))
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toDomainAttributes(attributes []dto.AttributeRequest) []domain.Attribute {
+	result := make([]domain.Attribute, len(attributes))
+	for i, attr := range attributes {
+		result[i] = domain.Attribute{Name: attr.Name, Value: attr.Value}
+	}
+	return result
+}
+
+func toProductVariantResponse(variant *domain.ProductVariant) dto.ProductVariantResponse {
+	images := make([]dto.ImageResponse, len(variant.Images))
+	for i, img := range variant.Images {
+		images[i] = dto.ImageResponse{URL: img.URL, AltText: img.AltText, IsPrimary: img.IsPrimary, Order: img.Order}
+	}
+
+	attributes := make([]dto.AttributeResponse, len(variant.Attributes))
+	for i, attr := range variant.Attributes {
+		attributes[i] = dto.AttributeResponse{Name: attr.Name, Value: attr.Value}
+	}
+
+	return dto.ProductVariantResponse{
+		ID:         string(variant.ID),
+		ProductID:  string(variant.ProductID),
+		SKU:        variant.SKU,
+		Price:      variant.Price,
+		Stock:      variant.Stock,
+		Images:     images,
+		Attributes: attributes,
+		CreatedAt:  variant.CreatedAt,
+		UpdatedAt:  variant.UpdatedAt,
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/delivery/http/dto"
+	"catalog/internal/domain"
+	"net/http"
+	"pkg/contextkeys"
+	"pkg/echo/pagination"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type ReviewHandler struct {
+	service services.ReviewService
+	logger  *zap.Logger
+}
+
+func NewReviewHandler(service services.ReviewService, logger *zap.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+		logger:  logger.Named("review_http_handler"),
+	}
+}
+
+func (h *ReviewHandler) CreateReview(c echo.Context) error {
+	userID, err := contextkeys.GetUserIDFromContext(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+	productID := domain.ProductID(c.Param("id"))
+
+	var req dto.CreateReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	review, err := h.service.CreateReview(c.Request().Context(), userID, productID, req.Rating, req.Comment)
+	if err != nil {
+		h.logger.Error("failed to create review", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{"review": toReviewResponse(review)})
+}
+
+func (h *ReviewHandler) ListReviews(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+
+	page := c.Get("page").(int)
+	limit := c.Get("size").(int)
+
+	reviews, total, err := h.service.ListReviews(c.Request().Context(), productID, page, limit)
+	if err != nil {
+		h.logger.Error("failed to list reviews", zap.Error(err))
+		return err
+	}
+
+	results := make([]dto.ReviewResponse, len(reviews))
+	for i, r := range reviews {
+		results[i] = toReviewResponse(r)
+	}
+
+	return c.JSON(http.StatusOK, pagination.NewResponse(c, total, results))
+}
+
+func (h *ReviewHandler) UpdateReview(c echo.Context) error {
+	userID, err := contextkeys.GetUserIDFromContext(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+	productID := domain.ProductID(c.Param("id"))
+	reviewID := domain.ReviewID(c.Param("reviewId"))
+
+	var req dto.UpdateReviewRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	review, err := h.service.UpdateReview(c.Request().Context(), userID, productID, reviewID, req.Rating, req.Comment)
+	if err != nil {
+		h.logger.Error("failed to update review", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"review": toReviewResponse(review)})
+}
+
+func (h *ReviewHandler) DeleteReview(c echo.Context) error {
+	userID, err := contextkeys.GetUserIDFromContext(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": err.Error()})
+	}
+	productID := domain.ProductID(c.Param("id"))
+	reviewID := domain.ReviewID(c.Param("reviewId"))
+
+	if err := h.service.DeleteReview(c.Request().Context(), userID, productID, reviewID); err != nil {
+		h.logger.Error("failed to delete review", zap.Error(err))
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toReviewResponse(review *domain.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:        string(review.ID),
+		ProductID: string(review.ProductID),
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Comment:   review.Comment,
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
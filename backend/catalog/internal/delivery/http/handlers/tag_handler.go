@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"catalog/internal/application/services"
+	"catalog/internal/delivery/http/dto"
+	"catalog/internal/domain"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type TagHandler struct {
+	service services.TagService
+	logger  *zap.Logger
+}
+
+func NewTagHandler(service services.TagService, logger *zap.Logger) *TagHandler {
+	return &TagHandler{
+		service: service,
+		logger:  logger.Named("tag_http_handler"),
+	}
+}
+
+func (h *TagHandler) CreateTag(c echo.Context) error {
+	var req dto.CreateTagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	tag, err := h.service.CreateTag(c.Request().Context(), req.Name)
+	if err != nil {
+		h.logger.Error("failed to create tag", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{"tag": toTagResponse(tag)})
+}
+
+func (h *TagHandler) ListTags(c echo.Context) error {
+	tags, err := h.service.ListTags(c.Request().Context())
+	if err != nil {
+		h.logger.Error("failed to list tags", zap.Error(err))
+		return err
+	}
+
+	results := make([]dto.TagResponse, len(tags))
+	for i, t := range tags {
+		results[i] = toTagResponse(t)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"tags": results})
+}
+
+func (h *TagHandler) GetTag(c echo.Context) error {
+	tag, err := h.service.GetTag(c.Request().Context(), domain.TagID(c.Param("id")))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"tag": toTagResponse(tag)})
+}
+
+func (h *TagHandler) UpdateTag(c echo.Context) error {
+	var req dto.UpdateTagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	tag, err := h.service.GetTag(c.Request().Context(), domain.TagID(c.Param("id")))
+	if err != nil {
+		return err
+	}
+
+	if req.Name != nil {
+		tag.Name = *req.Name
+	}
+
+	if err := h.service.UpdateTag(c.Request().Context(), tag); err != nil {
+		h.logger.Error("failed to update tag", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"tag": toTagResponse(tag)})
+}
+
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	if err := h.service.DeleteTag(c.Request().Context(), domain.TagID(c.Param("id"))); err != nil {
+		h.logger.Error("failed to delete tag", zap.Error(err))
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *TagHandler) AttachTags(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+
+	var req dto.AttachTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	tagIDs := make([]domain.TagID, len(req.TagIDs))
+	for i, id := range req.TagIDs {
+		tagIDs[i] = domain.TagID(id)
+	}
+
+	product, err := h.service.AttachTags(c.Request().Context(), productID, tagIDs)
+	if err != nil {
+		h.logger.Error("failed to attach tags to product", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"product": toProductResponse(product)})
+}
+
+func (h *TagHandler) DetachTag(c echo.Context) error {
+	productID := domain.ProductID(c.Param("id"))
+	tagID := domain.TagID(c.Param("tagId"))
+
+	product, err := h.service.DetachTag(c.Request().Context(), productID, tagID)
+	if err != nil {
+		h.logger.Error("failed to detach tag from product", zap.Error(err))
+		return err
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"product": toProductResponse(product)})
+}
+
+func toTagResponse(tag *domain.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:        string(tag.ID),
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}
+}
@@ -2,8 +2,9 @@ package router
 
 import (
 	"catalog/config"
-	"catalog/internal/application"
+	"catalog/internal/application/services"
 	"catalog/internal/delivery/http/handlers"
+	"pkg/auth"
 	"pkg/echo/pagination"
 	"pkg/minio"
 
@@ -11,12 +12,16 @@ import (
 	"go.uber.org/zap"
 )
 
-func Setup(e *echo.Echo, categoryService application.CategoryService, productService application.ProductService, minioService *minio.Service, cfg *config.Config, logger *zap.Logger) {
+func Setup(e *echo.Echo, categoryService services.CategoryService, productService services.ProductService, productVariantService services.ProductVariantService, brandService services.BrandService, reviewService services.ReviewService, tagService services.TagService, tokenManager *auth.TokenManager, minioService *minio.Service, cfg *config.Config, logger *zap.Logger) {
 
 	//e.Static(getStaticFilesPrefix(cfg.LocalStorage.StaticFilesPrefix), cfg.LocalStorage.PublicStoragePath)
 
 	categoryHandler := handlers.NewCategoryHandler(categoryService, minioService, cfg, logger)
 	productHandler := handlers.NewProductHandler(productService, logger)
+	productVariantHandler := handlers.NewProductVariantHandler(productVariantService, logger)
+	brandHandler := handlers.NewBrandHandler(brandService, logger)
+	reviewHandler := handlers.NewReviewHandler(reviewService, logger)
+	tagHandler := handlers.NewTagHandler(tagService, logger)
 
 	v1 := e.Group("/v1")
 	{
@@ -27,10 +32,53 @@ func Setup(e *echo.Echo, categoryService application.CategoryService, productSer
 			categories.GET("", categoryHandler.ListCategories)
 		}
 
+		brands := v1.Group("/brands")
+		{
+			brands.POST("", brandHandler.CreateBrand)
+			brands.GET("", brandHandler.ListBrands)
+			brands.GET("/:id", brandHandler.GetBrand)
+			brands.PATCH("/:id", brandHandler.UpdateBrand)
+			brands.GET("/:id/logo", brandHandler.GetLogo)
+		}
+
+		tags := v1.Group("/tags")
+		{
+			tags.POST("", tagHandler.CreateTag)
+			tags.GET("", tagHandler.ListTags)
+			tags.GET("/:id", tagHandler.GetTag)
+			tags.PATCH("/:id", tagHandler.UpdateTag)
+			tags.DELETE("/:id", tagHandler.DeleteTag)
+		}
+
 		products := v1.Group("/products")
 		{
 			products.POST("", productHandler.CreateProduct)
 			products.GET("", productHandler.ListProducts, pagination.New())
+			products.GET("/:id", productHandler.GetProduct)
+			products.PATCH("/:id", productHandler.UpdateProduct)
+
+			variants := products.Group("/:id/variants")
+			{
+				variants.POST("", productVariantHandler.CreateVariant)
+				variants.GET("", productVariantHandler.ListVariants)
+				variants.GET("/:variantId", productVariantHandler.GetVariant)
+				variants.PATCH("/:variantId", productVariantHandler.UpdateVariant)
+				variants.DELETE("/:variantId", productVariantHandler.DeleteVariant)
+			}
+
+			productTags := products.Group("/:id/tags")
+			{
+				productTags.POST("", tagHandler.AttachTags)
+				productTags.DELETE("/:tagId", tagHandler.DetachTag)
+			}
+
+			reviews := products.Group("/:id/reviews")
+			{
+				reviews.GET("", reviewHandler.ListReviews, pagination.New())
+				reviews.POST("", reviewHandler.CreateReview, tokenManager.EchoMiddleware())
+				reviews.PATCH("/:reviewId", reviewHandler.UpdateReview, tokenManager.EchoMiddleware())
+				reviews.DELETE("/:reviewId", reviewHandler.DeleteReview, tokenManager.EchoMiddleware())
+			}
 		}
 
 		e.GET("/images/:slug", categoryHandler.GetImage)
@@ -1,10 +1,27 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type Brand struct {
 	ID        BrandID
 	Name      string
+	Logo      *string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func NewBrand(name string) (*Brand, error) {
+
+	if name == "" {
+		return nil, errors.New("brand name cannot be empty")
+	}
+
+	return &Brand{
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -9,7 +9,21 @@ var (
 	ErrReviewNotFound             = errors.New("review not found")
 	ErrVariantNotFound            = errors.New("variant not found")
 	ErrTagNotFound                = errors.New("tag not found")
+	ErrReservationNotFound        = errors.New("stock reservation not found")
+	ErrReservationAlreadyExists   = errors.New("stock is already reserved for this order")
+	ErrReservationReleased        = errors.New("stock reservation has already been released")
+	ErrInvalidReservation         = errors.New("stock reservation needs an order id and positive quantities")
 	ErrCategoryAlreadyExists      = errors.New("a category with this name already exists at this level")
 	ErrCategoryDepthLimitExceeded = errors.New("category depth limit exceeded")
 	ErrCategoryHasProducts        = errors.New("cannot add sub-category to a category that already contains products")
+	ErrCategoryNotLeaf            = errors.New("products can only be added to a category without sub-categories")
+	ErrBrandLogoNotFound          = errors.New("brand has no logo")
+	ErrBrandAlreadyExists         = errors.New("a brand with this name already exists")
+	ErrTagAlreadyExists           = errors.New("a tag with this name already exists")
+	ErrReviewAlreadyExists        = errors.New("you have already reviewed this product")
+	ErrReviewNotAllowed           = errors.New("only customers who purchased this product can review it")
+	ErrReviewForbidden            = errors.New("you can only modify your own reviews")
+	ErrInvalidRating              = errors.New("rating must be between 1 and 5")
+	ErrSKUAlreadyExists           = errors.New("a variant with this sku already exists")
+	ErrInsufficientStock          = errors.New("insufficient stock for variant")
 )
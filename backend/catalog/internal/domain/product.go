@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"pkg/money"
+	"time"
+)
 
 // Product Entity
 type Product struct {
@@ -9,19 +13,43 @@ type Product struct {
 	Description     string
 	PrimaryImageURL *string
 	AverageRating   float64
+	ReviewCount     int
 	CategoryID      CategoryID
 	BrandID         *BrandID
 	Tags            []TagID
+	IsPublished     bool
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+func NewProduct(name, description string, categoryID CategoryID, brandID *BrandID, isPublished bool) (*Product, error) {
+
+	if name == "" {
+		return nil, errors.New("product name cannot be empty")
+	}
+
+	if categoryID == "" {
+		return nil, errors.New("product category cannot be empty")
+	}
+
+	return &Product{
+		Name:        name,
+		Description: description,
+		CategoryID:  categoryID,
+		BrandID:     brandID,
+		Tags:        []TagID{},
+		IsPublished: isPublished,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
 // ProductVariant Entity
 type ProductVariant struct {
-	ID         productVariantID
+	ID         ProductVariantID
 	ProductID  ProductID
 	SKU        string
-	Price      float64
+	Price      money.Money
 	Stock      int
 	Images     []Image
 	Attributes []Attribute
@@ -29,6 +57,36 @@ type ProductVariant struct {
 	UpdatedAt  time.Time
 }
 
+func NewProductVariant(productID ProductID, sku string, price money.Money, stock int, attributes []Attribute) (*ProductVariant, error) {
+
+	if sku == "" {
+		return nil, errors.New("variant sku cannot be empty")
+	}
+
+	if price.IsNegative() {
+		return nil, errors.New("variant price cannot be negative")
+	}
+
+	if err := money.ValidateCurrency(price.Currency); err != nil {
+		return nil, err
+	}
+
+	if stock < 0 {
+		return nil, errors.New("variant stock cannot be negative")
+	}
+
+	return &ProductVariant{
+		ProductID:  productID,
+		SKU:        sku,
+		Price:      price,
+		Stock:      stock,
+		Images:     []Image{},
+		Attributes: attributes,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}, nil
+}
+
 type Image struct {
 	URL       string
 	AltText   string
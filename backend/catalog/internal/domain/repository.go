@@ -20,34 +20,72 @@ type ProductRepository interface {
 	Create(ctx context.Context, product *Product) error
 	Update(ctx context.Context, product *Product) error
 	FindByID(ctx context.Context, id ProductID) (*Product, error)
+	FindByIDs(ctx context.Context, ids []ProductID) ([]*Product, error)
 	FindAll(ctx context.Context, filterQuery bson.M, sortOptions bson.D, page, limit int) ([]*Product, int64, error)
 	CategoryHasProducts(ctx context.Context, id CategoryID) (bool, error)
+	// UpdateRating adds the deltas to the product's rating sum and review count and recomputes AverageRating in one atomic update.
+	UpdateRating(ctx context.Context, id ProductID, ratingDelta, countDelta int) error
+	AddTags(ctx context.Context, id ProductID, tagIDs []TagID) error
+	RemoveTag(ctx context.Context, id ProductID, tagID TagID) error
+	RemoveTagFromAll(ctx context.Context, tagID TagID) error
 	// TODO: Add method for searching and filtering products
 }
 
 type ProductVariantRepository interface {
 	Create(ctx context.Context, productVariant *ProductVariant) error
+	// Update saves everything but the stock, which only changes through AdjustStock.
 	Update(ctx context.Context, productVariant *ProductVariant) error
 	FindByProductID(ctx context.Context, id ProductID) ([]*ProductVariant, error)
-	FindByID(ctx context.Context, id productVariantID) (*ProductVariant, error)
+	// FindByProductIDs returns the variants of all the given products in one query, oldest first.
+	FindByProductIDs(ctx context.Context, ids []ProductID) ([]*ProductVariant, error)
+	FindByID(ctx context.Context, id ProductVariantID) (*ProductVariant, error)
+	Delete(ctx context.Context, id ProductVariantID) error
+	// AdjustStock atomically adds delta to the variant stock, refusing to take it below zero.
+	AdjustStock(ctx context.Context, id ProductVariantID, delta int) error
 }
 
 type BrandRepository interface {
 	Create(ctx context.Context, brand *Brand) error
 	Update(ctx context.Context, brand *Brand) error
 	FindByID(ctx context.Context, id BrandID) (*Brand, error)
+	FindByName(ctx context.Context, name string) (*Brand, error)
 	FindAll(ctx context.Context) ([]*Brand, error)
 }
 
 type TagRepository interface {
 	Create(ctx context.Context, tag *Tag) error
 	Update(ctx context.Context, tag *Tag) error
+	Delete(ctx context.Context, id TagID) error
 	FindByID(ctx context.Context, id TagID) (*Tag, error)
 	FindAll(ctx context.Context) ([]*Tag, error)
 }
 
 type ReviewRepository interface {
 	Create(ctx context.Context, review *Review) error
-	Update(ctx context.Context, review *Review) error
-	FindByProductID(ctx context.Context, id ProductID) (*Review, error)
+	// Update and Delete return the review as it was just before the write, so rating aggregates can be
+	// adjusted by what was actually replaced.
+	Update(ctx context.Context, review *Review) (*Review, error)
+	Delete(ctx context.Context, id ReviewID) (*Review, error)
+	FindByID(ctx context.Context, id ReviewID) (*Review, error)
+	FindByProductID(ctx context.Context, id ProductID, page, limit int) ([]*Review, int64, error)
+}
+
+type StockReservationRepository interface {
+	Create(ctx context.Context, reservation *StockReservation) error
+	FindByOrderID(ctx context.Context, orderID string) (*StockReservation, error)
+	// UpdateStatus moves the reservation from one status to another and reports whether it did;
+	// false means the reservation was not in the expected status.
+	UpdateStatus(ctx context.Context, orderID string, from, to ReservationStatus) (bool, error)
+	// ClaimItem marks the item at index applied and reports whether it did; false means it already was or
+	// was released, so only one caller takes the item off the stock.
+	ClaimItem(ctx context.Context, orderID string, index int) (bool, error)
+	// UnclaimItem marks the item at index pending again, after the caller that claimed it gave it back
+	// to the stock or never took it.
+	UnclaimItem(ctx context.Context, orderID string, index int) error
+	// ReleaseItem marks the item at index of a RESERVED reservation released and returns it as it was just
+	// before; nil means it already was released or the reservation is no longer RESERVED, so only one
+	// caller hands the item back to the stock.
+	ReleaseItem(ctx context.Context, orderID string, index int) (*ReservedItem, error)
+	// UnreleaseItem clears the release of the item at index, after its stock could not be handed back.
+	UnreleaseItem(ctx context.Context, orderID string, index int) error
 }
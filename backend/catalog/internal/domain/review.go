@@ -1,6 +1,14 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
 
 type Review struct {
 	ID        ReviewID
@@ -11,3 +19,24 @@ type Review struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func NewReview(productID ProductID, userID string, rating int, comment string) (*Review, error) {
+
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return nil, ErrInvalidRating
+	}
+
+	return &Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    rating,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// PurchaseVerifier reports whether a user has a PAID order containing the product.
+type PurchaseVerifier interface {
+	HasPurchased(ctx context.Context, userID string, productID ProductID) (bool, error)
+}
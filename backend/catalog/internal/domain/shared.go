@@ -4,5 +4,5 @@ type ProductID string
 type CategoryID string
 type BrandID string
 type ReviewID string
-type productVariantID string
+type ProductVariantID string
 type TagID string
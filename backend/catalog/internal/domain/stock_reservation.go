@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationReserved  ReservationStatus = "RESERVED"
+	ReservationCommitted ReservationStatus = "COMMITTED"
+	ReservationReleased  ReservationStatus = "RELEASED"
+)
+
+type ReservedItem struct {
+	VariantID ProductVariantID
+	Quantity  int
+	Applied   bool // Whether the quantity has been taken off the variant stock
+	Released  bool // Whether the item has been handed back, or will never be taken, because the order was cancelled
+}
+
+// StockReservation holds variant stock for one order; the order id is its idempotency key.
+type StockReservation struct {
+	ID        string
+	OrderID   string
+	Items     []ReservedItem
+	Status    ReservationStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Released reports whether the reservation was released, or a release has started handing its items back.
+func (r *StockReservation) Released() bool {
+	if r.Status == ReservationReleased {
+		return true
+	}
+	for _, item := range r.Items {
+		if item.Released {
+			return true
+		}
+	}
+	return false
+}
+
+func NewStockReservation(orderID string, items []ReservedItem) (*StockReservation, error) {
+	if strings.TrimSpace(orderID) == "" || len(items) == 0 {
+		return nil, ErrInvalidReservation
+	}
+
+	for _, item := range items {
+		if item.VariantID == "" || item.Quantity <= 0 {
+			return nil, ErrInvalidReservation
+		}
+	}
+
+	return &StockReservation{
+		OrderID: orderID,
+		Items:   items,
+		Status:  ReservationReserved,
+	}, nil
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type Tag struct {
 	ID        TagID
@@ -8,3 +11,16 @@ type Tag struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+func NewTag(name string) (*Tag, error) {
+
+	if name == "" {
+		return nil, errors.New("tag name cannot be empty")
+	}
+
+	return &Tag{
+		Name:      name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"catalog/config"
 	"catalog/internal/adapters"
+	orderadapter "catalog/internal/adapters/order"
 	"catalog/internal/application/services"
 	"catalog/internal/delivery/http/error_mapping"
 	"context"
@@ -11,6 +12,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"pkg/auth"
 	"pkg/echo/error_handler"
 	"pkg/logger"
 	"pkg/minio"
@@ -30,8 +32,9 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
-	pb "catalog/api/proto/v1"
+	pb "api/proto/catalog/v1"
 
 	grpcserver "catalog/internal/delivery/grpc"
 	httpserver "catalog/internal/delivery/http/router"
@@ -115,13 +118,29 @@ func run(ctx context.Context, cfg *config.Config, appLogger *zap.Logger) error {
 	//	return fmt.Errorf("failed to create local storage service: %w", err)
 	//}
 
+	// --- Auth ---
+	tokenManager := auth.NewTokenManager(cfg.Auth.JWTSecret)
+
+	// --- Order Service Client ---
+	orderConn, err := grpc.NewClient(cfg.Services.OrderGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to connect to order-service: %w", err)
+	}
+	defer func(orderConn *grpc.ClientConn) {
+		_ = orderConn.Close()
+	}(orderConn)
+	purchaseVerifier := orderadapter.NewOrderClient(orderConn, cfg.Auth.ServiceKey, appLogger)
+
 	// --- Application Services ---
-	service := services.NewService(adapter.ProductRepo, adapter.CategoryRepo, appLogger)
+	service := services.NewService(adapter.ProductRepo, adapter.ProductVariantRepo, adapter.CategoryRepo, adapter.BrandRepo, adapter.ReviewRepo, adapter.TagRepo, adapter.StockReservationRepo, purchaseVerifier, minioService, appLogger)
 
 	grpcServerDeps := grpcserver.ServerDependencies{
-		ProductService:  service.ProductService,
-		CategoryService: service.CategoryService,
-		Logger:          appLogger,
+		ProductService:        service.ProductService,
+		ProductVariantService: service.ProductVariantService,
+		CategoryService:       service.CategoryService,
+		BrandService:          service.BrandService,
+		InventoryService:      service.InventoryService,
+		Logger:                appLogger,
 	}
 
 	// --- Delivery Layer (gRPC Handler) ---
@@ -135,7 +154,7 @@ func run(ctx context.Context, cfg *config.Config, appLogger *zap.Logger) error {
 
 	// Setup Echo
 	go func() {
-		errCh <- runHTTPServer(cfg.General.HTTPPort, service.CategoryService, service.ProductService, minioService, cfg, appLogger)
+		errCh <- runHTTPServer(cfg.General.HTTPPort, service.CategoryService, service.ProductService, service.ProductVariantService, service.BrandService, service.ReviewService, service.TagService, tokenManager, minioService, cfg, appLogger)
 	}()
 
 	select {
@@ -174,7 +193,7 @@ func runGRPCServer(port string, handler pb.CatalogServiceServer, appLogger *zap.
 	return gRPCServer.Serve(lis)
 }
 
-func runHTTPServer(port string, catSvc services.CategoryService, prodSvc services.ProductService, minioService *minio.Service, cfg *config.Config, appLogger *zap.Logger) error {
+func runHTTPServer(port string, catSvc services.CategoryService, prodSvc services.ProductService, variantSvc services.ProductVariantService, brandSvc services.BrandService, reviewSvc services.ReviewService, tagSvc services.TagService, tokenManager *auth.TokenManager, minioService *minio.Service, cfg *config.Config, appLogger *zap.Logger) error {
 	appLogger.Info("starting HTTP (Echo) server...", zap.String("port", port))
 	e := echo.New()
 
@@ -191,7 +210,7 @@ func runHTTPServer(port string, catSvc services.CategoryService, prodSvc service
 
 	e.HTTPErrorHandler = error_handler.NewHTTPErrorHandler(domainErrorMappings, appLogger)
 
-	httpserver.Setup(e, catSvc, prodSvc, minioService, cfg, appLogger)
+	httpserver.Setup(e, catSvc, prodSvc, variantSvc, brandSvc, reviewSvc, tagSvc, tokenManager, minioService, cfg, appLogger)
 
 	appLogger.Info("HTTP (Echo) Server is running on", zap.String("port", port))
 	if err := e.Start(port); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -86,7 +86,10 @@ func runGRPCServer(port string, handler pb.IdentityServiceServer, tokenManager *
 		return fmt.Errorf("failed to listen on %s: %w", port, err)
 	}
 
-	gRPCServer := grpc.NewServer(grpc.UnaryInterceptor(tokenManager.AuthenticationInterceptor))
+	interceptor := tokenManager.NewAuthenticationInterceptor(
+		auth.WithPublicMethods(pb.IdentityService_Register_FullMethodName, pb.IdentityService_Login_FullMethodName),
+	)
+	gRPCServer := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
 	pb.RegisterIdentityServiceServer(gRPCServer, handler)
 
 	appLogger.Info("gRPC Server is running", zap.String("port", port))
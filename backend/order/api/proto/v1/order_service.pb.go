@@ -1,533 +0,0 @@
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.36.6
-// 	protoc        v3.21.12
-// source: order/v1/order_service.proto
-
-package v1
-
-import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	reflect "reflect"
-	sync "sync"
-	unsafe "unsafe"
-)
-
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
-
-// --- Cart ---
-type CartItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CartItem) Reset() {
-	*x = CartItem{}
-	mi := &file_order_v1_order_service_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CartItem) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CartItem) ProtoMessage() {}
-
-func (x *CartItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CartItem.ProtoReflect.Descriptor instead.
-func (*CartItem) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{0}
-}
-
-func (x *CartItem) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
-}
-
-func (x *CartItem) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type Cart struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Cart) Reset() {
-	*x = Cart{}
-	mi := &file_order_v1_order_service_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Cart) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Cart) ProtoMessage() {}
-
-func (x *Cart) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Cart.ProtoReflect.Descriptor instead.
-func (*Cart) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *Cart) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *Cart) GetItems() []*CartItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
-}
-
-type AddItemToCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *AddItemToCartRequest) Reset() {
-	*x = AddItemToCartRequest{}
-	mi := &file_order_v1_order_service_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *AddItemToCartRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*AddItemToCartRequest) ProtoMessage() {}
-
-func (x *AddItemToCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[2]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use AddItemToCartRequest.ProtoReflect.Descriptor instead.
-func (*AddItemToCartRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *AddItemToCartRequest) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
-}
-
-func (x *AddItemToCartRequest) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type GetCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetCartRequest) Reset() {
-	*x = GetCartRequest{}
-	mi := &file_order_v1_order_service_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetCartRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetCartRequest) ProtoMessage() {}
-
-func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[3]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
-func (*GetCartRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{3}
-}
-
-// --- Order ---
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_order_v1_order_service_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *OrderItem) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*OrderItem) ProtoMessage() {}
-
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[4]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{4}
-}
-
-func (x *OrderItem) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
-}
-
-func (x *OrderItem) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-func (x *OrderItem) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_order_v1_order_service_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Order) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Order) ProtoMessage() {}
-
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[5]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *Order) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Order) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *Order) GetItems() []*OrderItem {
-	if x != nil {
-		return x.Items
-	}
-	return nil
-}
-
-func (x *Order) GetTotalPrice() float64 {
-	if x != nil {
-		return x.TotalPrice
-	}
-	return 0
-}
-
-func (x *Order) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-type CreateOrderFromCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateOrderFromCartRequest) Reset() {
-	*x = CreateOrderFromCartRequest{}
-	mi := &file_order_v1_order_service_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateOrderFromCartRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateOrderFromCartRequest) ProtoMessage() {}
-
-func (x *CreateOrderFromCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[6]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateOrderFromCartRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderFromCartRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{6}
-}
-
-type CreateOrderFromCartResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateOrderFromCartResponse) Reset() {
-	*x = CreateOrderFromCartResponse{}
-	mi := &file_order_v1_order_service_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateOrderFromCartResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateOrderFromCartResponse) ProtoMessage() {}
-
-func (x *CreateOrderFromCartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_service_proto_msgTypes[7]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CreateOrderFromCartResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrderFromCartResponse) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_service_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *CreateOrderFromCartResponse) GetOrder() *Order {
-	if x != nil {
-		return x.Order
-	}
-	return nil
-}
-
-var File_order_v1_order_service_proto protoreflect.FileDescriptor
-
-const file_order_v1_order_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1corder/v1/order_service.proto\x12\x02v1\"E\n" +
-	"\bCartItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"C\n" +
-	"\x04Cart\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
-	"\x05items\x18\x02 \x03(\v2\f.v1.CartItemR\x05items\"Q\n" +
-	"\x14AddItemToCartRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x10\n" +
-	"\x0eGetCartRequest\"\\\n" +
-	"\tOrderItem\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"\x8e\x01\n" +
-	"\x05Order\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
-	"\x05items\x18\x03 \x03(\v2\r.v1.OrderItemR\x05items\x12\x1f\n" +
-	"\vtotal_price\x18\x04 \x01(\x01R\n" +
-	"totalPrice\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"\x1c\n" +
-	"\x1aCreateOrderFromCartRequest\">\n" +
-	"\x1bCreateOrderFromCartResponse\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.v1.OrderR\x05order2\xc4\x01\n" +
-	"\fOrderService\x123\n" +
-	"\rAddItemToCart\x12\x18.v1.AddItemToCartRequest\x1a\b.v1.Cart\x12'\n" +
-	"\aGetCart\x12\x12.v1.GetCartRequest\x1a\b.v1.Cart\x12V\n" +
-	"\x13CreateOrderFromCart\x12\x1e.v1.CreateOrderFromCartRequest\x1a\x1f.v1.CreateOrderFromCartResponseB\rZ\vorder/v1;v1b\x06proto3"
-
-var (
-	file_order_v1_order_service_proto_rawDescOnce sync.Once
-	file_order_v1_order_service_proto_rawDescData []byte
-)
-
-func file_order_v1_order_service_proto_rawDescGZIP() []byte {
-	file_order_v1_order_service_proto_rawDescOnce.Do(func() {
-		file_order_v1_order_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_v1_order_service_proto_rawDesc), len(file_order_v1_order_service_proto_rawDesc)))
-	})
-	return file_order_v1_order_service_proto_rawDescData
-}
-
-var file_order_v1_order_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_order_v1_order_service_proto_goTypes = []any{
-	(*CartItem)(nil),                    // 0: v1.CartItem
-	(*Cart)(nil),                        // 1: v1.Cart
-	(*AddItemToCartRequest)(nil),        // 2: v1.AddItemToCartRequest
-	(*GetCartRequest)(nil),              // 3: v1.GetCartRequest
-	(*OrderItem)(nil),                   // 4: v1.OrderItem
-	(*Order)(nil),                       // 5: v1.Order
-	(*CreateOrderFromCartRequest)(nil),  // 6: v1.CreateOrderFromCartRequest
-	(*CreateOrderFromCartResponse)(nil), // 7: v1.CreateOrderFromCartResponse
-}
-var file_order_v1_order_service_proto_depIdxs = []int32{
-	0, // 0: v1.Cart.items:type_name -> v1.CartItem
-	4, // 1: v1.Order.items:type_name -> v1.OrderItem
-	5, // 2: v1.CreateOrderFromCartResponse.order:type_name -> v1.Order
-	2, // 3: v1.OrderService.AddItemToCart:input_type -> v1.AddItemToCartRequest
-	3, // 4: v1.OrderService.GetCart:input_type -> v1.GetCartRequest
-	6, // 5: v1.OrderService.CreateOrderFromCart:input_type -> v1.CreateOrderFromCartRequest
-	1, // 6: v1.OrderService.AddItemToCart:output_type -> v1.Cart
-	1, // 7: v1.OrderService.GetCart:output_type -> v1.Cart
-	7, // 8: v1.OrderService.CreateOrderFromCart:output_type -> v1.CreateOrderFromCartResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_order_v1_order_service_proto_init() }
-func file_order_v1_order_service_proto_init() {
-	if File_order_v1_order_service_proto != nil {
-		return
-	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_v1_order_service_proto_rawDesc), len(file_order_v1_order_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_order_v1_order_service_proto_goTypes,
-		DependencyIndexes: file_order_v1_order_service_proto_depIdxs,
-		MessageInfos:      file_order_v1_order_service_proto_msgTypes,
-	}.Build()
-	File_order_v1_order_service_proto = out.File
-	file_order_v1_order_service_proto_goTypes = nil
-	file_order_v1_order_service_proto_depIdxs = nil
-}
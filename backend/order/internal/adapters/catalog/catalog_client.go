@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	catalogpb "api/proto/catalog/v1"
+	"context"
+	"fmt"
+	"order/internal/domain"
+	"pkg/money"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type catalogClient struct {
+	client catalogpb.CatalogServiceClient
+	logger *zap.Logger
+}
+
+func NewCatalogClient(conn grpc.ClientConnInterface, logger *zap.Logger) domain.ProductCatalog {
+	return &catalogClient{
+		client: catalogpb.NewCatalogServiceClient(conn),
+		logger: logger.Named("grpc_catalog_client"),
+	}
+}
+
+func (c *catalogClient) ResolvePrices(ctx context.Context, items []domain.CartItem) ([]domain.ProductPrice, error) {
+	c.logger.Info("resolving prices from catalog-service", zap.Int("count", len(items)))
+
+	lookups := make([]*catalogpb.PriceLookup, len(items))
+	for i, item := range items {
+		lookups[i] = &catalogpb.PriceLookup{ProductId: item.ProductID, VariantId: item.VariantID}
+	}
+
+	res, err := c.client.ResolvePrices(ctx, &catalogpb.ResolvePricesRequest{Items: lookups})
+	if err != nil {
+		c.logger.Error("failed to resolve prices from catalog-service", zap.Error(err))
+		return nil, fmt.Errorf("failed to resolve prices from catalog-service: %w", err)
+	}
+
+	if len(res.GetItems()) != len(items) {
+		return nil, fmt.Errorf("catalog-service returned %d prices for %d items", len(res.GetItems()), len(items))
+	}
+
+	prices := make([]domain.ProductPrice, len(res.GetItems()))
+	for i, item := range res.GetItems() {
+		prices[i] = domain.ProductPrice{
+			ProductID:  item.GetProductId(),
+			VariantID:  item.GetVariantId(),
+			SKU:        item.GetSku(),
+			Name:       item.GetName(),
+			ImageURL:   item.GetImageUrl(),
+			CategoryID: item.GetCategoryId(),
+			UnitPrice:  money.New(item.GetUnitPrice().GetAmount(), item.GetUnitPrice().GetCurrency()),
+			Stock:      int(item.GetStock()),
+			Published:  item.GetIsPublished(),
+			Found:      item.GetFound(),
+		}
+	}
+
+	return prices, nil
+}
+
+func (c *catalogClient) ReserveStock(ctx context.Context, orderID domain.OrderID, items []domain.OrderItem) error {
+	c.logger.Info("reserving stock in catalog-service", zap.String("order_id", string(orderID)), zap.Int("count", len(items)))
+
+	stockItems := make([]*catalogpb.StockItem, len(items))
+	for i, item := range items {
+		stockItems[i] = &catalogpb.StockItem{VariantId: item.VariantID, Quantity: int32(item.Quantity)}
+	}
+
+	_, err := c.client.ReserveStock(ctx, &catalogpb.ReserveStockRequest{OrderId: string(orderID), Items: stockItems})
+	if err != nil {
+		c.logger.Error("failed to reserve stock in catalog-service", zap.Error(err))
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return fmt.Errorf("%w: %s", domain.ErrInsufficientStock, status.Convert(err).Message())
+		case codes.NotFound:
+			return fmt.Errorf("%w: %s", domain.ErrProductUnavailable, status.Convert(err).Message())
+		}
+		return fmt.Errorf("failed to reserve stock in catalog-service: %w", err)
+	}
+
+	return nil
+}
+
+func (c *catalogClient) CommitStock(ctx context.Context, orderID domain.OrderID) error {
+	c.logger.Info("committing stock in catalog-service", zap.String("order_id", string(orderID)))
+
+	if _, err := c.client.CommitStock(ctx, &catalogpb.CommitStockRequest{OrderId: string(orderID)}); err != nil {
+		c.logger.Error("failed to commit stock in catalog-service", zap.Error(err))
+		return fmt.Errorf("failed to commit stock in catalog-service: %w", err)
+	}
+
+	return nil
+}
+
+func (c *catalogClient) ReleaseStock(ctx context.Context, orderID domain.OrderID) error {
+	c.logger.Info("releasing stock in catalog-service", zap.String("order_id", string(orderID)))
+
+	if _, err := c.client.ReleaseStock(ctx, &catalogpb.ReleaseStockRequest{OrderId: string(orderID)}); err != nil {
+		c.logger.Error("failed to release stock in catalog-service", zap.Error(err))
+		return fmt.Errorf("failed to release stock in catalog-service: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Package chainstest provides an in-memory chain backend for tests.
+package chainstest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"order/internal/domain"
+	"pkg/wallet"
+	"sync"
+)
+
+// Output is a transfer of Value satoshi to Address.
+type Output struct {
+	Address string
+	Value   int64
+}
+
+type regtestTx struct {
+	id      string
+	from    string
+	outputs []Output
+}
+
+type regtestBlock struct {
+	hash       string
+	parentHash string
+	txs        []regtestTx
+}
+
+// Regtest is a Bitcoin regtest chain kept in memory. It implements domain.ChainBackend, so the payment
+// watcher can be tested without a node: transactions are queued with Pay and included by Mine, and
+// Reorg replaces the latest blocks to simulate a reorganisation.
+type Regtest struct {
+	mu      sync.Mutex
+	blocks  []regtestBlock // By height, starting with the genesis block
+	mempool []regtestTx
+	nonce   int // Makes every hash unique, also across forks
+	heads   []chan<- struct{}
+}
+
+func NewRegtest() *Regtest {
+	r := &Regtest{}
+	r.blocks = append(r.blocks, regtestBlock{hash: r.newHash("block")})
+	return r
+}
+
+func (r *Regtest) newHash(kind string) string {
+	r.nonce++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s-%d", kind, r.nonce)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Pay queues a transaction from from with outputs and returns its id. It is included by the next Mine.
+func (r *Regtest) Pay(from string, outputs ...Output) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := regtestTx{id: r.newHash("tx"), from: from, outputs: outputs}
+	r.mempool = append(r.mempool, tx)
+	return tx.id
+}
+
+// Mine appends n blocks, the first one including the queued transactions, and returns the hash of the last one.
+func (r *Regtest) Mine(n int) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		r.blocks = append(r.blocks, regtestBlock{
+			hash:       r.newHash("block"),
+			parentHash: r.blocks[len(r.blocks)-1].hash,
+			txs:        r.mempool,
+		})
+		r.mempool = nil
+	}
+
+	for _, heads := range r.heads {
+		select {
+		case heads <- struct{}{}:
+		default:
+		}
+	}
+	return r.blocks[len(r.blocks)-1].hash
+}
+
+// Reorg drops the latest depth blocks. Their transactions are dropped as well unless keepTxs is set,
+// in which case they go back to the mempool to be mined again. Mine builds the replacing chain.
+func (r *Regtest) Reorg(depth int, keepTxs bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if depth >= len(r.blocks) {
+		panic("chainstest: cannot reorganise the genesis block")
+	}
+	dropped := r.blocks[len(r.blocks)-depth:]
+	r.blocks = r.blocks[:len(r.blocks)-depth]
+
+	if !keepTxs {
+		return
+	}
+	var txs []regtestTx
+	for _, block := range dropped {
+		txs = append(txs, block.txs...)
+	}
+	r.mempool = append(txs, r.mempool...)
+}
+
+// BlockHash returns the hash of the canonical block at number.
+func (r *Regtest) BlockHash(number uint64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.blocks[number].hash
+}
+
+func (r *Regtest) Chain() string {
+	return domain.ChainBitcoin
+}
+
+func (r *Regtest) Head(_ context.Context) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return uint64(len(r.blocks) - 1), nil
+}
+
+func (r *Regtest) Block(_ context.Context, number uint64) (*domain.ChainBlock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if number >= uint64(len(r.blocks)) {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	block := r.blocks[number]
+	return &domain.ChainBlock{Number: number, Hash: block.hash, ParentHash: block.parentHash}, nil
+}
+
+func (r *Regtest) Transfers(_ context.Context, block *domain.ChainBlock, addresses map[string]bool) ([]domain.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if block.Number >= uint64(len(r.blocks)) || r.blocks[block.Number].hash != block.Hash {
+		return nil, fmt.Errorf("block %s not found", block.Hash)
+	}
+
+	var payments []domain.Payment
+	for _, tx := range r.blocks[block.Number].txs {
+		for i, out := range tx.outputs {
+			if !addresses[out.Address] {
+				continue
+			}
+			payments = append(payments, domain.Payment{
+				Chain:       domain.ChainBitcoin,
+				Asset:       domain.AssetBTC,
+				TxHash:      tx.id,
+				BlockNumber: block.Number,
+				BlockHash:   block.Hash,
+				LogIndex:    i,
+				FromAddress: tx.from,
+				ToAddress:   out.Address,
+				Value:       big.NewInt(out.Value),
+			})
+		}
+	}
+	return payments, nil
+}
+
+func (r *Regtest) NormalizeAddress(address string) (string, bool) {
+	return wallet.NormalizeBitcoinAddress(address, wallet.BitcoinRegtest)
+}
+
+// WatchHeads signals on heads after every Mine until ctx is done.
+func (r *Regtest) WatchHeads(ctx context.Context, heads chan<- struct{}) error {
+	r.mu.Lock()
+	r.heads = append(r.heads, heads)
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,211 @@
+package chains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"order/internal/domain"
+	"pkg/wallet"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// esploraPageSize is the number of transactions Esplora returns per page of a block.
+const esploraPageSize = 25
+
+// esploraBackend follows Bitcoin through the REST API of Esplora, which is also served by electrs,
+// the Electrum server of Blockstream. Esplora cannot push new blocks, so the tip is polled.
+type esploraBackend struct {
+	baseURL      string
+	network      string
+	pollInterval time.Duration
+	client       *http.Client
+	logger       *zap.Logger
+}
+
+type esploraBlock struct {
+	ID                string `json:"id"`
+	Height            uint64 `json:"height"`
+	PreviousBlockHash string `json:"previousblockhash"`
+	TxCount           int    `json:"tx_count"`
+}
+
+type esploraTx struct {
+	TxID string `json:"txid"`
+	Vin  []struct {
+		Prevout *struct {
+			ScriptPubKeyAddress string `json:"scriptpubkey_address"`
+		} `json:"prevout"`
+	} `json:"vin"`
+	Vout []struct {
+		ScriptPubKeyAddress string `json:"scriptpubkey_address"`
+		Value               int64  `json:"value"`
+	} `json:"vout"`
+}
+
+// NewEsploraBackend follows the Bitcoin network (mainnet, testnet or regtest) served at baseURL,
+// e.g. https://blockstream.info/api.
+func NewEsploraBackend(baseURL, network string, pollInterval time.Duration, logger *zap.Logger) domain.ChainBackend {
+	return &esploraBackend{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		network:      network,
+		pollInterval: pollInterval,
+		client:       &http.Client{Timeout: 30 * time.Second},
+		logger:       logger.Named("esplora_backend"),
+	}
+}
+
+func (b *esploraBackend) Chain() string {
+	return domain.ChainBitcoin
+}
+
+func (b *esploraBackend) Head(ctx context.Context) (uint64, error) {
+	height, err := b.getText(ctx, "/blocks/tip/height")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(height, 10, 64)
+}
+
+func (b *esploraBackend) Block(ctx context.Context, number uint64) (*domain.ChainBlock, error) {
+	hash, err := b.getText(ctx, fmt.Sprintf("/block-height/%d", number))
+	if err != nil {
+		return nil, err
+	}
+
+	var block esploraBlock
+	if err := b.getJSON(ctx, "/block/"+hash, &block); err != nil {
+		return nil, err
+	}
+	return &domain.ChainBlock{
+		Number:     block.Height,
+		Hash:       block.ID,
+		ParentHash: block.PreviousBlockHash,
+	}, nil
+}
+
+// Transfers reads the block by hash, page by page, and reports every output paying into addresses.
+func (b *esploraBackend) Transfers(ctx context.Context, block *domain.ChainBlock, addresses map[string]bool) ([]domain.Payment, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	var info esploraBlock
+	if err := b.getJSON(ctx, "/block/"+block.Hash, &info); err != nil {
+		return nil, err
+	}
+
+	var payments []domain.Payment
+	for start := 0; start < info.TxCount; start += esploraPageSize {
+		var txs []esploraTx
+		if err := b.getJSON(ctx, fmt.Sprintf("/block/%s/txs/%d", block.Hash, start), &txs); err != nil {
+			return nil, err
+		}
+
+		for _, tx := range txs {
+			var from string
+			if len(tx.Vin) > 0 && tx.Vin[0].Prevout != nil {
+				from = tx.Vin[0].Prevout.ScriptPubKeyAddress
+			}
+
+			for i, out := range tx.Vout {
+				if !addresses[out.ScriptPubKeyAddress] || out.Value <= 0 {
+					continue
+				}
+				payments = append(payments, domain.Payment{
+					Chain:       domain.ChainBitcoin,
+					Asset:       domain.AssetBTC,
+					TxHash:      tx.TxID,
+					BlockNumber: block.Number,
+					BlockHash:   block.Hash,
+					LogIndex:    i,
+					FromAddress: from,
+					ToAddress:   out.ScriptPubKeyAddress,
+					Value:       big.NewInt(out.Value),
+				})
+			}
+		}
+	}
+
+	return payments, nil
+}
+
+func (b *esploraBackend) NormalizeAddress(address string) (string, bool) {
+	return wallet.NormalizeBitcoinAddress(address, b.network)
+}
+
+// WatchHeads polls the hash of the tip every poll interval and signals when it changes.
+func (b *esploraBackend) WatchHeads(ctx context.Context, heads chan<- struct{}) error {
+	ticker := time.NewTicker(b.pollInterval)
+	defer ticker.Stop()
+
+	var tip string
+	for {
+		select {
+		case <-ticker.C:
+			hash, err := b.getText(ctx, "/blocks/tip/hash")
+			if err != nil {
+				return err
+			}
+			if hash == tip {
+				continue
+			}
+			tip = hash
+			select {
+			case heads <- struct{}{}:
+			default: // A sync is pending already
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (b *esploraBackend) getText(ctx context.Context, path string) (string, error) {
+	body, err := b.get(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func (b *esploraBackend) getJSON(ctx context.Context, path string, v any) error {
+	body, err := b.get(ctx, path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode esplora response of %s: %w", path, err)
+	}
+	return nil
+}
+
+func (b *esploraBackend) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("esplora request %s failed: %w", path, err)
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("esplora request %s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,81 @@
+package chains
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"order/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+)
+
+func TestEsploraBackend_Transfers(t *testing.T) {
+	is := is.New(t)
+
+	const (
+		blockHash = "0000000000000000000000000000000000000000000000000000000000000b0b"
+		parent    = "0000000000000000000000000000000000000000000000000000000000000a0a"
+		watched   = "bcrt1qwatched"
+	)
+
+	// The block has 27 transactions, so the payments span two pages.
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blocks/tip/height", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "101")
+	})
+	mux.HandleFunc("/block-height/101", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, blockHash)
+	})
+	mux.HandleFunc("/block/"+blockHash, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"id":%q,"height":101,"previousblockhash":%q,"tx_count":27}`, blockHash, parent)
+	})
+	mux.HandleFunc("/block/"+blockHash+"/txs/0", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"txid":"coinbase","vin":[{"prevout":null}],"vout":[{"scriptpubkey_address":"bcrt1qminer","value":5000000000}]},
+			{"txid":"first","vin":[{"prevout":{"scriptpubkey_address":"bcrt1qsender"}}],
+			 "vout":[{"scriptpubkey_address":"bcrt1qchange","value":1000},{"scriptpubkey_address":%q,"value":40000000}]}
+		]`, watched)
+	})
+	mux.HandleFunc("/block/"+blockHash+"/txs/25", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[
+			{"txid":"second","vin":[{"prevout":{"scriptpubkey_address":"bcrt1qother"}}],
+			 "vout":[{"scriptpubkey_address":%q,"value":60000000},{"value":0}]}
+		]`, watched)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	backend := NewEsploraBackend(server.URL+"/", "regtest", time.Second, zap.NewNop())
+
+	head, err := backend.Head(ctx)
+	is.NoErr(err)
+	is.Equal(head, uint64(101))
+	block, err := backend.Block(ctx, head)
+	is.NoErr(err)
+	is.Equal(*block, domain.ChainBlock{Number: 101, Hash: blockHash, ParentHash: parent})
+
+	payments, err := backend.Transfers(ctx, block, map[string]bool{watched: true})
+	is.NoErr(err)
+	is.Equal(len(payments), 2) // one payment per page
+	first, second := payments[0], payments[1]
+	is.Equal(first.TxHash, "first")
+	is.Equal(first.LogIndex, 1) // the output index
+	is.Equal(first.FromAddress, "bcrt1qsender")
+	is.Equal(first.Value.Int64(), int64(40000000))
+	is.Equal(second.TxHash, "second")
+	is.Equal(second.LogIndex, 0)
+	is.Equal(second.FromAddress, "bcrt1qother")
+	is.Equal(second.Value.Int64(), int64(60000000))
+	for _, p := range payments {
+		is.Equal(p.Chain, domain.ChainBitcoin)
+		is.Equal(p.Asset, domain.AssetBTC)
+		is.Equal(p.BlockNumber, uint64(101))
+		is.Equal(p.BlockHash, blockHash)
+		is.Equal(p.ToAddress, watched)
+	}
+}
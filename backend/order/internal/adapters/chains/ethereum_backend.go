@@ -0,0 +1,200 @@
+package chains
+
+import (
+	"context"
+	"math/big"
+	"order/internal/domain"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// transferTopic is the topic of the ERC-20 Transfer(address indexed from, address indexed to, uint256 value) event.
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// EthereumClient is the part of an Ethereum client the backend uses. *ethclient.Client and the
+// client of go-ethereum's simulated backend both implement it.
+type EthereumClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// ethereumBackend reports ETH transactions and the Transfer events of the registered ERC-20 tokens.
+type ethereumBackend struct {
+	client    EthereumClient
+	tokens    domain.TokenRegistry
+	contracts []common.Address // Of the registered tokens
+	signer    types.Signer
+	logger    *zap.Logger
+}
+
+func NewEthereumBackend(client EthereumClient, tokens domain.TokenRegistry, logger *zap.Logger) domain.ChainBackend {
+	contracts := make([]common.Address, 0, len(tokens))
+	for _, token := range tokens {
+		contracts = append(contracts, common.HexToAddress(token.Contract))
+	}
+
+	return &ethereumBackend{
+		client:    client,
+		tokens:    tokens,
+		contracts: contracts,
+		logger:    logger.Named("ethereum_backend"),
+	}
+}
+
+func (b *ethereumBackend) Chain() string {
+	return domain.ChainEthereum
+}
+
+func (b *ethereumBackend) Head(ctx context.Context) (uint64, error) {
+	header, err := b.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+func (b *ethereumBackend) Block(ctx context.Context, number uint64) (*domain.ChainBlock, error) {
+	header, err := b.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ChainBlock{
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash().Hex(),
+		ParentHash: header.ParentHash.Hex(),
+	}, nil
+}
+
+// Transfers fetches the block and its logs by hash, so they belong to the given block even if the
+// chain reorganises meanwhile.
+func (b *ethereumBackend) Transfers(ctx context.Context, block *domain.ChainBlock, addresses map[string]bool) ([]domain.Payment, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	if b.signer == nil {
+		chainID, err := b.client.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b.signer = types.LatestSignerForChainID(chainID)
+	}
+
+	blockHash := common.HexToHash(block.Hash)
+	fullBlock, err := b.client.BlockByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []domain.Payment
+	for _, tx := range fullBlock.Transactions() {
+		if tx.To() == nil || tx.Value().Sign() == 0 || !addresses[tx.To().Hex()] {
+			continue
+		}
+
+		from, err := types.Sender(b.signer, tx)
+		if err != nil {
+			b.logger.Warn("failed to recover transaction sender", zap.String("tx_hash", tx.Hash().Hex()), zap.Error(err))
+			continue
+		}
+
+		payments = append(payments, domain.Payment{
+			Chain:       domain.ChainEthereum,
+			Asset:       domain.AssetETH,
+			TxHash:      tx.Hash().Hex(),
+			BlockNumber: block.Number,
+			BlockHash:   block.Hash,
+			LogIndex:    -1,
+			FromAddress: from.Hex(),
+			ToAddress:   tx.To().Hex(),
+			Value:       tx.Value(),
+		})
+	}
+
+	if len(b.contracts) == 0 {
+		return payments, nil
+	}
+
+	recipients := make([]common.Hash, 0, len(addresses))
+	for address := range addresses {
+		recipients = append(recipients, common.BytesToHash(common.HexToAddress(address).Bytes()))
+	}
+	logs, err := b.client.FilterLogs(ctx, ethereum.FilterQuery{
+		BlockHash: &blockHash,
+		Addresses: b.contracts,
+		Topics:    [][]common.Hash{{transferTopic}, nil, recipients},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transfer := range logs {
+		// ERC-721 shares the event signature but indexes the token id, so it has a fourth topic and no data.
+		if transfer.Removed || len(transfer.Topics) != 3 || len(transfer.Data) != 32 {
+			continue
+		}
+		token, ok := b.tokens.ByContract(transfer.Address.Hex())
+		if !ok {
+			continue
+		}
+		to := common.BytesToAddress(transfer.Topics[2].Bytes()).Hex()
+		value := new(big.Int).SetBytes(transfer.Data)
+		if !addresses[to] || value.Sign() == 0 {
+			continue
+		}
+
+		payments = append(payments, domain.Payment{
+			Chain:       domain.ChainEthereum,
+			Asset:       token.Symbol,
+			TxHash:      transfer.TxHash.Hex(),
+			BlockNumber: block.Number,
+			BlockHash:   block.Hash,
+			LogIndex:    int(transfer.Index),
+			FromAddress: common.BytesToAddress(transfer.Topics[1].Bytes()).Hex(),
+			ToAddress:   to,
+			Value:       value,
+		})
+	}
+
+	return payments, nil
+}
+
+// NormalizeAddress returns the EIP-55 checksummed form of address.
+func (b *ethereumBackend) NormalizeAddress(address string) (string, bool) {
+	if !common.IsHexAddress(address) {
+		return "", false
+	}
+	return common.HexToAddress(address).Hex(), true
+}
+
+// WatchHeads subscribes to new heads, so the node must be reached over a websocket or IPC.
+func (b *ethereumBackend) WatchHeads(ctx context.Context, heads chan<- struct{}) error {
+	headers := make(chan *types.Header, 16)
+	sub, err := b.client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case header := <-headers:
+			b.logger.Debug("new head", zap.Uint64("block", header.Number.Uint64()))
+			select {
+			case heads <- struct{}{}:
+			default: // A sync is pending already
+			}
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
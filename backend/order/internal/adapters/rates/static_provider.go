@@ -0,0 +1,60 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"order/internal/domain"
+	"os"
+	"pkg/money"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const staticSource = "static"
+
+// staticProvider reads rates from a JSON file mapping an asset to its price per currency,
+// e.g. {"ETH": {"USD": "3000.00"}}. It stands in for a market data provider; the file is read
+// on every call so the rates can be changed without a restart.
+type staticProvider struct {
+	path   string
+	logger *zap.Logger
+}
+
+func NewStaticProvider(path string, logger *zap.Logger) domain.RateProvider {
+	return &staticProvider{
+		path:   path,
+		logger: logger.Named("static_rate_provider"),
+	}
+}
+
+func (p *staticProvider) Rate(ctx context.Context, asset, currency string) (*domain.ExchangeRate, error) {
+	p.logger.Info("reading exchange rate", zap.String("asset", asset), zap.String("currency", currency))
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	var rates map[string]map[string]string
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("failed to parse rates file: %w", err)
+	}
+
+	quoted, ok := rates[asset][currency]
+	if !ok {
+		return nil, domain.ErrRateUnavailable
+	}
+	price, err := money.Parse(quoted, currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s rate in %s: %w", asset, currency, err)
+	}
+
+	return &domain.ExchangeRate{
+		Asset:     asset,
+		Price:     price,
+		Source:    staticSource,
+		FetchedAt: time.Now(),
+	}, nil
+}
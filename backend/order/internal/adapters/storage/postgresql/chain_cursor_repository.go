@@ -0,0 +1,98 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"order/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// processedBlockRetention is how many processed blocks are kept per chain to detect reorgs.
+const processedBlockRetention = 256
+
+type chainCursorRepo struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewChainCursorRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.ChainCursorRepository, error) {
+	_, err := pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS processed_blocks (
+			chain TEXT NOT NULL,
+			number BIGINT NOT NULL,
+			hash TEXT NOT NULL,
+			processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (chain, number)
+		);
+	`)
+	if err != nil {
+		logger.Fatal("failed to create processed blocks table", zap.Error(err))
+		return nil, err
+	}
+
+	return &chainCursorRepo{
+		pool:   pool,
+		logger: logger.Named("postgres_chain_cursor_repo"),
+	}, nil
+}
+
+func (r *chainCursorRepo) Last(ctx context.Context, chain string) (*domain.BlockRef, error) {
+	return r.find(ctx, `SELECT number, hash FROM processed_blocks WHERE chain = $1 ORDER BY number DESC LIMIT 1`, chain)
+}
+
+func (r *chainCursorRepo) Find(ctx context.Context, chain string, number uint64) (*domain.BlockRef, error) {
+	return r.find(ctx, `SELECT number, hash FROM processed_blocks WHERE chain = $1 AND number = $2`, chain, int64(number))
+}
+
+func (r *chainCursorRepo) find(ctx context.Context, query string, args ...any) (*domain.BlockRef, error) {
+	var number int64
+	var block domain.BlockRef
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&number, &block.Hash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Error("failed to find processed block", zap.Error(err))
+		return nil, err
+	}
+	block.Number = uint64(number)
+	return &block, nil
+}
+
+func (r *chainCursorRepo) Save(ctx context.Context, chain string, block domain.BlockRef) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	query := `
+			INSERT INTO processed_blocks (chain, number, hash) VALUES ($1, $2, $3)
+			ON CONFLICT (chain, number) DO UPDATE SET hash = EXCLUDED.hash, processed_at = NOW()
+			`
+	if _, err := tx.Exec(ctx, query, chain, int64(block.Number), block.Hash); err != nil {
+		r.logger.Error("failed to save processed block", zap.String("chain", chain), zap.Uint64("number", block.Number), zap.Error(err))
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM processed_blocks WHERE chain = $1 AND number < $2`, chain, int64(block.Number)-processedBlockRetention); err != nil {
+		r.logger.Error("failed to prune processed blocks", zap.String("chain", chain), zap.Error(err))
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *chainCursorRepo) Rewind(ctx context.Context, chain string, number uint64) error {
+	r.logger.Warn("rewinding processed blocks", zap.String("chain", chain), zap.Uint64("from_block", number))
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM processed_blocks WHERE chain = $1 AND number >= $2`, chain, int64(number)); err != nil {
+		r.logger.Error("failed to rewind processed blocks", zap.String("chain", chain), zap.Error(err))
+		return err
+	}
+	return nil
+}
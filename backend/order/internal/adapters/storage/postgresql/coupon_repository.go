@@ -0,0 +1,258 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/internal/domain"
+	"pkg/money"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// uniqueViolation is the Postgres error code returned when a unique constraint is violated.
+const uniqueViolation = "23505"
+
+type couponRepo struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewCouponRepository must be created after the order repository, since redemptions reference orders.
+func NewCouponRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.CouponRepository, error) {
+	_, err := pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS coupons (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			code TEXT NOT NULL UNIQUE,
+			type TEXT NOT NULL,
+			value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+			amount BIGINT NOT NULL DEFAULT 0,
+			min_basket BIGINT NOT NULL DEFAULT 0,
+			currency TEXT NOT NULL DEFAULT 'USD',
+			max_uses INTEGER,
+			max_uses_per_user INTEGER,
+			used_count INTEGER NOT NULL DEFAULT 0,
+			valid_from TIMESTAMPTZ,
+			valid_until TIMESTAMPTZ,
+			product_ids TEXT[] NOT NULL DEFAULT '{}',
+			category_ids TEXT[] NOT NULL DEFAULT '{}',
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS coupon_redemptions (
+			id BIGSERIAL PRIMARY KEY,
+			coupon_id UUID NOT NULL REFERENCES coupons(id),
+			user_id TEXT NOT NULL,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			created_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (coupon_id, order_id)
+		);
+	`)
+	if err != nil {
+		logger.Fatal("failed to create coupon tables", zap.Error(err))
+		return nil, err
+	}
+
+	// value used to hold the discount of fixed amount coupons as well; it is now only the percentage.
+	_, err = pool.Exec(context.Background(), fmt.Sprintf(`
+		ALTER TABLE coupons ADD COLUMN IF NOT EXISTS amount BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE coupons ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'USD';
+		UPDATE coupons t SET amount = ROUND(value * 10 ^ (%s)), value = 0 WHERE type = 'FIXED_AMOUNT' AND value > 0;
+	`, currencyExponentSQL("t.currency")))
+	if err != nil {
+		logger.Fatal("failed to migrate coupon amounts", zap.Error(err))
+		return nil, err
+	}
+	if err := migrateToMinorUnits(context.Background(), pool, "coupons", "min_basket", "t.currency"); err != nil {
+		logger.Fatal("failed to migrate coupon minimum basket", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_coupon_user ON coupon_redemptions(coupon_id, user_id);`)
+	if err != nil {
+		logger.Fatal("failed to create index on coupon redemptions", zap.Error(err))
+		return nil, err
+	}
+
+	return &couponRepo{
+		pool:   pool,
+		logger: logger.Named("postgres_coupon_repo"),
+	}, nil
+}
+
+func (r *couponRepo) Create(ctx context.Context, c *domain.Coupon) error {
+	r.logger.Info("creating a new coupon", zap.String("code", c.Code))
+
+	query := `
+			INSERT INTO coupons (code, type, value, amount, min_basket, currency, max_uses, max_uses_per_user, valid_from, valid_until, product_ids, category_ids, active, created_at) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id
+			`
+
+	err := r.pool.QueryRow(ctx, query, c.Code, c.Type, c.Value, c.Amount.Amount, c.MinBasket.Amount, couponCurrency(c), c.MaxUses, c.MaxUsesPerUser, c.ValidFrom, c.ValidUntil, nonNil(c.ProductIDs), nonNil(c.CategoryIDs), c.Active, c.CreatedAt).Scan(&c.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			r.logger.Warn("coupon code already exists", zap.String("code", c.Code))
+			return domain.ErrCouponAlreadyExists
+		}
+		r.logger.Error("failed to insert coupon", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *couponRepo) FindByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	r.logger.Info("finding coupon by code", zap.String("code", code))
+
+	row := r.pool.QueryRow(ctx, couponSelect+` WHERE code = $1`, code)
+	c, err := scanCoupon(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCouponNotFound
+		}
+		r.logger.Error("failed to find coupon by code", zap.String("code", code), zap.Error(err))
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *couponRepo) List(ctx context.Context) ([]*domain.Coupon, error) {
+	r.logger.Info("listing coupons")
+
+	rows, err := r.pool.Query(ctx, couponSelect+` ORDER BY created_at DESC`)
+	if err != nil {
+		r.logger.Error("failed to query coupons", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	coupons := make([]*domain.Coupon, 0)
+	for rows.Next() {
+		c, err := scanCoupon(rows)
+		if err != nil {
+			r.logger.Error("failed to scan coupon row", zap.Error(err))
+			return nil, err
+		}
+		coupons = append(coupons, c)
+	}
+
+	return coupons, rows.Err()
+}
+
+func (r *couponRepo) CountRedemptions(ctx context.Context, couponID, userID string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM coupon_redemptions WHERE coupon_id = $1 AND user_id = $2`, couponID, userID).Scan(&count)
+	if err != nil {
+		r.logger.Error("failed to count coupon redemptions", zap.String("coupon_id", couponID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+const couponSelect = `
+			SELECT id, code, type, value, amount, min_basket, currency, max_uses, max_uses_per_user, used_count, valid_from, valid_until, product_ids, category_ids, active, created_at 
+			FROM coupons`
+
+func scanCoupon(row pgx.Row) (*domain.Coupon, error) {
+	var c domain.Coupon
+	var currency string
+	err := row.Scan(&c.ID, &c.Code, &c.Type, &c.Value, &c.Amount.Amount, &c.MinBasket.Amount, &currency, &c.MaxUses, &c.MaxUsesPerUser, &c.UsedCount, &c.ValidFrom, &c.ValidUntil, &c.ProductIDs, &c.CategoryIDs, &c.Active, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.Amount.Currency = currency
+	c.MinBasket.Currency = currency
+	return &c, nil
+}
+
+// couponCurrency is the one currency of the coupon's amounts; NewCoupon rejects coupons mixing two.
+func couponCurrency(c *domain.Coupon) string {
+	if c.Amount.Currency != "" {
+		return c.Amount.Currency
+	}
+	if c.MinBasket.Currency != "" {
+		return c.MinBasket.Currency
+	}
+	return money.DefaultCurrency
+}
+
+// redeemCoupons records the coupons used by an order within tx. The coupon rows are locked so
+// concurrent checkouts cannot exceed the global or per-user limits.
+func redeemCoupons(ctx context.Context, tx pgx.Tx, o *domain.Order) error {
+	seen := make(map[string]bool)
+	for _, d := range o.Discounts {
+		if d.CouponID == "" || seen[d.CouponID] {
+			continue
+		}
+		seen[d.CouponID] = true
+
+		var maxUses, maxUsesPerUser *int
+		var usedCount int
+		err := tx.QueryRow(ctx, `SELECT max_uses, max_uses_per_user, used_count FROM coupons WHERE id = $1 FOR UPDATE`, d.CouponID).Scan(&maxUses, &maxUsesPerUser, &usedCount)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCouponNotFound
+			}
+			return fmt.Errorf("failed to lock coupon: %w", err)
+		}
+		if maxUses != nil && usedCount >= *maxUses {
+			return domain.ErrCouponUsageLimitReached
+		}
+
+		if maxUsesPerUser != nil {
+			var userCount int
+			err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM coupon_redemptions WHERE coupon_id = $1 AND user_id = $2`, d.CouponID, o.UserID).Scan(&userCount)
+			if err != nil {
+				return fmt.Errorf("failed to count coupon redemptions: %w", err)
+			}
+			if userCount >= *maxUsesPerUser {
+				return domain.ErrCouponUsageLimitReached
+			}
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO coupon_redemptions (coupon_id, user_id, order_id, created_at) VALUES ($1, $2, $3, $4)`, d.CouponID, o.UserID, o.ID, o.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert coupon redemption: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE coupons SET used_count = used_count + 1 WHERE id = $1`, d.CouponID); err != nil {
+			return fmt.Errorf("failed to increment coupon usage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseCoupons gives the coupons used by a cancelled order back within tx.
+func releaseCoupons(ctx context.Context, tx pgx.Tx, orderID domain.OrderID) error {
+	rows, err := tx.Query(ctx, `DELETE FROM coupon_redemptions WHERE order_id = $1 RETURNING coupon_id`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to delete coupon redemptions: %w", err)
+	}
+	couponIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to delete coupon redemptions: %w", err)
+	}
+
+	for _, id := range couponIDs {
+		if _, err := tx.Exec(ctx, `UPDATE coupons SET used_count = GREATEST(used_count - 1, 0) WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to decrement coupon usage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
@@ -0,0 +1,83 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type exchangeRateRepo struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+func NewExchangeRateRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.ExchangeRateRepository, error) {
+	_, err := pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS exchange_rates (
+			id BIGSERIAL PRIMARY KEY,
+			asset TEXT NOT NULL,
+			currency TEXT NOT NULL,
+			price BIGINT NOT NULL,
+			source TEXT NOT NULL,
+			fetched_at TIMESTAMPTZ NOT NULL
+		);
+	`)
+	if err != nil {
+		logger.Fatal("failed to create exchange rates table", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_exchange_rates_asset_currency ON exchange_rates(asset, currency, fetched_at DESC);`)
+	if err != nil {
+		logger.Fatal("failed to create index on exchange rates", zap.Error(err))
+		return nil, err
+	}
+
+	return &exchangeRateRepo{
+		pool:   pool,
+		logger: logger.Named("postgres_exchange_rate_repo"),
+	}, nil
+}
+
+func (r *exchangeRateRepo) Save(ctx context.Context, rate *domain.ExchangeRate) error {
+	r.logger.Info("saving exchange rate", zap.String("asset", rate.Asset), zap.String("price", rate.Price.String()))
+
+	query := `
+			INSERT INTO exchange_rates (asset, currency, price, source, fetched_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+			`
+
+	if err := r.pool.QueryRow(ctx, query, rate.Asset, rate.Price.Currency, rate.Price.Amount, rate.Source, rate.FetchedAt).Scan(&rate.ID); err != nil {
+		r.logger.Error("failed to insert exchange rate", zap.Error(err))
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+func (r *exchangeRateRepo) Latest(ctx context.Context, asset, currency string) (*domain.ExchangeRate, error) {
+	query := `
+			SELECT id, asset, price, currency, source, fetched_at
+			FROM exchange_rates WHERE asset = $1 AND currency = $2
+			ORDER BY fetched_at DESC
+			LIMIT 1
+			`
+
+	var rate domain.ExchangeRate
+	err := r.pool.QueryRow(ctx, query, asset, currency).Scan(&rate.ID, &rate.Asset, &rate.Price.Amount, &rate.Price.Currency, &rate.Source, &rate.FetchedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRateUnavailable
+		}
+		r.logger.Error("failed to find latest exchange rate", zap.String("asset", asset), zap.Error(err))
+		return nil, err
+	}
+
+	return &rate, nil
+}
@@ -2,30 +2,42 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"order/internal/domain"
+	"pkg/money"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+// invalidTextRepresentation is the Postgres error code returned when an id is not a valid UUID.
+const invalidTextRepresentation = "22P02"
+
 type orderRepo struct {
-	conn   *pgx.Conn
+	pool   *pgxpool.Pool
 	logger *zap.Logger
 }
 
-func NewOrderRepository(conn *pgx.Conn, logger *zap.Logger) (domain.OrderRepository, error) {
+func NewOrderRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.OrderRepository, error) {
 
-	_, err := conn.Exec(context.Background(), `CREATE EXTENSION IF NOT EXISTS "pgcrypto";`)
+	_, err := pool.Exec(context.Background(), `CREATE EXTENSION IF NOT EXISTS "pgcrypto";`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to enable pgcrypto extension: %w", err)
 	}
 
-	_, err = conn.Exec(context.Background(), `
+	_, err = pool.Exec(context.Background(), `
 		CREATE TABLE IF NOT EXISTS orders (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			user_id TEXT NOT NULL,
-			total_price NUMERIC(10, 2) NOT NULL,
+			total_price BIGINT NOT NULL,
+			currency TEXT NOT NULL DEFAULT 'USD',
 			status TEXT NOT NULL,
 			payment_method TEXT NOT NULL,
 			payment_address TEXT,
@@ -38,8 +50,48 @@ func NewOrderRepository(conn *pgx.Conn, logger *zap.Logger) (domain.OrderReposit
 			id SERIAL PRIMARY KEY,
 			order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
 			product_id TEXT NOT NULL,
+			variant_id TEXT NOT NULL DEFAULT '',
+			sku TEXT NOT NULL DEFAULT '',
+			product_name TEXT NOT NULL DEFAULT '',
 			quantity INTEGER NOT NULL,
-			price NUMERIC(10, 2) NOT NULL
+			price BIGINT NOT NULL
+		);
+
+		ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE order_items ADD COLUMN IF NOT EXISTS sku TEXT NOT NULL DEFAULT '';
+		ALTER TABLE order_items ADD COLUMN IF NOT EXISTS product_name TEXT NOT NULL DEFAULT '';
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_fee BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'USD';
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_asset TEXT;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS quoted_rate BIGINT;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS quoted_rate_id BIGINT;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS expected_amount NUMERIC(78, 0);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS quoted_at TIMESTAMPTZ;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS amount_received NUMERIC(78, 0);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS amount_overpaid NUMERIC(78, 0);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_token_contract TEXT;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_token_decimals INTEGER;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS stock_commit_pending BOOLEAN NOT NULL DEFAULT FALSE;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS stock_release_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+		CREATE TABLE IF NOT EXISTS order_discounts (
+			id BIGSERIAL PRIMARY KEY,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			coupon_id TEXT NOT NULL DEFAULT '',
+			coupon_code TEXT NOT NULL DEFAULT '',
+			type TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			amount BIGINT NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS order_status_history (
+			id BIGSERIAL PRIMARY KEY,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			from_status TEXT,
+			to_status TEXT NOT NULL,
+			changed_by TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			changed_at TIMESTAMPTZ NOT NULL
 		);
 	`)
 
@@ -48,22 +100,61 @@ func NewOrderRepository(conn *pgx.Conn, logger *zap.Logger) (domain.OrderReposit
 		return nil, err
 	}
 
-	_, err = conn.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status);`)
+	// Amounts are stored in minor units of the order currency; older tables used NUMERIC(10, 2).
+	// The last entry is the currency of a row t of the table.
+	for _, column := range [][3]string{{"orders", "total_price", "t.currency"}, {"orders", "shipping_fee", "t.currency"}, {"order_items", "price", orderCurrency}, {"order_discounts", "amount", orderCurrency}} {
+		if err := migrateToMinorUnits(context.Background(), pool, column[0], column[1], column[2]); err != nil {
+			logger.Fatal("failed to migrate money column", zap.String("table", column[0]), zap.String("column", column[1]), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_payment_address ON orders(payment_address) WHERE payment_address IS NOT NULL;`)
+	if err != nil {
+		logger.Fatal("failed to create unique index on orders payment_address", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status);`)
 	if err != nil {
 		logger.Fatal("failed to create index on orders status", zap.Error(err))
 		return nil, err
 	}
 
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders(user_id, created_at DESC, id DESC);`)
+	if err != nil {
+		logger.Fatal("failed to create index on orders user_id", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);`)
+	if err != nil {
+		logger.Fatal("failed to create index on order items order_id", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_order_discounts_order_id ON order_discounts(order_id);`)
+	if err != nil {
+		logger.Fatal("failed to create index on order discounts order_id", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id ON order_status_history(order_id, changed_at);`)
+	if err != nil {
+		logger.Fatal("failed to create index on order status history", zap.Error(err))
+		return nil, err
+	}
+
 	return &orderRepo{
-		conn:   conn,
+		pool:   pool,
 		logger: logger.Named("postgres_order_repo"),
 	}, nil
 }
 
-func (r *orderRepo) Save(ctx context.Context, o *domain.Order) error {
+func (r *orderRepo) Save(ctx context.Context, o *domain.Order, deriveAddress domain.AddressDeriver) error {
 	r.logger.Info("saving a new order", zap.String("user_id", o.UserID))
 
-	tx, err := r.conn.Begin(ctx)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -71,15 +162,46 @@ func (r *orderRepo) Save(ctx context.Context, o *domain.Order) error {
 		_ = tx.Rollback(ctx)
 	}(tx, ctx)
 
+	// Indexes taken from the sequence are never handed out again, even if this transaction rolls back,
+	// so no two orders can ever share an address.
+	var derivationIndex int64
+	err = tx.QueryRow(ctx, `SELECT nextval(pg_get_serial_sequence('orders', 'derivation_index'))`).Scan(&derivationIndex)
+	if err != nil {
+		r.logger.Error("failed to reserve derivation index", zap.Error(err))
+		return err
+	}
+	if deriveAddress != nil {
+		address, err := deriveAddress(derivationIndex)
+		if err != nil {
+			r.logger.Error("failed to derive payment address", zap.Int64("derivation_index", derivationIndex), zap.Error(err))
+			return fmt.Errorf("failed to derive payment address: %w", err)
+		}
+		o.PaymentAddress = &address
+	}
+
 	orderQuery := `
-					INSERT INTO orders (user_id, total_price, status, payment_method, payment_address, created_at) 
-					VALUES  ($1, $2, $3, $4, $5, $6) 
-					returning id, derivation_index
+					INSERT INTO orders (user_id, total_price, shipping_fee, currency, status, payment_method, payment_address, created_at, 
+					                    payment_asset, quoted_rate, quoted_rate_id, expected_amount, quoted_at, derivation_index, 
+					                    payment_token_contract, payment_token_decimals) 
+					VALUES  ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12::NUMERIC, $13, $14, $15, $16) 
+					returning id
 					`
 
+	var asset, expectedAmount *string
+	var quotedRate, quotedRateID *int64
+	var quotedAt *time.Time
+	if q := o.PaymentQuote; q != nil {
+		asset, quotedRate, quotedRateID, expectedAmount, quotedAt = &q.Asset, &q.Rate.Amount, &q.RateID, bigIntText(q.ExpectedAmount), &q.QuotedAt
+	}
+	var tokenContract *string
+	var tokenDecimals *int
+	if t := o.PaymentToken; t != nil {
+		tokenContract, tokenDecimals = &t.Contract, &t.Decimals
+	}
+
 	var orderID domain.OrderID
-	var derivationIndex int64
-	err = tx.QueryRow(ctx, orderQuery, o.UserID, o.TotalPrice, o.Status, o.PaymentMethod, o.PaymentAddress, o.CreatedAt).Scan(&orderID)
+	err = tx.QueryRow(ctx, orderQuery, o.UserID, o.TotalPrice.Amount, o.ShippingFee.Amount, o.TotalPrice.Currency, o.Status, o.PaymentMethod, o.PaymentAddress, o.CreatedAt,
+		asset, quotedRate, quotedRateID, expectedAmount, quotedAt, derivationIndex, tokenContract, tokenDecimals).Scan(&orderID)
 	if err != nil {
 		r.logger.Error("failed to insert order", zap.Error(err))
 		return err
@@ -88,52 +210,244 @@ func (r *orderRepo) Save(ctx context.Context, o *domain.Order) error {
 	o.DerivationIndex = &derivationIndex
 
 	for _, item := range o.Items {
-		itemQuery := `INSERT INTO order_items (order_id, product_id, quantity, price) VALUES ($1, $2, $3, $4) returning id`
-		_, err := tx.Exec(ctx, itemQuery, orderID, item.ProductID, item.Quantity, item.Price)
+		itemQuery := `
+					INSERT INTO order_items (order_id, product_id, variant_id, sku, product_name, quantity, price) 
+					VALUES ($1, $2, $3, $4, $5, $6, $7)
+					`
+		_, err := tx.Exec(ctx, itemQuery, orderID, item.ProductID, item.VariantID, item.SKU, item.Name, item.Quantity, item.Price.Amount)
 		if err != nil {
 			r.logger.Error("failed to insert order item", zap.Error(err))
 			return err
 		}
 	}
+
+	for i := range o.Discounts {
+		d := &o.Discounts[i]
+		discountQuery := `
+					INSERT INTO order_discounts (order_id, coupon_id, coupon_code, type, description, amount) 
+					VALUES ($1, $2, $3, $4, $5, $6)
+					RETURNING id
+					`
+		if err := tx.QueryRow(ctx, discountQuery, orderID, d.CouponID, d.CouponCode, d.Type, d.Description, d.Amount.Amount).Scan(&d.ID); err != nil {
+			r.logger.Error("failed to insert order discount", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := redeemCoupons(ctx, tx, o); err != nil {
+		r.logger.Warn("failed to redeem coupon", zap.String("order_id", string(orderID)), zap.Error(err))
+		return err
+	}
+
+	if err := r.insertStatusChanges(ctx, tx, orderID, o.PendingStatusChanges()); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
 func (r *orderRepo) Update(ctx context.Context, order *domain.Order) error {
 	r.logger.Info("updating order", zap.String("order_id", string(order.ID)))
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	changes := order.PendingStatusChanges()
+
+	// Only write if nobody moved the order away from the status it was loaded with, whether or not this
+	// update changes the status itself.
+	loaded := order.Status
+	if len(changes) > 0 {
+		loaded = *changes[0].FromStatus
+	}
+
 	query := `
-        UPDATE orders 
-        SET status = $2, transaction_id = $3 
-        WHERE id = $1
-    `
-	_, err := r.conn.Exec(ctx, query, order.ID, order.Status, order.TransactionID)
+		UPDATE orders 
+		SET status = $2, transaction_id = $3, amount_received = $4::NUMERIC, amount_overpaid = $5::NUMERIC, stock_commit_pending = $7, 
+		    stock_release_pending = $8 
+		WHERE id = $1 AND status = $6
+	`
+	tag, err := tx.Exec(ctx, query, order.ID, order.Status, order.TransactionID, bigIntText(order.AmountReceived), bigIntText(order.AmountOverpaid), loaded, order.StockCommitPending, order.StockReleasePending)
 	if err != nil {
 		r.logger.Error("failed to update order", zap.String("order_id", string(order.ID)), zap.Error(err))
 		return err
 	}
 
-	return nil
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)`, order.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrOrderNotFound
+		}
+		r.logger.Warn("order status changed concurrently", zap.String("order_id", string(order.ID)))
+		return domain.ErrInvalidStatusTransition
+	}
+
+	if err := r.insertStatusChanges(ctx, tx, order.ID, changes); err != nil {
+		return err
+	}
+
+	// A cancelled order no longer counts towards coupon usage limits.
+	if len(changes) > 0 && order.Status == domain.StatusCancelled {
+		if err := releaseCoupons(ctx, tx, order.ID); err != nil {
+			r.logger.Error("failed to release coupons", zap.String("order_id", string(order.ID)), zap.Error(err))
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *orderRepo) FindByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
-	return nil, nil
+	r.logger.Info("finding order by id", zap.String("order_id", string(id)))
+	o, err := scanOrder(r.pool.QueryRow(ctx, orderSelect+` WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrderNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == invalidTextRepresentation {
+			return nil, domain.ErrOrderNotFound
+		}
+		r.logger.Error("failed to find order by id", zap.String("order_id", string(id)), zap.Error(err))
+		return nil, err
+	}
+
+	items, err := r.findItems(ctx, []domain.OrderID{o.ID})
+	if err != nil {
+		return nil, err
+	}
+	o.Items = items[o.ID]
+
+	discounts, err := r.findDiscounts(ctx, []domain.OrderID{o.ID})
+	if err != nil {
+		return nil, err
+	}
+	o.Discounts = discounts[o.ID]
+
+	history, err := r.FindStatusHistory(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+	o.StatusHistory = history
+
+	payments, err := findPayments(ctx, r.pool, []domain.OrderID{o.ID})
+	if err != nil {
+		r.logger.Error("failed to find order payments", zap.String("order_id", string(o.ID)), zap.Error(err))
+		return nil, err
+	}
+	o.Payments = payments[o.ID]
+
+	return o, nil
+}
+
+func (r *orderRepo) FindByUserID(ctx context.Context, userID string, filter domain.OrderFilter) ([]*domain.Order, error) {
+	r.logger.Info("finding orders by user id", zap.String("user_id", userID))
+
+	filter.UserID = userID
+	return r.Search(ctx, filter)
 }
 
-func (r *orderRepo) FindByUserID(ctx context.Context, userID string) ([]*domain.Order, error) {
-	return nil, nil
+func (r *orderRepo) Search(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+	r.logger.Info("searching orders", zap.String("user_id", filter.UserID), zap.Int("limit", filter.Limit))
+
+	var conditions []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+arg(filter.UserID))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, st := range filter.Statuses {
+			statuses[i] = string(st)
+		}
+		conditions = append(conditions, "status = ANY("+arg(statuses)+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < (%s, %s)", arg(filter.After.CreatedAt), arg(filter.After.ID)))
+	}
+	if filter.StockReleasePending {
+		conditions = append(conditions, "stock_release_pending")
+	}
+
+	query := orderSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT " + arg(filter.Limit)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("failed to search orders", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]*domain.Order, 0)
+	ids := make([]domain.OrderID, 0)
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			r.logger.Error("failed to scan order row", zap.Error(err))
+			return nil, err
+		}
+		orders = append(orders, o)
+		ids = append(ids, o.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	items, err := r.findItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := r.findDiscounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range orders {
+		o.Items = items[o.ID]
+		o.Discounts = discounts[o.ID]
+	}
+
+	return orders, nil
 }
 
-func (r *orderRepo) FindAwaitingPayment(ctx context.Context, limit, offset int) ([]*domain.Order, error) {
+func (r *orderRepo) FindAwaitingPayment(ctx context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
 	r.logger.Info("finding orders awaiting payment")
-	query := `
-			SELECT id, user_id, total_price, status, payment_method, payment_address, derivation_index, created_at 
-			FROM orders WHERE status = $1
-			ORDER BY created_at ASC 
-			LIMIT $2
-			OFFSET $3
+	query := orderSelect + `
+			WHERE status = ANY($1)
+			  AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) > ($2, $3::UUID))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $4
 			`
 
-	rows, err := r.conn.Query(ctx, query, domain.StatusAwaitingPayment, limit, offset)
+	statuses := []string{string(domain.StatusAwaitingPayment), string(domain.StatusPartiallyPaid)}
+	afterCreatedAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, query, statuses, afterCreatedAt, afterID, limit)
 	if err != nil {
 		r.logger.Error("failed to query orders awaiting payment", zap.Error(err))
 		return nil, err
@@ -142,15 +456,342 @@ func (r *orderRepo) FindAwaitingPayment(ctx context.Context, limit, offset int)
 
 	var orders []*domain.Order
 	for rows.Next() {
-		var o domain.Order
-		err := rows.Scan(&o.ID, &o.UserID, &o.TotalPrice, &o.Status, &o.PaymentMethod, &o.PaymentAddress, &o.DerivationIndex, &o.CreatedAt)
+		o, err := scanOrder(rows)
+		if err != nil {
+			r.logger.Error("failed to scan order row", zap.Error(err))
+			continue
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, nil
+
+}
+
+func (r *orderRepo) FindStockCommitPending(ctx context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+	r.logger.Info("finding paid orders with a pending stock commit")
+	query := orderSelect + `
+			WHERE status = $1 AND stock_commit_pending
+			  AND ($2::TIMESTAMPTZ IS NULL OR (created_at, id) > ($2, $3::UUID))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $4
+			`
+
+	afterCreatedAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, query, string(domain.StatusPaid), afterCreatedAt, afterID, limit)
+	if err != nil {
+		r.logger.Error("failed to query orders with a pending stock commit", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			r.logger.Error("failed to scan order row", zap.Error(err))
+			continue
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, nil
+}
+
+func (r *orderRepo) FindCancelledSince(ctx context.Context, since time.Time, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+	r.logger.Info("finding recently cancelled orders", zap.Time("since", since))
+	query := orderSelect + `
+			WHERE status = $1 AND payment_address IS NOT NULL
+			  AND EXISTS (
+				SELECT 1 FROM order_status_history h
+				WHERE h.order_id = orders.id AND h.to_status = $1 AND h.changed_at >= $2
+			  )
+			  AND ($3::TIMESTAMPTZ IS NULL OR (created_at, id) > ($3, $4::UUID))
+			ORDER BY created_at ASC, id ASC
+			LIMIT $5
+			`
+
+	afterCreatedAt, afterID := cursorArgs(after)
+	rows, err := r.pool.Query(ctx, query, string(domain.StatusCancelled), since, afterCreatedAt, afterID, limit)
+	if err != nil {
+		r.logger.Error("failed to query recently cancelled orders", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
 		if err != nil {
 			r.logger.Error("failed to scan order row", zap.Error(err))
 			continue
 		}
-		orders = append(orders, &o)
+		orders = append(orders, o)
 	}
 
 	return orders, nil
+}
+
+// cursorArgs returns the query arguments of a keyset cursor, both nil when there is none.
+func cursorArgs(after *domain.OrderCursor) (*time.Time, *string) {
+	if after == nil {
+		return nil, nil
+	}
+	id := string(after.ID)
+	return &after.CreatedAt, &id
+}
+
+func (r *orderRepo) HasPaidOrderWithProduct(ctx context.Context, userID, productID string) (bool, error) {
+	r.logger.Info("checking paid orders for product", zap.String("user_id", userID), zap.String("product_id", productID))
+	query := `
+			SELECT EXISTS (
+				SELECT 1 FROM orders o
+				JOIN order_items oi ON oi.order_id = o.id
+				WHERE o.user_id = $1 AND oi.product_id = $2 AND o.status = ANY($3)
+			)
+			`
+
+	// Orders stay purchases once they move on from PAID to SHIPPED.
+	statuses := []string{string(domain.StatusPaid), string(domain.StatusShipped)}
+
+	var exists bool
+	err := r.pool.QueryRow(ctx, query, userID, productID, statuses).Scan(&exists)
+	if err != nil {
+		r.logger.Error("failed to query paid orders for product", zap.Error(err))
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *orderRepo) FindStatusHistory(ctx context.Context, id domain.OrderID) ([]domain.StatusChange, error) {
+	r.logger.Info("finding order status history", zap.String("order_id", string(id)))
+	query := `
+			SELECT id, from_status, to_status, changed_by, reason, changed_at 
+			FROM order_status_history WHERE order_id = $1
+			ORDER BY changed_at ASC, id ASC
+			`
+
+	rows, err := r.pool.Query(ctx, query, id)
+	if err != nil {
+		r.logger.Error("failed to query order status history", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]domain.StatusChange, 0)
+	for rows.Next() {
+		var c domain.StatusChange
+		if err := rows.Scan(&c.ID, &c.FromStatus, &c.ToStatus, &c.ChangedBy, &c.Reason, &c.ChangedAt); err != nil {
+			r.logger.Error("failed to scan order status history row", zap.Error(err))
+			return nil, err
+		}
+		history = append(history, c)
+	}
+
+	return history, rows.Err()
+}
+
+const orderSelect = `
+			SELECT id, user_id, total_price, shipping_fee, currency, status, payment_method, payment_address, transaction_id, derivation_index, created_at, 
+			       payment_asset, quoted_rate, quoted_rate_id, expected_amount::TEXT, quoted_at, amount_received::TEXT, amount_overpaid::TEXT, 
+			       payment_token_contract, payment_token_decimals, stock_commit_pending, stock_release_pending 
+			FROM orders`
+
+func scanOrder(row pgx.Row) (*domain.Order, error) {
+	var o domain.Order
+	var currency string
+	var asset, expectedAmount, amountReceived, amountOverpaid, tokenContract *string
+	var quotedRate, quotedRateID *int64
+	var tokenDecimals *int
+	var quotedAt *time.Time
+	err := row.Scan(&o.ID, &o.UserID, &o.TotalPrice.Amount, &o.ShippingFee.Amount, &currency, &o.Status, &o.PaymentMethod, &o.PaymentAddress, &o.TransactionID, &o.DerivationIndex, &o.CreatedAt,
+		&asset, &quotedRate, &quotedRateID, &expectedAmount, &quotedAt, &amountReceived, &amountOverpaid, &tokenContract, &tokenDecimals, &o.StockCommitPending, &o.StockReleasePending)
+	if err != nil {
+		return nil, err
+	}
+	o.TotalPrice.Currency = currency
+	o.ShippingFee.Currency = currency
 
+	if o.AmountReceived, err = parseBigInt(amountReceived); err != nil {
+		return nil, err
+	}
+	if o.AmountOverpaid, err = parseBigInt(amountOverpaid); err != nil {
+		return nil, err
+	}
+
+	if asset != nil && quotedRate != nil && expectedAmount != nil {
+		expected, err := parseBigInt(expectedAmount)
+		if err != nil {
+			return nil, err
+		}
+		o.PaymentQuote = &domain.PaymentQuote{
+			Asset:          *asset,
+			Rate:           money.New(*quotedRate, currency),
+			ExpectedAmount: expected,
+		}
+		if quotedRateID != nil {
+			o.PaymentQuote.RateID = *quotedRateID
+		}
+		if quotedAt != nil {
+			o.PaymentQuote.QuotedAt = *quotedAt
+		}
+		if tokenContract != nil && tokenDecimals != nil {
+			o.PaymentToken = &domain.Token{Symbol: *asset, Contract: *tokenContract, Decimals: *tokenDecimals}
+		}
+	}
+
+	return &o, nil
+}
+
+// bigIntText and parseBigInt map crypto amounts to NUMERIC(78, 0) columns through their text form,
+// keeping NULL for amounts that are not known.
+func bigIntText(v *big.Int) *string {
+	if v == nil {
+		return nil
+	}
+	s := v.String()
+	return &s
+}
+
+func parseBigInt(s *string) (*big.Int, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, ok := new(big.Int).SetString(*s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid numeric amount %q", *s)
+	}
+	return v, nil
+}
+
+func (r *orderRepo) findItems(ctx context.Context, ids []domain.OrderID) (map[domain.OrderID][]domain.OrderItem, error) {
+	query := `
+			SELECT oi.order_id, oi.product_id, oi.variant_id, oi.sku, oi.product_name, oi.quantity, oi.price, o.currency 
+			FROM order_items oi JOIN orders o ON o.id = oi.order_id
+			WHERE oi.order_id = ANY($1)
+			ORDER BY oi.id ASC
+			`
+
+	orderIDs := make([]string, len(ids))
+	for i, id := range ids {
+		orderIDs[i] = string(id)
+	}
+
+	rows, err := r.pool.Query(ctx, query, orderIDs)
+	if err != nil {
+		r.logger.Error("failed to query order items", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[domain.OrderID][]domain.OrderItem, len(ids))
+	for rows.Next() {
+		var orderID domain.OrderID
+		var item domain.OrderItem
+		if err := rows.Scan(&orderID, &item.ProductID, &item.VariantID, &item.SKU, &item.Name, &item.Quantity, &item.Price.Amount, &item.Price.Currency); err != nil {
+			r.logger.Error("failed to scan order item row", zap.Error(err))
+			return nil, err
+		}
+		items[orderID] = append(items[orderID], item)
+	}
+
+	return items, rows.Err()
+}
+
+func (r *orderRepo) findDiscounts(ctx context.Context, ids []domain.OrderID) (map[domain.OrderID][]domain.DiscountLine, error) {
+	query := `
+			SELECT od.id, od.order_id, od.coupon_id, od.coupon_code, od.type, od.description, od.amount, o.currency 
+			FROM order_discounts od JOIN orders o ON o.id = od.order_id
+			WHERE od.order_id = ANY($1)
+			ORDER BY od.id ASC
+			`
+
+	orderIDs := make([]string, len(ids))
+	for i, id := range ids {
+		orderIDs[i] = string(id)
+	}
+
+	rows, err := r.pool.Query(ctx, query, orderIDs)
+	if err != nil {
+		r.logger.Error("failed to query order discounts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	discounts := make(map[domain.OrderID][]domain.DiscountLine, len(ids))
+	for rows.Next() {
+		var orderID domain.OrderID
+		var d domain.DiscountLine
+		if err := rows.Scan(&d.ID, &orderID, &d.CouponID, &d.CouponCode, &d.Type, &d.Description, &d.Amount.Amount, &d.Amount.Currency); err != nil {
+			r.logger.Error("failed to scan order discount row", zap.Error(err))
+			return nil, err
+		}
+		discounts[orderID] = append(discounts[orderID], d)
+	}
+
+	return discounts, rows.Err()
+}
+
+// insertStatusChanges persists not-yet-saved history entries within tx and assigns their IDs.
+func (r *orderRepo) insertStatusChanges(ctx context.Context, tx pgx.Tx, orderID domain.OrderID, changes []domain.StatusChange) error {
+	query := `
+			INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, reason, changed_at) 
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+			`
+
+	for i := range changes {
+		c := &changes[i]
+		if err := tx.QueryRow(ctx, query, orderID, c.FromStatus, c.ToStatus, c.ChangedBy, c.Reason, c.ChangedAt).Scan(&c.ID); err != nil {
+			r.logger.Error("failed to insert order status history", zap.String("order_id", string(orderID)), zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// orderCurrency is the currency of the order a row t of an order child table belongs to.
+const orderCurrency = "(SELECT o.currency FROM orders o WHERE o.id = t.order_id)"
+
+// migrateToMinorUnits converts a NUMERIC(10, 2) money column to BIGINT minor units, scaling each amount by
+// the exponent of its currency, which currency gives for a row t of the table. It does nothing once the
+// column has been converted, so it is safe to run on every start.
+func migrateToMinorUnits(ctx context.Context, pool *pgxpool.Pool, table, column, currency string) error {
+	_, err := pool.Exec(ctx, fmt.Sprintf(`
+		DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_name = '%[1]s' AND column_name = '%[2]s' AND data_type = 'numeric'
+			) THEN
+				ALTER TABLE %[1]s ADD COLUMN %[2]s_minor BIGINT;
+				UPDATE %[1]s t SET %[2]s_minor = ROUND(t.%[2]s * 10 ^ (%[3]s));
+				ALTER TABLE %[1]s DROP COLUMN %[2]s;
+				ALTER TABLE %[1]s RENAME COLUMN %[2]s_minor TO %[2]s;
+				ALTER TABLE %[1]s ALTER COLUMN %[2]s SET NOT NULL;
+			END IF;
+		END $$;
+	`, table, column, currencyExponentSQL(currency)))
+	return err
+}
+
+// currencyExponentSQL returns an SQL expression giving the number of minor-unit digits of currency,
+// 2 for currencies money does not know, like money.Money.Exponent.
+func currencyExponentSQL(currency string) string {
+	exponents := money.Exponents()
+	currencies := make([]string, 0, len(exponents))
+	for code := range exponents {
+		currencies = append(currencies, code)
+	}
+	sort.Strings(currencies)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CASE %s", currency)
+	for _, code := range currencies {
+		fmt.Fprintf(&b, " WHEN '%s' THEN %d", code, exponents[code])
+	}
+	b.WriteString(" ELSE 2 END")
+	return b.String()
 }
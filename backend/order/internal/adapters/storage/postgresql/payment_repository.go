@@ -0,0 +1,158 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"order/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type paymentRepo struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewPaymentRepository must be created after the order repository, since payments reference orders.
+func NewPaymentRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.PaymentRepository, error) {
+	_, err := pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS payments (
+			id BIGSERIAL PRIMARY KEY,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			chain TEXT NOT NULL DEFAULT 'ethereum',
+			asset TEXT NOT NULL,
+			tx_hash TEXT NOT NULL,
+			block_number BIGINT NOT NULL,
+			block_hash TEXT NOT NULL DEFAULT '',
+			log_index INTEGER NOT NULL DEFAULT -1,
+			from_address TEXT NOT NULL,
+			to_address TEXT NOT NULL,
+			value NUMERIC(78, 0) NOT NULL,
+			confirmations BIGINT NOT NULL DEFAULT 0,
+			detected_at TIMESTAMPTZ NOT NULL
+		);
+
+		ALTER TABLE payments ADD COLUMN IF NOT EXISTS chain TEXT NOT NULL DEFAULT 'ethereum';
+		ALTER TABLE payments ADD COLUMN IF NOT EXISTS block_hash TEXT NOT NULL DEFAULT '';
+		ALTER TABLE payments ADD COLUMN IF NOT EXISTS log_index INTEGER NOT NULL DEFAULT -1;
+		-- A transaction can carry several token transfers to the same address, told apart by their log index.
+		ALTER TABLE payments DROP CONSTRAINT IF EXISTS payments_tx_hash_to_address_key;
+	`)
+	if err != nil {
+		logger.Fatal("failed to create payments table", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_transfer ON payments(tx_hash, to_address, log_index);`)
+	if err != nil {
+		logger.Fatal("failed to create unique index on payments transfer", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id, block_number);`)
+	if err != nil {
+		logger.Fatal("failed to create index on payments order_id", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_payments_chain_block ON payments(chain, block_number);`)
+	if err != nil {
+		logger.Fatal("failed to create index on payments block_number", zap.Error(err))
+		return nil, err
+	}
+
+	return &paymentRepo{
+		pool:   pool,
+		logger: logger.Named("postgres_payment_repo"),
+	}, nil
+}
+
+func (r *paymentRepo) Save(ctx context.Context, p *domain.Payment) error {
+	r.logger.Info("saving payment", zap.String("order_id", string(p.OrderID)), zap.String("tx_hash", p.TxHash))
+
+	query := `
+			INSERT INTO payments (order_id, chain, asset, tx_hash, block_number, block_hash, log_index, from_address, to_address, value, confirmations, detected_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10::NUMERIC, $11, $12)
+			ON CONFLICT (tx_hash, to_address, log_index) DO UPDATE
+			SET block_number = EXCLUDED.block_number, block_hash = EXCLUDED.block_hash, confirmations = EXCLUDED.confirmations
+			RETURNING id
+			`
+
+	err := r.pool.QueryRow(ctx, query, p.OrderID, p.Chain, p.Asset, p.TxHash, int64(p.BlockNumber), p.BlockHash, p.LogIndex, p.FromAddress, p.ToAddress, bigIntText(p.Value), int64(p.Confirmations), p.DetectedAt).Scan(&p.ID)
+	if err != nil {
+		r.logger.Error("failed to save payment", zap.String("tx_hash", p.TxHash), zap.Error(err))
+		return fmt.Errorf("failed to save payment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *paymentRepo) FindByOrderIDs(ctx context.Context, ids []domain.OrderID) (map[domain.OrderID][]domain.Payment, error) {
+	payments, err := findPayments(ctx, r.pool, ids)
+	if err != nil {
+		r.logger.Error("failed to find payments", zap.Int("orders", len(ids)), zap.Error(err))
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *paymentRepo) RefreshConfirmations(ctx context.Context, chain string, head, upTo uint64) error {
+	query := `
+			UPDATE payments SET confirmations = GREATEST($2 - block_number + 1, 0)
+			WHERE chain = $1 AND confirmations < $3
+			`
+
+	if _, err := r.pool.Exec(ctx, query, chain, int64(head), int64(upTo)); err != nil {
+		r.logger.Error("failed to refresh payment confirmations", zap.String("chain", chain), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *paymentRepo) DeleteFromBlock(ctx context.Context, chain string, number uint64) error {
+	r.logger.Warn("deleting payments of reorganised blocks", zap.String("chain", chain), zap.Uint64("from_block", number))
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM payments WHERE chain = $1 AND block_number >= $2`, chain, int64(number)); err != nil {
+		r.logger.Error("failed to delete payments", zap.String("chain", chain), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// findPayments is shared with the order repository, which returns an order together with its payments.
+func findPayments(ctx context.Context, pool *pgxpool.Pool, ids []domain.OrderID) (map[domain.OrderID][]domain.Payment, error) {
+	query := `
+			SELECT id, order_id, chain, asset, tx_hash, block_number, block_hash, log_index, from_address, to_address, value::TEXT, confirmations, detected_at
+			FROM payments WHERE order_id = ANY($1)
+			ORDER BY block_number ASC, log_index ASC, id ASC
+			`
+
+	orderIDs := make([]string, len(ids))
+	for i, id := range ids {
+		orderIDs[i] = string(id)
+	}
+
+	rows, err := pool.Query(ctx, query, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make(map[domain.OrderID][]domain.Payment, len(ids))
+	for rows.Next() {
+		var p domain.Payment
+		var blockNumber, confirmations int64
+		var value string
+		if err := rows.Scan(&p.ID, &p.OrderID, &p.Chain, &p.Asset, &p.TxHash, &blockNumber, &p.BlockHash, &p.LogIndex, &p.FromAddress, &p.ToAddress, &value, &confirmations, &p.DetectedAt); err != nil {
+			return nil, err
+		}
+		if p.Value, err = parseBigInt(&value); err != nil {
+			return nil, err
+		}
+		p.BlockNumber, p.Confirmations = uint64(blockNumber), uint64(confirmations)
+		payments[p.OrderID] = append(payments[p.OrderID], p)
+	}
+
+	return payments, rows.Err()
+}
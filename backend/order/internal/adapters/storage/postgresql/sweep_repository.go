@@ -0,0 +1,161 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type sweepRepo struct {
+	pool   *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewSweepRepository must be created after the order repository, since sweeps reference orders.
+func NewSweepRepository(pool *pgxpool.Pool, logger *zap.Logger) (domain.SweepRepository, error) {
+	_, err := pool.Exec(context.Background(), `
+		CREATE TABLE IF NOT EXISTS sweeps (
+			id BIGSERIAL PRIMARY KEY,
+			order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			chain TEXT NOT NULL,
+			asset TEXT NOT NULL,
+			address TEXT NOT NULL,
+			treasury TEXT NOT NULL,
+			amount NUMERIC(78, 0),
+			gas_top_up_tx_hash TEXT,
+			tx_hash TEXT,
+			status TEXT NOT NULL,
+			error TEXT NOT NULL DEFAULT '',
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (chain, address, asset)
+		);
+	`)
+	if err != nil {
+		logger.Fatal("failed to create sweeps table", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = pool.Exec(context.Background(), `CREATE INDEX IF NOT EXISTS idx_sweeps_status ON sweeps(chain, status);`)
+	if err != nil {
+		logger.Fatal("failed to create index on sweeps status", zap.Error(err))
+		return nil, err
+	}
+
+	return &sweepRepo{
+		pool:   pool,
+		logger: logger.Named("postgres_sweep_repo"),
+	}, nil
+}
+
+func (r *sweepRepo) Save(ctx context.Context, s *domain.Sweep) error {
+	r.logger.Info("saving sweep", zap.String("address", s.Address), zap.String("asset", s.Asset), zap.String("status", string(s.Status)))
+
+	query := `
+			INSERT INTO sweeps (order_id, chain, asset, address, treasury, amount, gas_top_up_tx_hash, tx_hash, status, error, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6::NUMERIC, $7, $8, $9, $10, NOW())
+			ON CONFLICT (chain, address, asset) DO UPDATE
+			SET treasury = EXCLUDED.treasury, amount = EXCLUDED.amount, gas_top_up_tx_hash = EXCLUDED.gas_top_up_tx_hash,
+			    tx_hash = EXCLUDED.tx_hash, status = EXCLUDED.status, error = EXCLUDED.error, updated_at = NOW()
+			RETURNING id, updated_at
+			`
+
+	err := r.pool.QueryRow(ctx, query, s.OrderID, s.Chain, s.Asset, s.Address, s.Treasury, bigIntText(s.Amount), s.GasTopUpTxHash, s.TxHash, s.Status, s.Error).Scan(&s.ID, &s.UpdatedAt)
+	if err != nil {
+		r.logger.Error("failed to save sweep", zap.String("address", s.Address), zap.Error(err))
+		return fmt.Errorf("failed to save sweep: %w", err)
+	}
+
+	return nil
+}
+
+const sweepSelect = `
+			SELECT id, order_id, chain, asset, address, treasury, amount::TEXT, gas_top_up_tx_hash, tx_hash, status, error, updated_at
+			FROM sweeps`
+
+func (r *sweepRepo) Find(ctx context.Context, chain, address, asset string) (*domain.Sweep, error) {
+	s, err := scanSweep(r.pool.QueryRow(ctx, sweepSelect+` WHERE chain = $1 AND address = $2 AND asset = $3`, chain, address, asset))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.logger.Error("failed to find sweep", zap.String("address", address), zap.Error(err))
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *sweepRepo) FindByStatus(ctx context.Context, chain string, status domain.SweepStatus) ([]*domain.Sweep, error) {
+	rows, err := r.pool.Query(ctx, sweepSelect+` WHERE chain = $1 AND status = $2 ORDER BY updated_at ASC`, chain, status)
+	if err != nil {
+		r.logger.Error("failed to query sweeps", zap.String("status", string(status)), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sweeps []*domain.Sweep
+	for rows.Next() {
+		s, err := scanSweep(rows)
+		if err != nil {
+			r.logger.Error("failed to scan sweep row", zap.Error(err))
+			return nil, err
+		}
+		sweeps = append(sweeps, s)
+	}
+
+	return sweeps, rows.Err()
+}
+
+func (r *sweepRepo) FindOrdersToSweep(ctx context.Context, retryBefore time.Time, limit int) ([]*domain.Order, error) {
+	// Ethereum payment addresses are hex, Bitcoin ones are not.
+	query := orderSelect + ` o
+			WHERE o.status = ANY($1) AND o.payment_address LIKE '0x%' AND o.derivation_index IS NOT NULL
+			  AND NOT EXISTS (
+			      SELECT 1 FROM sweeps s
+			      WHERE s.chain = $2 AND s.address = o.payment_address
+			        AND (s.status = ANY($3) OR (s.status = ANY($4) AND s.updated_at >= $5))
+			  )
+			ORDER BY o.created_at ASC
+			LIMIT $6
+			`
+
+	statuses := []string{string(domain.StatusPaid), string(domain.StatusShipped)}
+	settled := []string{string(domain.SweepSubmitted), string(domain.SweepConfirmed)}
+	retried := []string{string(domain.SweepFailed), string(domain.SweepEmpty)}
+	rows, err := r.pool.Query(ctx, query, statuses, domain.ChainEthereum, settled, retried, retryBefore, limit)
+	if err != nil {
+		r.logger.Error("failed to query orders to sweep", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			r.logger.Error("failed to scan order row", zap.Error(err))
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+func scanSweep(row pgx.Row) (*domain.Sweep, error) {
+	var s domain.Sweep
+	var amount *string
+	err := row.Scan(&s.ID, &s.OrderID, &s.Chain, &s.Asset, &s.Address, &s.Treasury, &amount, &s.GasTopUpTxHash, &s.TxHash, &s.Status, &s.Error, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if s.Amount, err = parseBigInt(amount); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -1,16 +1,97 @@
 package redis
 
 import (
-	"black-shop/internal/order/domain"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"order/internal/domain"
+	"pkg/money"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// cartTTL is refreshed on every write, so a cart expires a week after it was last changed.
+const cartTTL = time.Hour * 24 * 7
+
+// A cart is kept in two hashes sharing the same fields, one per line: KEYS[1] holds quantities and
+// KEYS[2] the unit price seen when the line was last added. KEYS[3] is a string holding the applied
+// coupon code. The scripts below keep them in step and refresh the TTL of all three. The three keys share
+// the hash tag of their owner, so on Redis Cluster they live in one slot and a script can touch them all.
+
+// addItemScript increments a cart line unless the result would exceed the per-line maximum.
+// It returns the new quantity, or -1 when the maximum would be exceeded.
+var addItemScript = redis.NewScript(`
+local quantity = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0') + tonumber(ARGV[2])
+if quantity > tonumber(ARGV[3]) then
+	return -1
+end
+redis.call('HSET', KEYS[1], ARGV[1], quantity)
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[5])
+for _, key in ipairs(KEYS) do
+	redis.call('EXPIRE', key, ARGV[4])
+end
+return quantity
+`)
+
+// setItemScript replaces the quantity of an existing cart line. It returns 0 when the line does not exist.
+var setItemScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+for _, key in ipairs(KEYS) do
+	redis.call('EXPIRE', key, ARGV[3])
+end
+return 1
+`)
+
+// removeItemScript deletes a cart line. It returns 0 when the line does not exist.
+var removeItemScript = redis.NewScript(`
+local removed = redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+for _, key in ipairs(KEYS) do
+	redis.call('EXPIRE', key, ARGV[2])
+end
+return removed
+`)
+
+// takeCartScript reads every line, price and the coupon of a cart and deletes it. It returns the lines
+// and prices as flat field/value lists and the coupon code, empty when there is none.
+var takeCartScript = redis.NewScript(`
+local items = redis.call('HGETALL', KEYS[1])
+local prices = redis.call('HGETALL', KEYS[2])
+local coupon = redis.call('GET', KEYS[3]) or ''
+redis.call('DEL', KEYS[1], KEYS[2], KEYS[3])
+return {items, prices, coupon}
+`)
+
+// mergeLinesScript adds lines taken from another cart, passed as field, quantity and price triples from
+// ARGV[4] on, capping merged quantities at the per-line maximum ARGV[1]. Lines already in the cart keep
+// their recorded price, and the cart keeps its coupon if it has one, ARGV[3] is set otherwise.
+var mergeLinesScript = redis.NewScript(`
+for i = 4, #ARGV, 3 do
+	local quantity = tonumber(redis.call('HGET', KEYS[1], ARGV[i]) or '0') + tonumber(ARGV[i + 1])
+	if quantity > tonumber(ARGV[1]) then
+		quantity = tonumber(ARGV[1])
+	end
+	redis.call('HSET', KEYS[1], ARGV[i], quantity)
+	if ARGV[i + 2] ~= '' then
+		redis.call('HSETNX', KEYS[2], ARGV[i], ARGV[i + 2])
+	end
+end
+if ARGV[3] ~= '' then
+	redis.call('SET', KEYS[3], ARGV[3], 'NX')
+end
+for _, key in ipairs(KEYS) do
+	redis.call('EXPIRE', key, ARGV[2])
+end
+return 1
+`)
+
 type cartRepo struct {
 	client *redis.Client
 	logger *zap.Logger
@@ -23,60 +104,263 @@ func NewCartRepository(client *redis.Client, logger *zap.Logger) domain.CartRepo
 	}
 }
 
-func generateCartKey(userID string) string {
-	return fmt.Sprintf("cart:%s", userID)
+// generateCartKey returns the key of the hash holding a cart, one field per line. Guest carts live under
+// their own prefix so a guest token can never address a user's cart. The owner is the hash tag of the key.
+// Carts used to be stored as a JSON string under cart:<userID>, and then under keys without the hash tag;
+// those keys simply expire.
+func generateCartKey(owner domain.CartOwner) string {
+	if owner.IsGuest() {
+		return fmt.Sprintf("guest_cart:{%s}:items", owner.GuestToken)
+	}
+	return fmt.Sprintf("cart:{%s}:items", owner.UserID)
+}
+
+// generatePricesKey returns the key of the hash holding the unit price of each line when it was added.
+func generatePricesKey(owner domain.CartOwner) string {
+	if owner.IsGuest() {
+		return fmt.Sprintf("guest_cart:{%s}:prices", owner.GuestToken)
+	}
+	return fmt.Sprintf("cart:{%s}:prices", owner.UserID)
+}
+
+// generateCouponKey returns the key of the string holding the coupon code applied to the cart.
+func generateCouponKey(owner domain.CartOwner) string {
+	if owner.IsGuest() {
+		return fmt.Sprintf("guest_cart:{%s}:coupon", owner.GuestToken)
+	}
+	return fmt.Sprintf("cart:{%s}:coupon", owner.UserID)
+}
+
+func cartKeys(owner domain.CartOwner) []string {
+	return []string{generateCartKey(owner), generatePricesKey(owner), generateCouponKey(owner)}
+}
+
+func generateItemField(productID, variantID string) string {
+	return productID + ":" + variantID
+}
+
+// parsePrice reads a price written by Money.String, e.g. "19.99 USD". Prices written before
+// currencies were recorded have no currency and are read as money.DefaultCurrency.
+func parsePrice(value string) (money.Money, error) {
+	amount, currency, found := strings.Cut(value, " ")
+	if !found {
+		currency = money.DefaultCurrency
+	}
+	return money.Parse(amount, currency)
+}
+
+func parseItemField(field string) (productID, variantID string) {
+	productID, variantID, _ = strings.Cut(field, ":")
+	return productID, variantID
+}
+
+func (r *cartRepo) Get(ctx context.Context, owner domain.CartOwner) (*domain.Cart, error) {
+	key := generateCartKey(owner)
+	r.logger.Info("getting cart", zap.String("key", key))
+
+	var fieldsCmd, pricesCmd *redis.MapStringStringCmd
+	var couponCmd *redis.StringCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		fieldsCmd = pipe.HGetAll(ctx, key)
+		pricesCmd = pipe.HGetAll(ctx, generatePricesKey(owner))
+		couponCmd = pipe.Get(ctx, generateCouponKey(owner))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.logger.Error("failed to get cart from redis", zap.Error(err))
+		return nil, err
+	}
+
+	fields, prices := fieldsCmd.Val(), pricesCmd.Val()
+	if len(fields) == 0 {
+		r.logger.Warn("cart not found", zap.String("key", key))
+		return nil, domain.ErrCartNotFound
+	}
+
+	items := make([]domain.CartItem, 0, len(fields))
+	for field, value := range fields {
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			r.logger.Error("failed to parse cart item quantity", zap.String("field", field), zap.Error(err))
+			return nil, err
+		}
+
+		// Lines written before prices were recorded have none; they are never flagged as changed.
+		var addedPrice money.Money
+		if price, ok := prices[field]; ok {
+			if addedPrice, err = parsePrice(price); err != nil {
+				r.logger.Warn("ignoring unreadable cart item price", zap.String("field", field), zap.Error(err))
+			}
+		}
+
+		productID, variantID := parseItemField(field)
+		items = append(items, domain.CartItem{ProductID: productID, VariantID: variantID, Quantity: quantity, AddedPrice: addedPrice})
+	}
+
+	// Hash fields come back in no particular order; keep the cart stable between reads.
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ProductID != items[j].ProductID {
+			return items[i].ProductID < items[j].ProductID
+		}
+		return items[i].VariantID < items[j].VariantID
+	})
+
+	return &domain.Cart{
+		UserID:     owner.UserID,
+		GuestToken: owner.GuestToken,
+		Items:      items,
+		CouponCode: couponCmd.Val(),
+	}, nil
+}
+
+func (r *cartRepo) AddItem(ctx context.Context, owner domain.CartOwner, item domain.CartItem) error {
+	keys := cartKeys(owner)
+	r.logger.Info("adding cart item", zap.String("key", keys[0]), zap.String("product_id", item.ProductID))
+
+	field := generateItemField(item.ProductID, item.VariantID)
+	price := item.AddedPrice.String()
+	quantity, err := addItemScript.Run(ctx, r.client, keys, field, item.Quantity, domain.MaxCartItemQuantity, int(cartTTL.Seconds()), price).Int()
+	if err != nil {
+		r.logger.Error("failed to add cart item to redis", zap.Error(err))
+		return err
+	}
+
+	if quantity < 0 {
+		return domain.ErrInvalidQuantity
+	}
+
+	return nil
 }
 
-func (r *cartRepo) Save(ctx context.Context, cart *domain.Cart) error {
-	key := generateCartKey(cart.UserID)
-	r.logger.Info("saving cart", zap.String("key", key))
+func (r *cartRepo) SetItemQuantity(ctx context.Context, owner domain.CartOwner, item domain.CartItem) error {
+	keys := cartKeys(owner)
+	r.logger.Info("setting cart item quantity", zap.String("key", keys[0]), zap.String("product_id", item.ProductID), zap.Int("quantity", item.Quantity))
 
-	cartData, err := json.Marshal(cart.Items)
+	field := generateItemField(item.ProductID, item.VariantID)
+	updated, err := setItemScript.Run(ctx, r.client, keys, field, item.Quantity, int(cartTTL.Seconds())).Int()
 	if err != nil {
-		r.logger.Error("failed to marshal cart data", zap.Error(err))
+		r.logger.Error("failed to set cart item quantity in redis", zap.Error(err))
 		return err
 	}
 
-	err = r.client.Set(ctx, key, cartData, time.Hour*24*7).Err()
+	if updated == 0 {
+		return domain.ErrCartItemNotFound
+	}
+
+	return nil
+}
+
+func (r *cartRepo) RemoveItem(ctx context.Context, owner domain.CartOwner, productID, variantID string) error {
+	keys := cartKeys(owner)
+	r.logger.Info("removing cart item", zap.String("key", keys[0]), zap.String("product_id", productID))
+
+	removed, err := removeItemScript.Run(ctx, r.client, keys, generateItemField(productID, variantID), int(cartTTL.Seconds())).Int()
 	if err != nil {
-		r.logger.Error("failed to save cart to redis", zap.Error(err))
+		r.logger.Error("failed to remove cart item from redis", zap.Error(err))
 		return err
 	}
 
+	if removed == 0 {
+		return domain.ErrCartItemNotFound
+	}
+
 	return nil
 }
 
-func (r *cartRepo) GetByUserID(ctx context.Context, userID string) (*domain.Cart, error) {
-	key := generateCartKey(userID)
-	r.logger.Info("getting cart", zap.String("key", key))
+// Merge moves every line of the source cart into the destination cart and deletes the source. The carts
+// hash to different slots, so the source is taken by one script and its lines merged by another; when
+// the merge fails they are put back into the source.
+func (r *cartRepo) Merge(ctx context.Context, from, to domain.CartOwner) (int, error) {
+	fromKeys, toKeys := cartKeys(from), cartKeys(to)
+	r.logger.Info("merging carts", zap.String("from", fromKeys[0]), zap.String("to", toKeys[0]))
 
-	cartData, err := r.client.Get(ctx, key).Bytes()
+	taken, err := takeCartScript.Run(ctx, r.client, fromKeys).Slice()
+	if err != nil {
+		r.logger.Error("failed to take cart from redis", zap.Error(err))
+		return 0, err
+	}
+	coupon, lines, err := parseTakenCart(taken)
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			r.logger.Warn("cart not found", zap.String("key", key))
-			return nil, domain.ErrCartNotFound
+		r.logger.Error("failed to parse taken cart", zap.Error(err))
+		return 0, err
+	}
+	if coupon == "" && len(lines) == 0 {
+		return 0, nil
+	}
+
+	args := append([]interface{}{domain.MaxCartItemQuantity, int(cartTTL.Seconds()), coupon}, lines...)
+	if err := mergeLinesScript.Run(ctx, r.client, toKeys, args...).Err(); err != nil {
+		r.logger.Error("failed to merge carts in redis", zap.Error(err))
+		if err := mergeLinesScript.Run(ctx, r.client, fromKeys, args...).Err(); err != nil {
+			r.logger.Error("failed to put back merged cart", zap.String("key", fromKeys[0]), zap.Error(err))
 		}
-		r.logger.Error("failed to get cart from redis", zap.Error(err))
-		return nil, err
+		return 0, err
 	}
 
-	var items []domain.CartItem
-	if err := json.Unmarshal(cartData, &items); err != nil {
-		r.logger.Error("failed to unmarshal cart data", zap.Error(err))
-		return nil, err
+	return len(lines) / 3, nil
+}
+
+// parseTakenCart reads the result of takeCartScript into its coupon code and the field, quantity and
+// price triples mergeLinesScript takes. Lines without a recorded price get an empty one.
+func parseTakenCart(taken []interface{}) (string, []interface{}, error) {
+	if len(taken) != 3 {
+		return "", nil, fmt.Errorf("unexpected taken cart of %d values", len(taken))
+	}
+	items, ok := taken[0].([]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected cart items %T", taken[0])
+	}
+	priceList, ok := taken[1].([]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected cart prices %T", taken[1])
+	}
+	coupon, ok := taken[2].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("unexpected cart coupon %T", taken[2])
 	}
 
-	return &domain.Cart{
-		UserID: userID,
-		Items:  items,
-	}, nil
+	prices := make(map[interface{}]interface{}, len(priceList)/2)
+	for i := 0; i+1 < len(priceList); i += 2 {
+		prices[priceList[i]] = priceList[i+1]
+	}
+	lines := make([]interface{}, 0, len(items)/2*3)
+	for i := 0; i+1 < len(items); i += 2 {
+		price, ok := prices[items[i]]
+		if !ok {
+			price = ""
+		}
+		lines = append(lines, items[i], items[i+1], price)
+	}
+	return coupon, lines, nil
+}
+
+func (r *cartRepo) SetCoupon(ctx context.Context, owner domain.CartOwner, code string) error {
+	key := generateCouponKey(owner)
+	r.logger.Info("setting cart coupon", zap.String("key", key), zap.String("code", code))
+
+	if err := r.client.Set(ctx, key, code, cartTTL).Err(); err != nil {
+		r.logger.Error("failed to set cart coupon in redis", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *cartRepo) ClearCoupon(ctx context.Context, owner domain.CartOwner) error {
+	key := generateCouponKey(owner)
+	r.logger.Info("clearing cart coupon", zap.String("key", key))
+
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		r.logger.Error("failed to clear cart coupon in redis", zap.Error(err))
+		return err
+	}
+	return nil
 }
 
-func (r *cartRepo) Delete(ctx context.Context, userID string) error {
-	key := generateCartKey(userID)
-	r.logger.Info("deleting cart", zap.String("key", key))
+func (r *cartRepo) Delete(ctx context.Context, owner domain.CartOwner) error {
+	keys := cartKeys(owner)
+	r.logger.Info("deleting cart", zap.String("key", keys[0]))
 
-	err := r.client.Del(ctx, key).Err()
+	err := r.client.Del(ctx, keys...).Err()
 	if err != nil {
 		r.logger.Error("failed to delete cart from redis", zap.Error(err))
 		return err
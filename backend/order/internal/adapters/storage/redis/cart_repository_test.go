@@ -0,0 +1,274 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/matryer/is"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	redisStorage "order/internal/adapters/storage/redis"
+	"order/internal/domain"
+	"pkg/money"
+)
+
+var user = domain.UserCart("user-1")
+
+func newTestCartRepository(t *testing.T) (domain.CartRepository, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return redisStorage.NewCartRepository(client, zap.NewNop()), server
+}
+
+func TestCartRepository_AddItemMergesLines(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	items := []domain.CartItem{
+		{ProductID: "p1", VariantID: "v1", Quantity: 2},
+		{ProductID: "p1", VariantID: "v2", Quantity: 1},
+		{ProductID: "p1", VariantID: "v1", Quantity: 3},
+	}
+	for _, item := range items {
+		is.NoErr(repo.AddItem(ctx, user, item))
+	}
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items, []domain.CartItem{
+		{ProductID: "p1", VariantID: "v1", Quantity: 5},
+		{ProductID: "p1", VariantID: "v2", Quantity: 1},
+	})
+}
+
+func TestCartRepository_AddItemRecordsLatestPrice(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	for _, price := range []string{"10.50", "12.25"} {
+		item := domain.CartItem{ProductID: "p1", Quantity: 1, AddedPrice: money.MustParse(price, "USD")}
+		is.NoErr(repo.AddItem(ctx, user, item))
+	}
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items[0].AddedPrice, money.MustParse("12.25", "USD"))
+}
+
+func TestCartRepository_ReadsPricesWithoutCurrency(t *testing.T) {
+	is := is.New(t)
+	repo, server := newTestCartRepository(t)
+	ctx := context.Background()
+
+	// Prices used to be stored as plain floats.
+	server.HSet("cart:{user-1}:items", "p1:", "1")
+	server.HSet("cart:{user-1}:prices", "p1:", "19.9")
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items[0].AddedPrice, money.New(1990, money.DefaultCurrency))
+}
+
+func TestCartRepository_AddItemRejectsQuantityOverMax(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	item := domain.CartItem{ProductID: "p1", Quantity: domain.MaxCartItemQuantity}
+	is.NoErr(repo.AddItem(ctx, user, item))
+
+	item.Quantity = 1
+	err := repo.AddItem(ctx, user, item)
+	is.True(errors.Is(err, domain.ErrInvalidQuantity))
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items[0].Quantity, domain.MaxCartItemQuantity) // the line is left as it was
+}
+
+func TestCartRepository_ConcurrentAddsAreNotLost(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	const writers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Failing from another goroutine must not stop the test.
+			is := is.NewRelaxed(t)
+			is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 1}))
+		}()
+	}
+	wg.Wait()
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items[0].Quantity, writers)
+}
+
+func TestCartRepository_SetAndRemoveItem(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	err := repo.SetItemQuantity(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 4})
+	is.True(errors.Is(err, domain.ErrCartItemNotFound)) // the line does not exist yet
+
+	is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 1}))
+	is.NoErr(repo.SetItemQuantity(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 4}))
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items[0].Quantity, 4)
+
+	is.NoErr(repo.RemoveItem(ctx, user, "p1", ""))
+	err = repo.RemoveItem(ctx, user, "p1", "")
+	is.True(errors.Is(err, domain.ErrCartItemNotFound)) // the line is already removed
+	_, err = repo.Get(ctx, user)
+	is.True(errors.Is(err, domain.ErrCartNotFound)) // the emptied cart is gone
+}
+
+func TestCartRepository_TTLSlidesOnWrite(t *testing.T) {
+	is := is.New(t)
+	repo, server := newTestCartRepository(t)
+	ctx := context.Background()
+	week := 7 * 24 * time.Hour
+
+	is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 1}))
+
+	server.FastForward(6 * 24 * time.Hour)
+	is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p2", Quantity: 1}))
+
+	is.Equal(server.TTL("cart:{user-1}:items"), week) // TTL after the last write
+
+	server.FastForward(week - time.Second)
+	_, err := repo.Get(ctx, user)
+	is.NoErr(err) // the cart expired before its sliding TTL
+
+	server.FastForward(time.Second)
+	_, err = repo.Get(ctx, user)
+	is.True(errors.Is(err, domain.ErrCartNotFound)) // the cart outlived its TTL
+}
+
+func TestCartRepository_MergeGuestCartIntoUserCart(t *testing.T) {
+	is := is.New(t)
+	repo, _ := newTestCartRepository(t)
+	ctx := context.Background()
+
+	guestToken, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+	guest := domain.GuestCart(guestToken)
+
+	userItems := []domain.CartItem{
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "p2", Quantity: 90},
+	}
+	guestItems := []domain.CartItem{
+		{ProductID: "p1", Quantity: 3},
+		{ProductID: "p2", Quantity: 20},
+		{ProductID: "p3", VariantID: "v1", Quantity: 1},
+	}
+	for _, item := range userItems {
+		is.NoErr(repo.AddItem(ctx, user, item))
+	}
+	for _, item := range guestItems {
+		is.NoErr(repo.AddItem(ctx, guest, item))
+	}
+
+	merged, err := repo.Merge(ctx, guest, user)
+	is.NoErr(err)
+	is.Equal(merged, len(guestItems))
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.Items, []domain.CartItem{
+		{ProductID: "p1", Quantity: 5},
+		{ProductID: "p2", Quantity: domain.MaxCartItemQuantity},
+		{ProductID: "p3", VariantID: "v1", Quantity: 1},
+	})
+
+	_, err = repo.Get(ctx, guest)
+	is.True(errors.Is(err, domain.ErrCartNotFound)) // the merged guest cart is gone
+}
+
+func TestCartRepository_CouponFollowsCart(t *testing.T) {
+	is := is.New(t)
+	repo, server := newTestCartRepository(t)
+	ctx := context.Background()
+
+	guestToken, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+	guest := domain.GuestCart(guestToken)
+
+	is.NoErr(repo.AddItem(ctx, guest, domain.CartItem{ProductID: "p1", Quantity: 1}))
+	is.NoErr(repo.SetCoupon(ctx, guest, "WELCOME10"))
+
+	// The user's own coupon wins over the guest's.
+	is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p2", Quantity: 1}))
+	is.NoErr(repo.SetCoupon(ctx, user, "SUMMER"))
+	_, err = repo.Merge(ctx, guest, user)
+	is.NoErr(err)
+
+	cart, err := repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.CouponCode, "SUMMER")
+	is.True(!server.Exists("guest_cart:{" + guestToken + "}:coupon")) // the merge deletes the guest coupon
+
+	is.NoErr(repo.ClearCoupon(ctx, user))
+	cart, err = repo.Get(ctx, user)
+	is.NoErr(err)
+	is.Equal(cart.CouponCode, "")
+}
+
+func TestCartRepository_KeysOfACartShareOneSlot(t *testing.T) {
+	is := is.New(t)
+	repo, server := newTestCartRepository(t)
+	ctx := context.Background()
+
+	is.NoErr(repo.AddItem(ctx, user, domain.CartItem{ProductID: "p1", Quantity: 1, AddedPrice: money.MustParse("1.00", "USD")}))
+	is.NoErr(repo.SetCoupon(ctx, user, "SUMMER"))
+
+	// Redis Cluster only hashes the part between the braces, so every key of the cart is in one slot.
+	keys := server.Keys()
+	is.Equal(len(keys), 3)
+	for _, key := range keys {
+		is.True(strings.Contains(key, "{user-1}")) // key without the owner's hash tag
+	}
+}
+
+func TestCartRepository_FailedMergePutsTheGuestCartBack(t *testing.T) {
+	is := is.New(t)
+	repo, server := newTestCartRepository(t)
+	ctx := context.Background()
+
+	guestToken, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+	guest := domain.GuestCart(guestToken)
+	is.NoErr(repo.AddItem(ctx, guest, domain.CartItem{ProductID: "p1", Quantity: 2, AddedPrice: money.MustParse("3.50", "USD")}))
+	is.NoErr(repo.SetCoupon(ctx, guest, "WELCOME10"))
+
+	// The user's lines are not a hash, so merging into them fails.
+	is.NoErr(server.Set("cart:{user-1}:items", "corrupt"))
+	_, err = repo.Merge(ctx, guest, user)
+	is.True(err != nil)
+
+	cart, err := repo.Get(ctx, guest)
+	is.NoErr(err)
+	is.Equal(cart.Items, []domain.CartItem{{ProductID: "p1", Quantity: 2, AddedPrice: money.MustParse("3.50", "USD")}})
+	is.Equal(cart.CouponCode, "WELCOME10")
+}
@@ -0,0 +1,48 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"order/internal/domain"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenEntry is one token of the registry file, e.g. {"USDC": {"contract": "0xA0b8...eB48", "decimals": 6}}.
+type tokenEntry struct {
+	Contract string `json:"contract"`
+	Decimals int    `json:"decimals"`
+}
+
+// LoadRegistry reads the ERC-20 tokens accepted for payments from a JSON file mapping a symbol to its
+// contract on the configured network. Without a path only ETH is accepted.
+func LoadRegistry(path string) (domain.TokenRegistry, error) {
+	if path == "" {
+		return domain.NewTokenRegistry(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens file: %w", err)
+	}
+
+	var entries map[string]tokenEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens file: %w", err)
+	}
+
+	tokens := make([]domain.Token, 0, len(entries))
+	for symbol, entry := range entries {
+		if !common.IsHexAddress(entry.Contract) {
+			return nil, fmt.Errorf("token %s has invalid contract address %q", symbol, entry.Contract)
+		}
+		tokens = append(tokens, domain.Token{
+			Symbol:   symbol,
+			Contract: common.HexToAddress(entry.Contract).Hex(),
+			Decimals: entry.Decimals,
+		})
+	}
+
+	return domain.NewTokenRegistry(tokens)
+}
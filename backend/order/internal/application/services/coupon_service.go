@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"order/internal/domain"
+	"pkg/money"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ApplyCoupon puts a coupon on the owner's cart. The coupon must grant a discount on the cart as it is now;
+// it is evaluated again every time the cart is priced and at checkout.
+func (s *Service) ApplyCoupon(ctx context.Context, owner domain.CartOwner, code string) (*domain.PricedCart, error) {
+	code = domain.NormalizeCouponCode(code)
+	s.logger.Info("applying coupon to cart", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("code", code))
+
+	cart, err := s.priceCart(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, domain.ErrEmptyCart
+	}
+
+	if _, err := s.evaluateCoupon(ctx, code, owner.UserID, cart.CouponLines(), cart.Subtotal); err != nil {
+		s.logger.Warn("coupon rejected", zap.String("code", code), zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.cartRepo.SetCoupon(ctx, owner, code); err != nil {
+		s.logger.Error("failed to apply coupon to cart", zap.Error(err))
+		return nil, err
+	}
+
+	return s.priceCart(ctx, owner)
+}
+
+func (s *Service) RemoveCoupon(ctx context.Context, owner domain.CartOwner) (*domain.PricedCart, error) {
+	s.logger.Info("removing coupon from cart", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+
+	if err := s.cartRepo.ClearCoupon(ctx, owner); err != nil {
+		s.logger.Error("failed to remove coupon from cart", zap.Error(err))
+		return nil, err
+	}
+
+	return s.priceCart(ctx, owner)
+}
+
+func (s *Service) CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error) {
+	s.logger.Info("creating coupon", zap.String("code", coupon.Code))
+
+	newCoupon, err := domain.NewCoupon(coupon.Code, coupon.Type, coupon.Value, coupon.Amount, coupon.MinBasket, coupon.MaxUses, coupon.MaxUsesPerUser, coupon.ValidFrom, coupon.ValidUntil, coupon.ProductIDs, coupon.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.couponRepo.Create(ctx, newCoupon); err != nil {
+		return nil, err
+	}
+
+	return newCoupon, nil
+}
+
+func (s *Service) ListCoupons(ctx context.Context) ([]*domain.Coupon, error) {
+	s.logger.Info("listing coupons")
+	return s.couponRepo.List(ctx)
+}
+
+// evaluateCoupon loads a coupon and returns the discounts it grants on the given lines.
+// Guests have no redemptions yet; the per-user limit is enforced again when the order is saved.
+func (s *Service) evaluateCoupon(ctx context.Context, code, userID string, lines []domain.CouponLine, subtotal money.Money) ([]domain.DiscountLine, error) {
+	coupon, err := s.couponRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions := 0
+	if userID != "" {
+		if redemptions, err = s.couponRepo.CountRedemptions(ctx, coupon.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return coupon.Evaluate(time.Now(), lines, subtotal, s.shippingFee, redemptions)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"order/internal/domain"
+	"pkg/money"
 	"pkg/wallet"
 	"time"
 
@@ -14,89 +15,227 @@ import (
 type Service struct {
 	cartRepo      domain.CartRepository
 	orderRepo     domain.OrderRepository
+	paymentRepo   domain.PaymentRepository
+	couponRepo    domain.CouponRepository
+	catalog       domain.ProductCatalog
 	walletService *wallet.Service
+	rates         *RateService
+	tokens        domain.TokenRegistry
+	btcNetwork    string      // Bitcoin network BTC addresses are derived for, empty when BTC is not accepted
+	shippingFee   money.Money // Also sets the currency carts and orders are priced in
 	logger        *zap.Logger
 }
 
 func NewService(
 	cartRepo domain.CartRepository,
 	orderRepo domain.OrderRepository,
+	paymentRepo domain.PaymentRepository,
+	couponRepo domain.CouponRepository,
+	catalog domain.ProductCatalog,
 	walletService *wallet.Service,
+	rates *RateService,
+	tokens domain.TokenRegistry,
+	btcNetwork string,
+	shippingFee money.Money,
 	logger *zap.Logger,
 ) *Service {
 
 	return &Service{
 		cartRepo:      cartRepo,
 		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		couponRepo:    couponRepo,
+		catalog:       catalog,
 		walletService: walletService,
+		rates:         rates,
+		tokens:        tokens,
+		btcNetwork:    btcNetwork,
+		shippingFee:   shippingFee,
 		logger:        logger.Named("order_service"),
 	}
 }
 
-func (s *Service) AddItemToCart(ctx context.Context, userID string, item domain.CartItem) (*domain.Cart, error) {
-	s.logger.Info("adding item to cart", zap.String("user_id", userID), zap.String("product_id", item.ProductID))
+func (s *Service) CreateGuestCart(ctx context.Context) (string, error) {
+	token, err := domain.NewGuestCartToken()
+	if err != nil {
+		s.logger.Error("failed to generate guest cart token", zap.Error(err))
+		return "", err
+	}
+
+	s.logger.Info("issued guest cart token")
+	return token, nil
+}
 
-	cart, err := s.cartRepo.GetByUserID(ctx, userID)
-	if err != nil && !errors.Is(err, domain.ErrCartNotFound) {
-		s.logger.Error("failed to get existing cart", zap.Error(err))
+func (s *Service) AddItemToCart(ctx context.Context, owner domain.CartOwner, item domain.CartItem) (*domain.PricedCart, error) {
+	s.logger.Info("adding item to cart", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", item.ProductID))
+
+	if err := domain.ValidateCartQuantity(item.Quantity); err != nil {
 		return nil, err
 	}
 
-	if cart == nil {
-		cart = &domain.Cart{UserID: userID, Items: []domain.CartItem{}}
+	// The price seen now is stored with the line so the cart can later flag price changes.
+	prices, err := s.catalog.ResolvePrices(ctx, []domain.CartItem{item})
+	if err != nil {
+		s.logger.Error("failed to resolve item price from catalog", zap.Error(err))
+		return nil, err
 	}
+	if !prices[0].Found || !prices[0].Published || prices[0].UnitPrice.Currency != s.shippingFee.Currency {
+		return nil, fmt.Errorf("%w: %s", domain.ErrProductUnavailable, item.ProductID)
+	}
+	item.AddedPrice = prices[0].UnitPrice
 
-	found := false
-	for i, existingItem := range cart.Items {
-		if existingItem.ProductID == item.ProductID {
-			cart.Items[i].Quantity += item.Quantity
-			found = true
-			break
-		}
+	if err := s.cartRepo.AddItem(ctx, owner, item); err != nil {
+		s.logger.Error("failed to add item to cart", zap.Error(err))
+		return nil, err
 	}
 
-	if !found {
-		cart.Items = append(cart.Items, item)
+	return s.priceCart(ctx, owner)
+}
+
+func (s *Service) UpdateCartItemQuantity(ctx context.Context, owner domain.CartOwner, productID, variantID string, quantity int) (*domain.PricedCart, error) {
+	s.logger.Info("updating cart item quantity", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", productID), zap.Int("quantity", quantity))
+
+	if err := domain.ValidateCartQuantity(quantity); err != nil {
+		return nil, err
+	}
+
+	item := domain.CartItem{ProductID: productID, VariantID: variantID, Quantity: quantity}
+	if err := s.cartRepo.SetItemQuantity(ctx, owner, item); err != nil {
+		s.logger.Error("failed to update cart item quantity", zap.Error(err))
+		return nil, err
 	}
 
-	fmt.Println("*********************** Cart", cart)
+	return s.priceCart(ctx, owner)
+}
+
+func (s *Service) RemoveCartItem(ctx context.Context, owner domain.CartOwner, productID, variantID string) (*domain.PricedCart, error) {
+	s.logger.Info("removing cart item", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", productID))
 
-	if err := s.cartRepo.Save(ctx, cart); err != nil {
-		s.logger.Error("failed to save updated cart", zap.Error(err))
+	if err := s.cartRepo.RemoveItem(ctx, owner, productID, variantID); err != nil {
+		s.logger.Error("failed to remove cart item", zap.Error(err))
 		return nil, err
 	}
 
-	return cart, nil
+	return s.priceCart(ctx, owner)
 }
 
-func (s *Service) GetCart(ctx context.Context, userID string) (*domain.Cart, error) {
-	s.logger.Info("getting user cart", zap.String("user_id", userID))
-	return s.cartRepo.GetByUserID(ctx, userID)
+func (s *Service) ClearCart(ctx context.Context, owner domain.CartOwner) error {
+	s.logger.Info("clearing cart", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+
+	if err := s.cartRepo.Delete(ctx, owner); err != nil {
+		s.logger.Error("failed to clear cart", zap.Error(err))
+		return err
+	}
+
+	return nil
 }
 
-func (s *Service) CreateOrderFromCart(ctx context.Context, userID string, paymentMethod string) (*domain.Order, error) {
-	s.logger.Info("creating order from cart", zap.String("user_id", userID), zap.String("payment_method", paymentMethod))
+func (s *Service) GetCart(ctx context.Context, owner domain.CartOwner) (*domain.PricedCart, error) {
+	s.logger.Info("getting cart", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+	return s.priceCart(ctx, owner)
+}
 
-	cart, err := s.cartRepo.GetByUserID(ctx, userID)
+// MergeCart moves the guest cart into the user's cart after login and returns the priced result.
+func (s *Service) MergeCart(ctx context.Context, userID, guestToken string) (*domain.PricedCart, error) {
+	if _, err := s.MergeGuestCart(ctx, userID, guestToken); err != nil {
+		return nil, err
+	}
+	return s.priceCart(ctx, domain.UserCart(userID))
+}
+
+// MergeGuestCart moves the guest cart into the user's cart and returns how many lines it moved. Lines
+// present in both carts have their quantities added, capped at the per-line maximum; the guest cart is
+// deleted. Merging a guest cart that is already gone moves nothing.
+func (s *Service) MergeGuestCart(ctx context.Context, userID, guestToken string) (int, error) {
+	s.logger.Info("merging guest cart", zap.String("user_id", userID))
+
+	if err := domain.ValidateGuestCartToken(guestToken); err != nil {
+		return 0, err
+	}
+
+	merged, err := s.cartRepo.Merge(ctx, domain.GuestCart(guestToken), domain.UserCart(userID))
+	if err != nil {
+		s.logger.Error("failed to merge guest cart", zap.Error(err))
+		return 0, err
+	}
+
+	if merged > 0 {
+		s.logger.Info("guest cart merged", zap.String("user_id", userID), zap.Int("lines", merged))
+	}
+	return merged, nil
+}
+
+// priceCart loads the owner's cart and prices it against the catalog, shipping and the applied coupon.
+// A cart without items is returned empty rather than as ErrCartNotFound. A coupon that no longer
+// applies stays on the cart with CouponError set, so the shopper can see why.
+func (s *Service) priceCart(ctx context.Context, owner domain.CartOwner) (*domain.PricedCart, error) {
+	cart, err := s.cartRepo.Get(ctx, owner)
 	if err != nil {
+		if errors.Is(err, domain.ErrCartNotFound) {
+			return domain.NewPricedCart(&domain.Cart{UserID: owner.UserID, GuestToken: owner.GuestToken}, nil, s.shippingFee.Currency), nil
+		}
 		return nil, err
 	}
 
-	if len(cart.Items) == 0 {
-		return nil, errors.New("cannot create order from an empty cart")
+	prices, err := s.catalog.ResolvePrices(ctx, cart.Items)
+	if err != nil {
+		s.logger.Error("failed to resolve cart prices from catalog", zap.Error(err))
+		return nil, err
 	}
 
-	var orderItems []domain.OrderItem
-	for _, cartItem := range cart.Items {
+	priced := domain.NewPricedCart(cart, prices, s.shippingFee.Currency)
 
-		// TODO Request to GRPC for get product price of catalog-service
-		price := 10.00
+	var discounts []domain.DiscountLine
+	if priced.CouponCode != "" {
+		discounts, err = s.evaluateCoupon(ctx, priced.CouponCode, owner.UserID, priced.CouponLines(), priced.Subtotal)
+		if err != nil {
+			if !domain.IsCouponRejection(err) {
+				return nil, err
+			}
+			priced.CouponError = err.Error()
+		}
+	}
+	priced.ApplyPricing(s.shippingFee, discounts)
 
-		orderItems = append(orderItems, domain.OrderItem{
-			ProductID: cartItem.ProductID,
-			Quantity:  cartItem.Quantity,
-			Price:     price,
-		})
+	return priced, nil
+}
+
+// CreateOrderFromCart places an order for the user's cart. Crypto orders are paid in paymentAsset:
+// ETH, one of the registered ERC-20 tokens or BTC when a Bitcoin network is configured. It defaults to ETH.
+func (s *Service) CreateOrderFromCart(ctx context.Context, userID string, paymentMethod, paymentAsset string) (*domain.Order, error) {
+	s.logger.Info("creating order from cart", zap.String("user_id", userID), zap.String("payment_method", paymentMethod), zap.String("payment_asset", paymentAsset))
+
+	// Checked before anything is priced or reserved, so an order that could never be paid is never placed.
+	if paymentMethod != domain.PaymentMethodCrypto {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedPayment, paymentMethod)
+	}
+
+	var decimals int
+	if paymentMethod == domain.PaymentMethodCrypto {
+		if paymentAsset == "" {
+			paymentAsset = domain.AssetETH
+		}
+		if paymentAsset == domain.AssetBTC && s.btcNetwork == "" {
+			return nil, domain.ErrUnsupportedAsset
+		}
+		var err error
+		if decimals, err = s.tokens.Decimals(paymentAsset); err != nil {
+			return nil, err
+		}
+	}
+
+	cart, err := s.cartRepo.Get(ctx, domain.UserCart(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cart.Items) == 0 {
+		return nil, domain.ErrEmptyCart
+	}
+
+	orderItems, couponLines, err := s.priceCartItems(ctx, cart.Items)
+	if err != nil {
+		return nil, err
 	}
 
 	newOrder, err := domain.NewOrder(userID, paymentMethod, orderItems)
@@ -104,32 +243,74 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID string, paymen
 		return nil, err
 	}
 
-	if paymentMethod == "CRYPTO" {
+	// A coupon that stopped applying fails the checkout instead of silently charging the full price.
+	var discounts []domain.DiscountLine
+	if cart.CouponCode != "" {
+		discounts, err = s.evaluateCoupon(ctx, cart.CouponCode, userID, couponLines, newOrder.Subtotal())
+		if err != nil {
+			return nil, err
+		}
+	}
+	newOrder.ApplyPricing(s.shippingFee, discounts)
+
+	// A crypto order is only paid once a transfer covers its quote, which never happens for a zero total.
+	if !newOrder.TotalPrice.IsPositive() {
+		return nil, domain.ErrNothingToPay
+	}
+
+	var deriveAddress domain.AddressDeriver
+	if paymentMethod == domain.PaymentMethodCrypto {
 		s.logger.Info("crypto payment method selected, generating address...")
 
+		quote, err := s.rates.Quote(ctx, paymentAsset, decimals, newOrder.TotalPrice)
+		if err != nil {
+			s.logger.Error("failed to quote order total", zap.Error(err))
+			return nil, err
+		}
+		newOrder.PaymentQuote = quote
+		if token, ok := s.tokens[paymentAsset]; ok {
+			newOrder.PaymentToken = &token
+		}
+
 		// derivationPath (Mnemonic): m/purpose'/coin_type'/account'/change/address_index
-		// purpose: Always is 44 for BIP44 standard
-		// coin_type: Bitcoin = 0, Ethereum = 60
+		// purpose: 44 for BIP44 (Ethereum), 84 for BIP84 native SegWit (Bitcoin)
+		// coin_type: Bitcoin = 0 (1 on test networks), Ethereum = 60 (ERC-20 tokens are paid to Ethereum addresses as well)
 		// account: Support multi accounts ( personal or work account)
-		// address_index: is a counter increase for a new address
-		addressIndex := time.Now().Unix()
-		derivationPath := fmt.Sprintf("m/44'/60'/0'/%d", addressIndex)
+		// change: 0 for addresses handed out to receive payments
+		// address_index: the order's derivation index, reserved by the repository when the order is saved
+		if paymentAsset == domain.AssetBTC {
+			coinType, err := wallet.BitcoinCoinType(s.btcNetwork)
+			if err != nil {
+				return nil, err
+			}
+			deriveAddress = func(index int64) (string, error) {
+				return s.walletService.DeriveBitcoinAddress(fmt.Sprintf("m/84'/%d'/0'/0/%d", coinType, index), s.btcNetwork)
+			}
+		} else {
+			deriveAddress = func(index int64) (string, error) {
+				return s.walletService.DeriveAddress(wallet.EthereumPaymentPath(index))
+			}
+		}
 
-		paymentAddr, err := s.walletService.DeriveAddress(derivationPath)
-		if err != nil {
-			s.logger.Error("failed to derive payment address", zap.Error(err))
-			return nil, fmt.Errorf("could not generate payment address for order")
+		if err := newOrder.TransitionTo(domain.StatusAwaitingPayment, domain.ActorSystem, "crypto payment address assigned"); err != nil {
+			return nil, err
 		}
+	}
 
-		newOrder.PaymentAddress = &paymentAddr
-		newOrder.Status = domain.StatusAwaitingPayment
+	if err := s.orderRepo.Save(ctx, newOrder, deriveAddress); err != nil {
+		return nil, err
 	}
 
-	if err := s.orderRepo.Save(ctx, newOrder); err != nil {
+	// Stock is reserved against the persisted order id; an order whose items are gone is cancelled again.
+	if err := s.catalog.ReserveStock(ctx, newOrder.ID, newOrder.Items); err != nil {
+		s.logger.Warn("failed to reserve stock for order", zap.String("order_id", string(newOrder.ID)), zap.Error(err))
+		if cancelErr := s.cancelOrder(ctx, newOrder, domain.ActorSystem, "stock reservation failed"); cancelErr != nil {
+			s.logger.Error("failed to cancel order after stock reservation failure", zap.String("order_id", string(newOrder.ID)), zap.Error(cancelErr))
+		}
 		return nil, err
 	}
 
-	if err := s.cartRepo.Delete(ctx, userID); err != nil {
+	if err := s.cartRepo.Delete(ctx, domain.UserCart(userID)); err != nil {
 		s.logger.Error("failed to delete cart after order creation", zap.String("user_id", userID), zap.Error(err))
 	}
 
@@ -137,3 +318,273 @@ func (s *Service) CreateOrderFromCart(ctx context.Context, userID string, paymen
 	return newOrder, nil
 
 }
+
+func (s *Service) HasPurchasedProduct(ctx context.Context, userID, productID string) (bool, error) {
+	s.logger.Info("checking product purchase", zap.String("user_id", userID), zap.String("product_id", productID))
+	return s.orderRepo.HasPaidOrderWithProduct(ctx, userID, productID)
+}
+
+func (s *Service) GetOrder(ctx context.Context, userID string, orderID domain.OrderID) (*domain.Order, error) {
+	s.logger.Info("getting order", zap.String("user_id", userID), zap.String("order_id", string(orderID)))
+
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other users' orders are reported as missing rather than forbidden.
+	if order.UserID != userID {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+func (s *Service) GetOrderStatusHistory(ctx context.Context, userID string, orderID domain.OrderID) ([]domain.StatusChange, error) {
+	s.logger.Info("getting order status history", zap.String("user_id", userID), zap.String("order_id", string(orderID)))
+
+	order, err := s.GetOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return order.StatusHistory, nil
+}
+
+func (s *Service) ListMyOrders(ctx context.Context, userID string, filter domain.OrderFilter) (*domain.OrderPage, error) {
+	s.logger.Info("listing user orders", zap.String("user_id", userID))
+
+	return s.listOrders(ctx, filter, func(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+		return s.orderRepo.FindByUserID(ctx, userID, filter)
+	})
+}
+
+func (s *Service) SearchOrders(ctx context.Context, filter domain.OrderFilter) (*domain.OrderPage, error) {
+	s.logger.Info("searching orders", zap.String("user_id", filter.UserID))
+
+	return s.listOrders(ctx, filter, s.orderRepo.Search)
+}
+
+func (s *Service) CancelOrder(ctx context.Context, userID string, orderID domain.OrderID, reason string) (*domain.Order, error) {
+	s.logger.Info("cancelling order", zap.String("user_id", userID), zap.String("order_id", string(orderID)))
+
+	order, err := s.GetOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.CustomerCancellable() {
+		return nil, fmt.Errorf("%w: a %s order cannot be cancelled by the customer", domain.ErrInvalidStatusTransition, order.Status)
+	}
+
+	if reason == "" {
+		reason = "cancelled by customer"
+	}
+
+	return order, s.cancelOrder(ctx, order, userID, reason)
+}
+
+func (s *Service) AdminCancelOrder(ctx context.Context, adminID string, orderID domain.OrderID, reason string) (*domain.Order, error) {
+	s.logger.Info("cancelling order as admin", zap.String("admin_id", adminID), zap.String("order_id", string(orderID)))
+
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "cancelled by admin"
+	}
+
+	return order, s.cancelOrder(ctx, order, adminID, reason)
+}
+
+// ExpireUnpaidOrders cancels crypto orders still awaiting (the rest of) their payment that were created
+// before createdBefore and returns how many were cancelled. Whatever a partially paid order received stays
+// recorded on it so it can be refunded. Orders with a transfer detected but not confirmed yet are left to the
+// payment watcher until it is.
+func (s *Service) ExpireUnpaidOrders(ctx context.Context, createdBefore time.Time) (int, error) {
+	s.logger.Info("expiring unpaid orders", zap.Time("created_before", createdBefore))
+
+	filter := domain.OrderFilter{
+		Statuses:  []domain.OrderStatus{domain.StatusAwaitingPayment, domain.StatusPartiallyPaid},
+		CreatedTo: &createdBefore,
+		Limit:     domain.MaxOrderPageSize,
+	}
+
+	expired := 0
+	for {
+		orders, err := s.orderRepo.Search(ctx, filter)
+		if err != nil {
+			s.logger.Error("failed to find unpaid orders", zap.Error(err))
+			return expired, err
+		}
+
+		ids := make([]domain.OrderID, len(orders))
+		for i, order := range orders {
+			ids[i] = order.ID
+		}
+		payments, err := s.paymentRepo.FindByOrderIDs(ctx, ids)
+		if err != nil {
+			s.logger.Error("failed to find payments of unpaid orders", zap.Error(err))
+			return expired, err
+		}
+
+		for _, order := range orders {
+			if order.AwaitsConfirmation(payments[order.ID]) {
+				s.logger.Info("not expiring order with an unconfirmed payment", zap.String("order_id", string(order.ID)))
+				continue
+			}
+			if err := s.cancelOrder(ctx, order, domain.ActorSystem, "payment window elapsed"); err != nil {
+				// The watcher may have marked the order paid in the meantime; leave it alone.
+				s.logger.Warn("failed to expire order", zap.String("order_id", string(order.ID)), zap.Error(err))
+				continue
+			}
+			expired++
+		}
+
+		if len(orders) < filter.Limit {
+			break
+		}
+		last := orders[len(orders)-1]
+		filter.After = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return expired, nil
+}
+
+// RetryStockReleases hands back the reserved stock of cancelled orders whose release failed before and
+// returns how many were released.
+func (s *Service) RetryStockReleases(ctx context.Context) (int, error) {
+	filter := domain.OrderFilter{
+		Statuses:            []domain.OrderStatus{domain.StatusCancelled},
+		StockReleasePending: true,
+		Limit:               domain.MaxOrderPageSize,
+	}
+
+	released := 0
+	for {
+		orders, err := s.orderRepo.Search(ctx, filter)
+		if err != nil {
+			s.logger.Error("failed to find orders with a pending stock release", zap.Error(err))
+			return released, err
+		}
+
+		for _, order := range orders {
+			if s.releaseStock(ctx, order) {
+				released++
+			}
+		}
+
+		if len(orders) < filter.Limit {
+			break
+		}
+		last := orders[len(orders)-1]
+		filter.After = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return released, nil
+}
+
+// cancelOrder persists the cancellation, with the stock release pending, and then hands the order's reserved
+// stock back to the catalog. A failed release stays pending on the order and is retried by RetryStockReleases.
+func (s *Service) cancelOrder(ctx context.Context, order *domain.Order, changedBy, reason string) error {
+	if err := order.TransitionTo(domain.StatusCancelled, changedBy, reason); err != nil {
+		return err
+	}
+	order.StockReleasePending = true
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		s.logger.Error("failed to save cancelled order", zap.String("order_id", string(order.ID)), zap.Error(err))
+		return err
+	}
+
+	s.releaseStock(ctx, order)
+
+	s.logger.Info("order cancelled", zap.String("order_id", string(order.ID)), zap.String("changed_by", changedBy))
+	return nil
+}
+
+// releaseStock hands the reserved stock of a cancelled order back to the catalog and clears the pending
+// release. It reports whether it did; failures are logged and left for the next retry.
+func (s *Service) releaseStock(ctx context.Context, order *domain.Order) bool {
+	if err := s.catalog.ReleaseStock(ctx, order.ID); err != nil {
+		s.logger.Error("failed to release stock for cancelled order", zap.String("order_id", string(order.ID)), zap.Error(err))
+		return false
+	}
+
+	order.StockReleasePending = false
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		s.logger.Error("failed to clear the pending stock release", zap.String("order_id", string(order.ID)), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// listOrders fetches one extra row to learn whether another page exists.
+func (s *Service) listOrders(ctx context.Context, filter domain.OrderFilter, find func(ctx context.Context, filter domain.OrderFilter) ([]*domain.Order, error)) (*domain.OrderPage, error) {
+	pageSize := filter.Limit
+	if pageSize <= 0 {
+		pageSize = domain.DefaultOrderPageSize
+	}
+	if pageSize > domain.MaxOrderPageSize {
+		pageSize = domain.MaxOrderPageSize
+	}
+	filter.Limit = pageSize + 1
+
+	orders, err := find(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list orders", zap.Error(err))
+		return nil, err
+	}
+
+	page := &domain.OrderPage{Orders: orders}
+	if len(orders) > pageSize {
+		page.Orders = orders[:pageSize]
+		last := page.Orders[pageSize-1]
+		page.NextCursor = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return page, nil
+}
+
+// priceCartItems snapshots the current catalog price, SKU and name of every cart item,
+// rejecting items that are missing, unpublished or out of stock, with the quantities of lines for the
+// same variant added up. It also returns the lines
+// as seen by a coupon.
+func (s *Service) priceCartItems(ctx context.Context, items []domain.CartItem) ([]domain.OrderItem, []domain.CouponLine, error) {
+	prices, err := s.catalog.ResolvePrices(ctx, items)
+	if err != nil {
+		s.logger.Error("failed to resolve cart prices from catalog", zap.Error(err))
+		return nil, nil, err
+	}
+
+	quantities := domain.VariantQuantities(items, prices)
+	orderItems := make([]domain.OrderItem, 0, len(items))
+	couponLines := make([]domain.CouponLine, 0, len(items))
+	for i, cartItem := range items {
+		price := prices[i]
+
+		if !price.Found || !price.Published || price.UnitPrice.Currency != s.shippingFee.Currency {
+			return nil, nil, fmt.Errorf("%w: %s", domain.ErrProductUnavailable, cartItem.ProductID)
+		}
+		if price.Stock < quantities[price.VariantID] {
+			return nil, nil, fmt.Errorf("%w: %s", domain.ErrInsufficientStock, cartItem.ProductID)
+		}
+
+		orderItems = append(orderItems, domain.OrderItem{
+			ProductID: cartItem.ProductID,
+			VariantID: price.VariantID,
+			SKU:       price.SKU,
+			Name:      price.Name,
+			Quantity:  cartItem.Quantity,
+			Price:     price.UnitPrice,
+		})
+		couponLines = append(couponLines, domain.CouponLine{
+			ProductID:  cartItem.ProductID,
+			CategoryID: price.CategoryID,
+			Amount:     price.UnitPrice.Mul(cartItem.Quantity),
+		})
+	}
+
+	return orderItems, couponLines, nil
+}
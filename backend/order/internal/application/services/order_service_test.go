@@ -0,0 +1,529 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"order/internal/application/services"
+	"order/internal/domain"
+	"pkg/money"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+)
+
+// testEnv is a Service on in-memory repositories and a fake catalog.
+type testEnv struct {
+	service  *services.Service
+	carts    *fakeCartRepo
+	orders   *fakeOrderRepo
+	payments *fakePaymentRepo
+	coupons  *fakeCouponRepo
+	catalog  *fakeCatalog
+}
+
+func newTestEnv() *testEnv {
+	return newTestEnvWithShipping(money.MustParse("4.99", "USD"))
+}
+
+func newTestEnvWithShipping(shippingFee money.Money) *testEnv {
+	env := &testEnv{
+		carts:    &fakeCartRepo{carts: make(map[string]*domain.Cart)},
+		orders:   &fakeOrderRepo{orders: make(map[domain.OrderID]*domain.Order)},
+		payments: &fakePaymentRepo{payments: make(map[domain.OrderID][]domain.Payment)},
+		coupons:  &fakeCouponRepo{coupons: make(map[string]*domain.Coupon)},
+		catalog:  &fakeCatalog{},
+	}
+	env.service = services.NewService(env.carts, env.orders, env.payments, env.coupons, env.catalog, nil, nil, nil, "", shippingFee, zap.NewNop())
+	return env
+}
+
+// addOrder stores an order of userID in status, created age ago.
+func (e *testEnv) addOrder(t *testing.T, userID string, status domain.OrderStatus, age time.Duration) *domain.Order {
+	is := is.New(t)
+	is.Helper()
+
+	order, err := domain.NewOrder(userID, "CRYPTO", []domain.OrderItem{{ProductID: "p1", VariantID: "v1", Quantity: 1, Price: money.MustParse("10.00", "USD")}})
+	is.NoErr(err)
+	order.CreatedAt = order.CreatedAt.Add(-age)
+	order.Status = status
+	order.PaymentQuote = &domain.PaymentQuote{Asset: domain.AssetETH, ExpectedAmount: big.NewInt(1000)}
+	e.orders.add(order)
+	return order
+}
+
+func TestService_CreateOrderFromCartRejectsUnsupportedPaymentMethods(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	for _, method := range []string{"", "CARD", "crypto"} {
+		_, err := env.service.CreateOrderFromCart(ctx, "user-1", method, "")
+		is.True(errors.Is(err, domain.ErrUnsupportedPayment)) // rejected before the cart is read
+	}
+	is.Equal(len(env.orders.orders), 0)    // nothing saved
+	is.Equal(len(env.catalog.reserved), 0) // nor any stock reserved
+}
+
+func TestService_CreateOrderFromCartRefusesNothingToPay(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnvWithShipping(money.Zero("USD"))
+	ctx := context.Background()
+
+	coupon, err := domain.NewCoupon("FREE", domain.CouponPercentage, 100, money.Money{}, money.Money{}, nil, nil, nil, nil, nil, nil)
+	is.NoErr(err)
+	env.coupons.coupons[coupon.Code] = coupon
+	env.carts.carts["user-1"] = &domain.Cart{UserID: "user-1", Items: []domain.CartItem{{ProductID: "p1", VariantID: "v1", Quantity: 2}}, CouponCode: "FREE"}
+
+	_, err = env.service.CreateOrderFromCart(ctx, "user-1", domain.PaymentMethodCrypto, "")
+	is.True(errors.Is(err, domain.ErrNothingToPay)) // a zero total could never be paid
+	is.Equal(len(env.orders.orders), 0)
+	is.Equal(len(env.catalog.reserved), 0)
+	is.Equal(len(env.carts.carts["user-1"].Items), 1) // the cart is kept
+}
+
+func TestService_GetOrderOfItsOwnerOnly(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	order := env.addOrder(t, "user-1", domain.StatusPending, 0)
+
+	found, err := env.service.GetOrder(ctx, "user-1", order.ID)
+	is.NoErr(err)
+	is.Equal(found.ID, order.ID)
+
+	_, err = env.service.GetOrder(ctx, "user-2", order.ID)
+	is.True(errors.Is(err, domain.ErrOrderNotFound)) // other users' orders are reported missing
+
+	_, err = env.service.GetOrder(ctx, "user-1", "unknown")
+	is.True(errors.Is(err, domain.ErrOrderNotFound))
+
+	_, err = env.service.GetOrderStatusHistory(ctx, "user-2", order.ID)
+	is.True(errors.Is(err, domain.ErrOrderNotFound))
+}
+
+func TestService_ListMyOrdersPagesThroughOwnOrders(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	var want []domain.OrderID
+	for i := 0; i < 5; i++ {
+		want = append(want, env.addOrder(t, "user-1", domain.StatusPending, time.Duration(i)*time.Minute).ID)
+		env.addOrder(t, "user-2", domain.StatusPending, time.Duration(i)*time.Minute)
+	}
+
+	var got []domain.OrderID
+	filter := domain.OrderFilter{Limit: 2}
+	pages := 0
+	for {
+		page, err := env.service.ListMyOrders(ctx, "user-1", filter)
+		is.NoErr(err)
+		pages++
+		is.True(len(page.Orders) <= 2)
+		for _, order := range page.Orders {
+			is.Equal(order.UserID, "user-1") // only the user's own orders are listed
+			got = append(got, order.ID)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		filter.After = page.NextCursor
+	}
+
+	is.Equal(pages, 3)
+	is.Equal(got, want) // newest first, each order once
+}
+
+func TestService_ListMyOrdersClampsThePageSize(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	for i := 0; i < domain.MaxOrderPageSize+1; i++ {
+		env.addOrder(t, "user-1", domain.StatusPending, time.Duration(i)*time.Second)
+	}
+
+	page, err := env.service.ListMyOrders(ctx, "user-1", domain.OrderFilter{})
+	is.NoErr(err)
+	is.Equal(len(page.Orders), domain.DefaultOrderPageSize) // no limit asks for the default page
+	is.True(page.NextCursor != nil)
+
+	page, err = env.service.ListMyOrders(ctx, "user-1", domain.OrderFilter{Limit: 1000})
+	is.NoErr(err)
+	is.Equal(len(page.Orders), domain.MaxOrderPageSize) // too large a limit is capped
+	is.True(page.NextCursor != nil)
+
+	last := page.Orders[len(page.Orders)-1]
+	is.Equal(*page.NextCursor, domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+}
+
+func TestService_SearchOrdersFiltersByStatus(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	paid := env.addOrder(t, "user-1", domain.StatusPaid, 0)
+	env.addOrder(t, "user-2", domain.StatusPending, 0)
+
+	page, err := env.service.SearchOrders(ctx, domain.OrderFilter{Statuses: []domain.OrderStatus{domain.StatusPaid}})
+	is.NoErr(err)
+	is.Equal(len(page.Orders), 1)
+	is.Equal(page.Orders[0].ID, paid.ID)
+	is.True(page.NextCursor == nil)
+}
+
+func TestService_CancelOrderByStatus(t *testing.T) {
+	tests := []struct {
+		status   domain.OrderStatus
+		customer bool // the owner may cancel it
+		admin    bool // an admin may cancel it
+	}{
+		{domain.StatusPending, true, true},
+		{domain.StatusAwaitingPayment, true, true},
+		{domain.StatusPartiallyPaid, false, true},
+		{domain.StatusPaid, false, false},
+		{domain.StatusShipped, false, false},
+		{domain.StatusCancelled, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			is := is.New(t)
+			env := newTestEnv()
+			ctx := context.Background()
+
+			order := env.addOrder(t, "user-1", tt.status, 0)
+			_, err := env.service.CancelOrder(ctx, "user-1", order.ID, "")
+			if tt.customer {
+				is.NoErr(err)
+				is.Equal(env.orders.get(order.ID).Status, domain.StatusCancelled)
+			} else {
+				is.True(errors.Is(err, domain.ErrInvalidStatusTransition))
+				is.Equal(env.orders.get(order.ID).Status, tt.status) // left as it was
+				is.Equal(len(env.catalog.released), 0)               // no stock handed back
+			}
+
+			order = env.addOrder(t, "user-1", tt.status, 0)
+			_, err = env.service.AdminCancelOrder(ctx, "admin", order.ID, "")
+			if tt.admin {
+				is.NoErr(err)
+				is.Equal(env.orders.get(order.ID).Status, domain.StatusCancelled)
+			} else {
+				is.True(errors.Is(err, domain.ErrInvalidStatusTransition))
+				is.Equal(env.orders.get(order.ID).Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestService_CancelOrderReleasesTheStock(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	order := env.addOrder(t, "user-1", domain.StatusAwaitingPayment, 0)
+
+	_, err := env.service.CancelOrder(ctx, "user-2", order.ID, "")
+	is.True(errors.Is(err, domain.ErrOrderNotFound)) // not the owner
+
+	cancelled, err := env.service.CancelOrder(ctx, "user-1", order.ID, "changed my mind")
+	is.NoErr(err)
+	is.Equal(cancelled.Status, domain.StatusCancelled)
+	is.Equal(env.catalog.released, []domain.OrderID{order.ID})
+
+	stored := env.orders.get(order.ID)
+	is.True(!stored.StockReleasePending) // cleared once the catalog confirmed
+	last := stored.StatusHistory[len(stored.StatusHistory)-1]
+	is.Equal(last.ChangedBy, "user-1")
+	is.Equal(last.Reason, "changed my mind")
+}
+
+func TestService_RetryStockReleasesAfterAFailedRelease(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	order := env.addOrder(t, "user-1", domain.StatusPending, 0)
+	env.catalog.releaseFailures = 1
+
+	_, err := env.service.CancelOrder(ctx, "user-1", order.ID, "")
+	is.NoErr(err) // the cancellation stands without the release
+	stored := env.orders.get(order.ID)
+	is.Equal(stored.Status, domain.StatusCancelled)
+	is.True(stored.StockReleasePending) // left for the retry
+
+	released, err := env.service.RetryStockReleases(ctx)
+	is.NoErr(err)
+	is.Equal(released, 1)
+	is.Equal(env.catalog.released, []domain.OrderID{order.ID})
+	is.True(!env.orders.get(order.ID).StockReleasePending)
+
+	released, err = env.service.RetryStockReleases(ctx)
+	is.NoErr(err)
+	is.Equal(released, 0) // nothing left pending
+}
+
+func TestService_ExpireUnpaidOrders(t *testing.T) {
+	is := is.New(t)
+	env := newTestEnv()
+	ctx := context.Background()
+
+	awaiting := env.addOrder(t, "user-1", domain.StatusAwaitingPayment, 2*time.Hour)
+	partial := env.addOrder(t, "user-1", domain.StatusPartiallyPaid, 2*time.Hour)
+	partial.AmountReceived = big.NewInt(400)
+	env.orders.put(partial)
+	unconfirmed := env.addOrder(t, "user-2", domain.StatusAwaitingPayment, 2*time.Hour)
+	recent := env.addOrder(t, "user-2", domain.StatusAwaitingPayment, 0)
+	paid := env.addOrder(t, "user-2", domain.StatusPaid, 2*time.Hour)
+
+	// Confirmed payments recorded on the order do not hold it back; a transfer still being confirmed does.
+	env.payments.add(domain.Payment{OrderID: partial.ID, Asset: domain.AssetETH, Value: big.NewInt(400), Confirmations: 12})
+	env.payments.add(domain.Payment{OrderID: unconfirmed.ID, Asset: domain.AssetETH, Value: big.NewInt(1000), Confirmations: 1})
+
+	expired, err := env.service.ExpireUnpaidOrders(ctx, time.Now().Add(-time.Hour))
+	is.NoErr(err)
+	is.Equal(expired, 2)
+
+	is.Equal(env.orders.get(awaiting.ID).Status, domain.StatusCancelled)
+	is.Equal(env.orders.get(partial.ID).Status, domain.StatusCancelled)
+	is.Equal(env.orders.get(partial.ID).AmountReceived, big.NewInt(400))          // kept for the refund
+	is.Equal(env.orders.get(unconfirmed.ID).Status, domain.StatusAwaitingPayment) // left to the payment watcher
+	is.Equal(env.orders.get(recent.ID).Status, domain.StatusAwaitingPayment)      // still within its window
+	is.Equal(env.orders.get(paid.ID).Status, domain.StatusPaid)
+
+	last := env.orders.get(awaiting.ID).StatusHistory
+	is.Equal(last[len(last)-1].ChangedBy, domain.ActorSystem)
+}
+
+// fakeCartRepo holds the carts of signed-in users by user id.
+type fakeCartRepo struct {
+	domain.CartRepository
+	carts map[string]*domain.Cart
+}
+
+func (r *fakeCartRepo) Get(_ context.Context, owner domain.CartOwner) (*domain.Cart, error) {
+	cart, ok := r.carts[owner.UserID]
+	if !ok {
+		return nil, domain.ErrCartNotFound
+	}
+	return cart, nil
+}
+
+// fakeCouponRepo looks coupons up by code; nobody has redeemed any yet.
+type fakeCouponRepo struct {
+	domain.CouponRepository
+	coupons map[string]*domain.Coupon
+}
+
+func (r *fakeCouponRepo) FindByCode(_ context.Context, code string) (*domain.Coupon, error) {
+	coupon, ok := r.coupons[code]
+	if !ok {
+		return nil, domain.ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func (r *fakeCouponRepo) CountRedemptions(context.Context, string, string) (int, error) {
+	return 0, nil
+}
+
+// fakeOrderRepo keeps orders in memory with the same status check on Update as the PostgreSQL one.
+type fakeOrderRepo struct {
+	domain.OrderRepository
+	mu     sync.Mutex
+	orders map[domain.OrderID]*domain.Order
+	nextID int
+}
+
+func copyOrder(o *domain.Order) *domain.Order {
+	c := *o
+	c.StatusHistory = append([]domain.StatusChange(nil), o.StatusHistory...)
+	c.Items = append([]domain.OrderItem(nil), o.Items...)
+	return &c
+}
+
+// add stores order as persisted, assigning it an id.
+func (r *fakeOrderRepo) add(order *domain.Order) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	order.ID = domain.OrderID(fmt.Sprintf("order-%03d", r.nextID))
+	for i := range order.StatusHistory {
+		order.StatusHistory[i].ID = int64(i + 1)
+	}
+	r.orders[order.ID] = copyOrder(order)
+}
+
+// put overwrites the stored copy of order.
+func (r *fakeOrderRepo) put(order *domain.Order) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.orders[order.ID] = copyOrder(order)
+}
+
+func (r *fakeOrderRepo) get(id domain.OrderID) *domain.Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyOrder(r.orders[id])
+}
+
+func (r *fakeOrderRepo) Update(_ context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.orders[order.ID]
+	if !ok {
+		return domain.ErrOrderNotFound
+	}
+	loaded := order.Status
+	if changes := order.PendingStatusChanges(); len(changes) > 0 {
+		loaded = *changes[0].FromStatus
+	}
+	if stored.Status != loaded {
+		return domain.ErrInvalidStatusTransition
+	}
+	for i := range order.StatusHistory {
+		if order.StatusHistory[i].ID == 0 {
+			order.StatusHistory[i].ID = int64(i + 1)
+		}
+	}
+	r.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+func (r *fakeOrderRepo) FindByID(_ context.Context, id domain.OrderID) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return copyOrder(order), nil
+}
+
+func (r *fakeOrderRepo) FindByUserID(ctx context.Context, userID string, filter domain.OrderFilter) ([]*domain.Order, error) {
+	filter.UserID = userID
+	return r.Search(ctx, filter)
+}
+
+func (r *fakeOrderRepo) Search(_ context.Context, filter domain.OrderFilter) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orders []*domain.Order
+	for _, o := range r.orders {
+		if filter.UserID != "" && o.UserID != filter.UserID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !hasStatus(filter.Statuses, o.Status) {
+			continue
+		}
+		if filter.CreatedTo != nil && !o.CreatedAt.Before(*filter.CreatedTo) {
+			continue
+		}
+		if filter.StockReleasePending && !o.StockReleasePending {
+			continue
+		}
+		if filter.After != nil && !newerThan(*filter.After, o) {
+			continue
+		}
+		orders = append(orders, copyOrder(o))
+	}
+
+	// Newest first, the same order as the keyset the cursors point into.
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.After(orders[j].CreatedAt)
+		}
+		return orders[i].ID > orders[j].ID
+	})
+	if len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+	}
+	return orders, nil
+}
+
+func hasStatus(statuses []domain.OrderStatus, status domain.OrderStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// newerThan reports whether order comes after the cursor, i.e. (created_at, id) < cursor.
+func newerThan(cursor domain.OrderCursor, order *domain.Order) bool {
+	if !order.CreatedAt.Equal(cursor.CreatedAt) {
+		return order.CreatedAt.Before(cursor.CreatedAt)
+	}
+	return order.ID < cursor.ID
+}
+
+// fakePaymentRepo returns the payments recorded for each order.
+type fakePaymentRepo struct {
+	domain.PaymentRepository
+	mu       sync.Mutex
+	payments map[domain.OrderID][]domain.Payment
+}
+
+func (r *fakePaymentRepo) add(payment domain.Payment) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.payments[payment.OrderID] = append(r.payments[payment.OrderID], payment)
+}
+
+func (r *fakePaymentRepo) FindByOrderIDs(_ context.Context, ids []domain.OrderID) (map[domain.OrderID][]domain.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := make(map[domain.OrderID][]domain.Payment)
+	for _, id := range ids {
+		if payments, ok := r.payments[id]; ok {
+			found[id] = payments
+		}
+	}
+	return found, nil
+}
+
+// fakeCatalog records the stock reservations and releases it is asked for and fails the next
+// releaseFailures releases.
+type fakeCatalog struct {
+	domain.ProductCatalog
+	mu              sync.Mutex
+	reserved        []domain.OrderID
+	released        []domain.OrderID
+	releaseFailures int
+}
+
+// ResolvePrices prices every item at 10.00 USD, with plenty of stock.
+func (c *fakeCatalog) ResolvePrices(_ context.Context, items []domain.CartItem) ([]domain.ProductPrice, error) {
+	prices := make([]domain.ProductPrice, len(items))
+	for i, item := range items {
+		prices[i] = domain.ProductPrice{ProductID: item.ProductID, VariantID: item.VariantID, UnitPrice: money.MustParse("10.00", "USD"), Stock: 100, Published: true, Found: true}
+	}
+	return prices, nil
+}
+
+func (c *fakeCatalog) ReserveStock(_ context.Context, orderID domain.OrderID, _ []domain.OrderItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reserved = append(c.reserved, orderID)
+	return nil
+}
+
+func (c *fakeCatalog) ReleaseStock(_ context.Context, orderID domain.OrderID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.releaseFailures > 0 {
+		c.releaseFailures--
+		return errors.New("catalog unavailable")
+	}
+	c.released = append(c.released, orderID)
+	return nil
+}
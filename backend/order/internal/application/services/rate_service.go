@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"order/internal/domain"
+	"pkg/money"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RateService quotes order totals in a crypto asset. Every rate fetched from the provider is stored
+// as a snapshot, and the latest snapshot is used while the provider is unavailable for up to maxAge.
+type RateService struct {
+	provider domain.RateProvider
+	rateRepo domain.ExchangeRateRepository
+	maxAge   time.Duration
+	logger   *zap.Logger
+}
+
+func NewRateService(provider domain.RateProvider, rateRepo domain.ExchangeRateRepository, maxAge time.Duration, logger *zap.Logger) *RateService {
+	return &RateService{
+		provider: provider,
+		rateRepo: rateRepo,
+		maxAge:   maxAge,
+		logger:   logger.Named("rate_service"),
+	}
+}
+
+// Quote converts total into asset at the current rate. decimals is the number of decimals of the
+// smallest unit of asset.
+func (s *RateService) Quote(ctx context.Context, asset string, decimals int, total money.Money) (*domain.PaymentQuote, error) {
+	rate, err := s.currentRate(ctx, asset, total.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := domain.NewPaymentQuote(total, rate, decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("quoted order total",
+		zap.String("total", total.String()),
+		zap.String("asset", asset),
+		zap.String("rate", rate.Price.String()),
+		zap.String("expected_amount", quote.ExpectedAmount.String()),
+	)
+	return quote, nil
+}
+
+func (s *RateService) currentRate(ctx context.Context, asset, currency string) (*domain.ExchangeRate, error) {
+	rate, err := s.provider.Rate(ctx, asset, currency)
+	if err == nil {
+		if err := s.rateRepo.Save(ctx, rate); err != nil {
+			return nil, err
+		}
+		return rate, nil
+	}
+	s.logger.Warn("rate provider failed, falling back to the latest snapshot", zap.String("asset", asset), zap.String("currency", currency), zap.Error(err))
+
+	rate, err = s.rateRepo.Latest(ctx, asset, currency)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(rate.FetchedAt) > s.maxAge {
+		s.logger.Warn("latest rate snapshot is too old", zap.String("asset", asset), zap.Time("fetched_at", rate.FetchedAt))
+		return nil, domain.ErrRateUnavailable
+	}
+	return rate, nil
+}
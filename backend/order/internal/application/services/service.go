@@ -3,10 +3,30 @@ package services
 import (
 	"context"
 	"order/internal/domain"
+	"time"
 )
 
 type OrderService interface {
-	CreateOrderFromCart(ctx context.Context, userID string, paymentMethod string) (*domain.Order, error)
-	AddItemToCart(ctx context.Context, userID string, item domain.CartItem) (*domain.Cart, error)
-	GetCart(ctx context.Context, userID string) (*domain.Cart, error)
+	CreateOrderFromCart(ctx context.Context, userID string, paymentMethod, paymentAsset string) (*domain.Order, error)
+	CreateGuestCart(ctx context.Context) (string, error)
+	AddItemToCart(ctx context.Context, owner domain.CartOwner, item domain.CartItem) (*domain.PricedCart, error)
+	GetCart(ctx context.Context, owner domain.CartOwner) (*domain.PricedCart, error)
+	UpdateCartItemQuantity(ctx context.Context, owner domain.CartOwner, productID, variantID string, quantity int) (*domain.PricedCart, error)
+	RemoveCartItem(ctx context.Context, owner domain.CartOwner, productID, variantID string) (*domain.PricedCart, error)
+	ClearCart(ctx context.Context, owner domain.CartOwner) error
+	MergeCart(ctx context.Context, userID, guestToken string) (*domain.PricedCart, error)
+	MergeGuestCart(ctx context.Context, userID, guestToken string) (int, error)
+	ApplyCoupon(ctx context.Context, owner domain.CartOwner, code string) (*domain.PricedCart, error)
+	RemoveCoupon(ctx context.Context, owner domain.CartOwner) (*domain.PricedCart, error)
+	CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	ListCoupons(ctx context.Context) ([]*domain.Coupon, error)
+	HasPurchasedProduct(ctx context.Context, userID, productID string) (bool, error)
+	GetOrder(ctx context.Context, userID string, orderID domain.OrderID) (*domain.Order, error)
+	ListMyOrders(ctx context.Context, userID string, filter domain.OrderFilter) (*domain.OrderPage, error)
+	SearchOrders(ctx context.Context, filter domain.OrderFilter) (*domain.OrderPage, error)
+	GetOrderStatusHistory(ctx context.Context, userID string, orderID domain.OrderID) ([]domain.StatusChange, error)
+	CancelOrder(ctx context.Context, userID string, orderID domain.OrderID, reason string) (*domain.Order, error)
+	AdminCancelOrder(ctx context.Context, adminID string, orderID domain.OrderID, reason string) (*domain.Order, error)
+	ExpireUnpaidOrders(ctx context.Context, createdBefore time.Time) (int, error)
+	RetryStockReleases(ctx context.Context) (int, error)
 }
@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"order/internal/application/services"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// OrderExpiry periodically cancels crypto orders whose payment window has elapsed and retries the stock
+// releases of cancelled orders that failed.
+type OrderExpiry struct {
+	service       services.OrderService
+	paymentWindow time.Duration
+	interval      time.Duration
+	logger        *zap.Logger
+}
+
+func NewOrderExpiry(service services.OrderService, paymentWindow time.Duration, logger *zap.Logger) *OrderExpiry {
+	return &OrderExpiry{
+		service:       service,
+		paymentWindow: paymentWindow,
+		interval:      time.Minute,
+		logger:        logger.Named("order_expiry"),
+	}
+}
+
+func (w *OrderExpiry) Start(ctx context.Context) {
+	w.logger.Info("Order expiry started...", zap.Duration("payment_window", w.paymentWindow))
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.expireOrders(ctx)
+			w.retryStockReleases(ctx)
+		case <-ctx.Done():
+			w.logger.Info("Order expiry shutting down.")
+			return
+		}
+	}
+}
+
+func (w *OrderExpiry) expireOrders(ctx context.Context) {
+	expired, err := w.service.ExpireUnpaidOrders(ctx, time.Now().Add(-w.paymentWindow))
+	if err != nil {
+		w.logger.Error("Failed to expire unpaid orders", zap.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		w.logger.Info("Expired unpaid orders", zap.Int("count", expired))
+	}
+}
+
+func (w *OrderExpiry) retryStockReleases(ctx context.Context) {
+	released, err := w.service.RetryStockReleases(ctx)
+	if err != nil {
+		w.logger.Error("Failed to retry stock releases", zap.Error(err))
+		return
+	}
+
+	if released > 0 {
+		w.logger.Info("Released stock of cancelled orders", zap.Int("count", released))
+	}
+}
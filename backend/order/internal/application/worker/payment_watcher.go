@@ -2,95 +2,462 @@ package worker
 
 import (
 	"context"
-	"math/big"
 	"order/internal/domain"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"go.uber.org/zap"
 )
 
+// resubscribeDelay is how long the watcher waits before watching the heads again after losing the chain.
+const resubscribeDelay = 5 * time.Second
+
+// PaymentWatcher follows the head of one chain through its backend and records the transfers paying
+// into the addresses of orders awaiting payment on that chain. An order is settled from the sum of its
+// payments in the quoted asset with at least confirmations confirmations. The processed blocks are
+// persisted, so a restarted watcher catches up from where it stopped, and payments of blocks that were
+// reorganised away are dropped again. The addresses of orders cancelled within the late payment window stay
+// watched, so transfers arriving after the cancellation are still recorded for refunds.
 type PaymentWatcher struct {
-	orderRepo domain.OrderRepository
-	ethClient *ethclient.Client
-	logger    *zap.Logger
-	ctx       context.Context
+	orderRepo     domain.OrderRepository
+	paymentRepo   domain.PaymentRepository
+	cursorRepo    domain.ChainCursorRepository
+	catalog       domain.ProductCatalog
+	backend       domain.ChainBackend
+	chain         string
+	confirmations uint64
+	latePayments  time.Duration            // How long the address of a cancelled order stays watched
+	watched       map[string]*domain.Order // By normalized payment address
+	logger        *zap.Logger
+	ctx           context.Context
 }
 
+func NewPaymentWatcher(
+	ctx context.Context,
+	orderRepo domain.OrderRepository,
+	paymentRepo domain.PaymentRepository,
+	cursorRepo domain.ChainCursorRepository,
+	catalog domain.ProductCatalog,
+	backend domain.ChainBackend,
+	confirmations uint64,
+	latePayments time.Duration,
+	logger *zap.Logger,
+) *PaymentWatcher {
+	return &PaymentWatcher{
+		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		cursorRepo:    cursorRepo,
+		catalog:       catalog,
+		backend:       backend,
+		chain:         backend.Chain(),
+		confirmations: confirmations,
+		latePayments:  latePayments,
+		watched:       make(map[string]*domain.Order),
+		logger:        logger.Named("payment_watcher").With(zap.String("chain", backend.Chain())),
+		ctx:           ctx,
+	}
+}
+
+// Start catches up with the chain and then syncs on every new head until the context is done.
 func (w *PaymentWatcher) Start() {
-	w.logger.Info("Payment watched started...")
-	ticker := time.NewTicker(30 * time.Second)
+	w.logger.Info("Payment watcher started...", zap.Uint64("confirmations", w.confirmations))
 
 	for {
+		if err := w.Sync(w.ctx); err != nil {
+			w.logger.Error("Failed to sync payments", zap.Error(err))
+		}
+
+		heads := make(chan struct{}, 1)
+		dropped := make(chan error, 1)
+		go func() {
+			dropped <- w.backend.WatchHeads(w.ctx, heads)
+		}()
+		w.follow(heads, dropped)
+
 		select {
-		case <-ticker.C:
-			w.logger.Info("Checking for pending payment...")
-			w.processPendingOrders()
 		case <-w.ctx.Done():
 			w.logger.Info("Payment watcher shutting down.")
 			return
+		case <-time.After(resubscribeDelay):
 		}
 	}
 }
 
-func (w *PaymentWatcher) processPendingOrders() {
-	limit := 100
-	offset := 0
+func (w *PaymentWatcher) follow(heads <-chan struct{}, dropped <-chan error) {
+	for {
+		select {
+		case <-heads:
+			if err := w.Sync(w.ctx); err != nil {
+				w.logger.Error("Failed to sync payments", zap.Error(err))
+			}
+		case err := <-dropped:
+			if err != nil {
+				w.logger.Warn("Lost the chain head", zap.Error(err))
+			}
+			return
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// Sync processes every block up to the current head, retries failed stock commits and settles the watched
+// orders.
+func (w *PaymentWatcher) Sync(ctx context.Context) error {
+	head, err := w.backend.Head(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := w.loadWatchedOrders(ctx); err != nil {
+		return err
+	}
+
+	next, err := w.nextBlock(ctx, head)
+	if err != nil {
+		return err
+	}
+
+	for n := next; n <= head; n++ {
+		block, err := w.backend.Block(ctx, n)
+		if err != nil {
+			return err
+		}
+
+		// A parent we processed that is not this block's parent any more means the chain reorganised.
+		if n > 0 {
+			parent, err := w.cursorRepo.Find(ctx, w.chain, n-1)
+			if err != nil {
+				return err
+			}
+			if parent != nil && parent.Hash != block.ParentHash {
+				ancestor, err := w.rewind(ctx, n-1)
+				if err != nil {
+					return err
+				}
+				n = ancestor // The loop continues with the first block after the common ancestor.
+				continue
+			}
+		}
+
+		if err := w.processBlock(ctx, block, head); err != nil {
+			return err
+		}
+		if err := w.cursorRepo.Save(ctx, w.chain, domain.BlockRef{Number: n, Hash: block.Hash}); err != nil {
+			return err
+		}
+	}
+
+	if err := w.paymentRepo.RefreshConfirmations(ctx, w.chain, head, w.confirmations); err != nil {
+		return err
+	}
+
+	// Commits that failed on an earlier sync are retried before new orders are settled.
+	if err := w.retryStockCommits(ctx); err != nil {
+		return err
+	}
+
+	return w.settleOrders(ctx)
+}
+
+// loadWatchedOrders refreshes the in-memory set of payment addresses from the orders awaiting payment and
+// the orders cancelled within the late payment window. Orders paid on another chain are left to the watcher
+// of that chain.
+func (w *PaymentWatcher) loadWatchedOrders(ctx context.Context) error {
+	watched := make(map[string]*domain.Order, len(w.watched))
+	watch := func(orders []*domain.Order) {
+		for _, order := range orders {
+			if order.PaymentAddress == nil {
+				continue
+			}
+			if address, ok := w.backend.NormalizeAddress(*order.PaymentAddress); ok {
+				watched[address] = order
+			}
+		}
+	}
 
+	if w.latePayments > 0 {
+		since := time.Now().Add(-w.latePayments)
+		err := findAllOrders(ctx, func(ctx context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+			return w.orderRepo.FindCancelledSince(ctx, since, after, limit)
+		}, watch)
+		if err != nil {
+			return err
+		}
+	}
+	if err := findAllOrders(ctx, w.orderRepo.FindAwaitingPayment, watch); err != nil {
+		return err
+	}
+
+	w.watched = watched
+	return nil
+}
+
+// findAllOrders pages through find, each page starting after the last order of the previous one, and
+// hands every page to fn.
+func findAllOrders(ctx context.Context, find func(ctx context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error), fn func([]*domain.Order)) error {
+	limit := 100
+	var after *domain.OrderCursor
 	for {
-		orders, err := w.orderRepo.FindAwaitingPayment(w.ctx, limit, offset)
+		orders, err := find(ctx, after, limit)
 		if err != nil {
-			w.logger.Error("Failed to fetch awaiting payment orders", zap.Error(err))
-			return
+			return err
+		}
+		fn(orders)
+
+		if len(orders) < limit {
+			return nil
+		}
+		last := orders[len(orders)-1]
+		after = &domain.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// nextBlock returns the first block to process. Without a persisted cursor the watcher starts at the
+// oldest block that is not confirmed yet. A cursor that is no longer on the chain is rewound first.
+func (w *PaymentWatcher) nextBlock(ctx context.Context, head uint64) (uint64, error) {
+	last, err := w.cursorRepo.Last(ctx, w.chain)
+	if err != nil {
+		return 0, err
+	}
+	if last == nil {
+		if head+1 < w.confirmations {
+			return 0, nil
 		}
+		if w.confirmations == 0 {
+			return head, nil
+		}
+		return head + 1 - w.confirmations, nil
+	}
+
+	if last.Number > head {
+		ancestor, err := w.rewind(ctx, head)
+		return ancestor + 1, err
+	}
+	block, err := w.backend.Block(ctx, last.Number)
+	if err != nil {
+		return 0, err
+	}
+	if block.Hash != last.Hash {
+		ancestor, err := w.rewind(ctx, last.Number)
+		return ancestor + 1, err
+	}
+	return last.Number + 1, nil
+}
 
-		if len(orders) == 0 {
+// rewind walks back from number to the newest processed block that is still on the chain, forgets
+// everything after it and returns its number.
+func (w *PaymentWatcher) rewind(ctx context.Context, number uint64) (uint64, error) {
+	ancestor := number
+	for ancestor > 0 {
+		processed, err := w.cursorRepo.Find(ctx, w.chain, ancestor)
+		if err != nil {
+			return 0, err
+		}
+		if processed == nil {
 			break
 		}
+		block, err := w.backend.Block(ctx, ancestor)
+		if err != nil {
+			return 0, err
+		}
+		if block.Hash == processed.Hash {
+			break
+		}
+		ancestor--
+	}
 
-		for _, order := range orders {
-			w.checkOrderPayment(order)
+	w.logger.Warn("Chain reorganisation detected", zap.Uint64("from_block", number), zap.Uint64("common_ancestor", ancestor))
+
+	if err := w.paymentRepo.DeleteFromBlock(ctx, w.chain, ancestor+1); err != nil {
+		return 0, err
+	}
+	if err := w.cursorRepo.Rewind(ctx, w.chain, ancestor+1); err != nil {
+		return 0, err
+	}
+	return ancestor, nil
+}
+
+// processBlock records the transfers of block that pay into a watched address.
+func (w *PaymentWatcher) processBlock(ctx context.Context, block *domain.ChainBlock, head uint64) error {
+	if len(w.watched) == 0 {
+		return nil
+	}
+
+	addresses := make(map[string]bool, len(w.watched))
+	for address := range w.watched {
+		addresses[address] = true
+	}
+	transfers, err := w.backend.Transfers(ctx, block, addresses)
+	if err != nil {
+		return err
+	}
+
+	for i := range transfers {
+		payment := &transfers[i]
+		order, ok := w.watched[payment.ToAddress]
+		if !ok {
+			continue
+		}
+		payment.OrderID = order.ID
+		payment.Confirmations = head - block.Number + 1
+		payment.DetectedAt = time.Now()
+
+		if err := w.recordPayment(ctx, order, payment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordPayment saves a transfer into the address of order. Transfers of another asset than the quoted
+// one are kept for refunds but do not count towards the order.
+func (w *PaymentWatcher) recordPayment(ctx context.Context, order *domain.Order, payment *domain.Payment) error {
+	if err := w.paymentRepo.Save(ctx, payment); err != nil {
+		return err
+	}
+	w.logger.Info("Recorded payment transaction",
+		zap.String("order_id", string(order.ID)),
+		zap.String("asset", payment.Asset),
+		zap.String("tx_hash", payment.TxHash),
+		zap.String("value", payment.Value.String()),
+	)
+
+	if order.Status == domain.StatusCancelled {
+		w.logger.Warn("Payment arrived after the order was cancelled, the transfer must be refunded",
+			zap.String("order_id", string(order.ID)),
+			zap.String("asset", payment.Asset),
+			zap.String("tx_hash", payment.TxHash),
+			zap.String("value", payment.Value.String()),
+		)
+		return nil
+	}
+
+	if order.PaymentQuote != nil && order.PaymentQuote.Asset != payment.Asset {
+		w.logger.Warn("Order was paid in another asset than quoted, the transfer must be refunded",
+			zap.String("order_id", string(order.ID)),
+			zap.String("quoted_asset", order.PaymentQuote.Asset),
+			zap.String("asset", payment.Asset),
+			zap.String("tx_hash", payment.TxHash),
+		)
+	}
+	return nil
+}
+
+// settleOrders updates every watched order awaiting payment with the confirmed amount it has received.
+// Cancelled orders are only watched to record late transfers and are never settled.
+func (w *PaymentWatcher) settleOrders(ctx context.Context) error {
+	var awaiting []*domain.Order
+	for _, order := range w.watched {
+		if order.Status != domain.StatusCancelled {
+			awaiting = append(awaiting, order)
 		}
+	}
+	if len(awaiting) == 0 {
+		return nil
+	}
 
-		offset += limit
+	ids := make([]domain.OrderID, 0, len(awaiting))
+	for _, order := range awaiting {
+		ids = append(ids, order.ID)
 	}
+	payments, err := w.paymentRepo.FindByOrderIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range awaiting {
+		if len(payments[order.ID]) > 0 {
+			w.settleOrder(ctx, order, payments[order.ID])
+		}
+	}
+	return nil
 }
 
-func (w *PaymentWatcher) checkOrderPayment(order *domain.Order) {
-	if order.PaymentAddress == nil {
+func (w *PaymentWatcher) settleOrder(ctx context.Context, order *domain.Order, payments []domain.Payment) {
+	if order.PaymentQuote == nil {
+		w.logger.Warn("Order awaiting payment has no payment quote", zap.String("order_id", string(order.ID)))
 		return
 	}
 
-	address := common.HexToAddress(*order.PaymentAddress)
-	balance, err := w.ethClient.BalanceAt(w.ctx, address, nil)
+	received, latest := domain.ConfirmedAmount(payments, order.PaymentQuote.Asset, w.confirmations)
+	changed, err := order.RecordPayment(received, domain.ActorPaymentWatcher)
 	if err != nil {
-		w.logger.Error("Failed to get balance for address",
-			zap.String("address", *order.PaymentAddress),
-			zap.Error(err),
-		)
+		w.logger.Error("Cannot record payment", zap.String("order_id", string(order.ID)), zap.Error(err))
+		return
+	}
+	if !changed {
+		return
+	}
+
+	w.logger.Info("Payment detected!",
+		zap.String("order_id", string(order.ID)),
+		zap.String("address", *order.PaymentAddress),
+		zap.String("received", received.String()),
+		zap.String("asset", order.PaymentQuote.Asset),
+		zap.String("expected", order.PaymentQuote.ExpectedAmount.String()),
+		zap.String("status", string(order.Status)),
+	)
+
+	if order.Status == domain.StatusPaid && latest != nil {
+		txHash := latest.TxHash
+		order.TransactionID = &txHash
+	}
+
+	if err := w.orderRepo.Update(ctx, order); err != nil {
+		w.logger.Error("Failed to update order payment", zap.String("order_id", string(order.ID)), zap.Error(err))
 		return
 	}
 
-	if balance.Cmp(big.NewInt(0)) > 0 {
-		w.logger.Info("Payment detected!",
+	if order.Status != domain.StatusPaid {
+		return
+	}
+	w.logger.Info("Order status updated to PAID", zap.String("order_id", string(order.ID)))
+	if address, ok := w.backend.NormalizeAddress(*order.PaymentAddress); ok {
+		delete(w.watched, address)
+	}
+
+	if order.AmountOverpaid != nil {
+		w.logger.Warn("Order was overpaid, the difference must be refunded",
 			zap.String("order_id", string(order.ID)),
-			zap.String("address", *order.PaymentAddress),
-			zap.String("balance", balance.String()),
+			zap.String("overpaid", order.AmountOverpaid.String()),
 		)
+	}
 
-		// TODO: Find the actual transaction hash and save it. This is a more advanced step.
+	w.commitStock(ctx, order)
+}
 
-		order.Status = domain.StatusPaid
-		err := w.orderRepo.Update(w.ctx, order)
-		if err != nil {
-			w.logger.Error("Failed to update order status to PAID", zap.String("order_id", string(order.ID)), zap.Error(err))
-		} else {
-			w.logger.Info("Order status updated to PAID", zap.String("order_id", string(order.ID)))
+// retryStockCommits commits the stock of the orders paid on this chain whose commit failed before.
+func (w *PaymentWatcher) retryStockCommits(ctx context.Context) error {
+	var pending []*domain.Order
+	err := findAllOrders(ctx, w.orderRepo.FindStockCommitPending, func(orders []*domain.Order) {
+		pending = append(pending, orders...)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, order := range pending {
+		if order.PaymentAddress == nil {
+			continue
+		}
+		if _, ok := w.backend.NormalizeAddress(*order.PaymentAddress); ok {
+			w.commitStock(ctx, order)
 		}
 	}
+	return nil
 }
 
-func (w *PaymentWatcher) Close() {
-	w.ethClient.Close()
+// commitStock makes the stock reservation of a paid order final. A failed commit stays pending on the order
+// and is retried on the next sync.
+func (w *PaymentWatcher) commitStock(ctx context.Context, order *domain.Order) {
+	if err := w.catalog.CommitStock(ctx, order.ID); err != nil {
+		w.logger.Error("Failed to commit stock for paid order, retrying on the next sync", zap.String("order_id", string(order.ID)), zap.Error(err))
+		return
+	}
+
+	order.StockCommitPending = false
+	if err := w.orderRepo.Update(ctx, order); err != nil {
+		w.logger.Error("Failed to clear the pending stock commit", zap.String("order_id", string(order.ID)), zap.Error(err))
+	}
 }
@@ -0,0 +1,684 @@
+package worker_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+
+	"order/internal/adapters/chains"
+	"order/internal/adapters/chains/chainstest"
+	"order/internal/application/worker"
+	"order/internal/domain"
+	"pkg/wallet"
+)
+
+var oneEther = big.NewInt(params.Ether)
+
+func ether(tenths int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(tenths), big.NewInt(params.Ether/10))
+}
+
+// testChain is a simulated Ethereum backend with one funded account to pay orders from.
+type testChain struct {
+	backend *simulated.Backend
+	client  simulated.Client
+	key     *ecdsa.PrivateKey
+	from    common.Address
+}
+
+func newTestChain(t *testing.T) *testChain {
+	is := is.New(t)
+	is.Helper()
+
+	key, err := crypto.GenerateKey()
+	is.NoErr(err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		from: {Balance: new(big.Int).Mul(big.NewInt(100), oneEther)},
+	})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	return &testChain{backend: backend, client: backend.Client(), key: key, from: from}
+}
+
+// pay sends value from the funded account to to. The transfer is mined by the next Commit.
+func (c *testChain) pay(t *testing.T, to common.Address, value *big.Int) common.Hash {
+	is := is.New(t)
+	is.Helper()
+	ctx := context.Background()
+
+	chainID, err := c.client.ChainID(ctx)
+	is.NoErr(err)
+	head, err := c.client.HeaderByNumber(ctx, nil)
+	is.NoErr(err)
+	nonce, err := c.client.PendingNonceAt(ctx, c.from)
+	is.NoErr(err)
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), big.NewInt(params.GWei)),
+		Gas:       params.TxGas,
+		To:        &to,
+		Value:     value,
+	})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), c.key)
+	is.NoErr(err)
+	is.NoErr(c.client.SendTransaction(ctx, signed))
+	return signed.Hash()
+}
+
+func newAddress(t *testing.T) common.Address {
+	is := is.New(t)
+	is.Helper()
+	key, err := crypto.GenerateKey()
+	is.NoErr(err)
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+// newAwaitingOrder returns an order waiting for expected wei at address.
+func newAwaitingOrder(t *testing.T, id string, address common.Address, expected *big.Int) *domain.Order {
+	t.Helper()
+	return newAwaitingOrderIn(t, id, domain.AssetETH, address.Hex(), expected)
+}
+
+// newAwaitingOrderIn returns an order waiting for expected of the smallest unit of asset at address.
+func newAwaitingOrderIn(t *testing.T, id, asset, address string, expected *big.Int) *domain.Order {
+	is := is.New(t)
+	is.Helper()
+
+	order, err := domain.NewOrder("user-1", "CRYPTO", nil)
+	is.NoErr(err)
+	is.NoErr(order.TransitionTo(domain.StatusAwaitingPayment, domain.ActorSystem, "crypto payment address assigned"))
+	for i := range order.StatusHistory {
+		order.StatusHistory[i].ID = int64(i + 1)
+	}
+
+	order.ID = domain.OrderID(id)
+	order.PaymentAddress = &address
+	order.PaymentQuote = &domain.PaymentQuote{Asset: asset, ExpectedAmount: expected}
+	return order
+}
+
+type watcherDeps struct {
+	orders   *fakeOrderRepo
+	payments *fakePaymentRepo
+	cursor   *fakeCursorRepo
+	catalog  *fakeCatalog
+}
+
+func newWatcherDeps(orders ...*domain.Order) *watcherDeps {
+	deps := &watcherDeps{
+		orders:   &fakeOrderRepo{orders: make(map[domain.OrderID]*domain.Order)},
+		payments: &fakePaymentRepo{},
+		cursor:   &fakeCursorRepo{blocks: make(map[uint64]domain.BlockRef)},
+		catalog:  &fakeCatalog{},
+	}
+	for _, o := range orders {
+		deps.orders.orders[o.ID] = o
+	}
+	return deps
+}
+
+func (d *watcherDeps) newWatcher(chain *testChain, confirmations uint64) *worker.PaymentWatcher {
+	return d.newWatcherOn(chains.NewEthereumBackend(chain.client, nil, zap.NewNop()), confirmations)
+}
+
+func (d *watcherDeps) newWatcherOn(backend domain.ChainBackend, confirmations uint64) *worker.PaymentWatcher {
+	return worker.NewPaymentWatcher(context.Background(), d.orders, d.payments, d.cursor, d.catalog, backend, confirmations, time.Hour, zap.NewNop())
+}
+
+func syncWatcher(t *testing.T, watcher *worker.PaymentWatcher) {
+	is := is.New(t)
+	is.Helper()
+	is.NoErr(watcher.Sync(context.Background()))
+}
+
+func TestPaymentWatcher_PaysOrderOnceConfirmed(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", address, oneEther))
+	watcher := deps.newWatcher(chain, 3)
+
+	txHash := chain.pay(t, address, oneEther)
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	payments := deps.payments.forOrder("order-1")
+	is.Equal(len(payments), 1)
+	p := payments[0]
+	is.Equal(p.TxHash, txHash.Hex())
+	is.Equal(p.Value.Cmp(oneEther), 0)
+	is.Equal(p.FromAddress, chain.from.Hex())
+	is.Equal(p.Confirmations, uint64(1))
+	is.Equal(deps.orders.get("order-1").Status, domain.StatusAwaitingPayment) // not paid before the confirmations
+
+	chain.backend.Commit()
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	order := deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusPaid)
+	is.True(order.TransactionID != nil)
+	is.Equal(*order.TransactionID, txHash.Hex())
+	is.Equal(order.AmountReceived.Cmp(oneEther), 0)
+	is.True(order.AmountOverpaid == nil)
+	is.Equal(deps.catalog.committed, []domain.OrderID{"order-1"}) // stock committed once paid
+}
+
+func TestPaymentWatcher_WatchesOrdersOnEveryPage(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	// More orders than fit on a page, all created at the same time so only their ids order them.
+	createdAt := time.Now()
+	var orders []*domain.Order
+	for i := 0; i < 250; i++ {
+		order := newAwaitingOrder(t, fmt.Sprintf("order-%03d", i), newAddress(t), oneEther)
+		order.CreatedAt = createdAt
+		orders = append(orders, order)
+	}
+	deps := newWatcherDeps(orders...)
+	watcher := deps.newWatcher(chain, 1)
+
+	last := orders[len(orders)-1]
+	chain.pay(t, common.HexToAddress(*last.PaymentAddress), oneEther)
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	is.Equal(deps.orders.get(last.ID).Status, domain.StatusPaid) // found on the last page
+}
+
+func TestPaymentWatcher_RecordsPartialAndOverpayments(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", address, oneEther))
+	watcher := deps.newWatcher(chain, 1)
+
+	chain.pay(t, address, ether(4))
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	order := deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusPartiallyPaid)
+	is.Equal(order.AmountReceived.Cmp(ether(4)), 0)
+
+	chain.pay(t, address, ether(7))
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	order = deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusPaid)
+	is.Equal(order.AmountReceived.Cmp(ether(11)), 0)
+	is.True(order.AmountOverpaid != nil)
+	is.Equal(order.AmountOverpaid.Cmp(ether(1)), 0)
+}
+
+func TestPaymentWatcher_IgnoresUnwatchedAddresses(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", newAddress(t), oneEther))
+	watcher := deps.newWatcher(chain, 1)
+
+	chain.pay(t, newAddress(t), oneEther)
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.payments.all()), 0)
+	is.Equal(deps.orders.get("order-1").Status, domain.StatusAwaitingPayment)
+}
+
+func TestPaymentWatcher_DropsPaymentsOfReorganisedBlocks(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", address, oneEther))
+	watcher := deps.newWatcher(chain, 2)
+
+	genesis, err := chain.client.HeaderByNumber(ctx, nil)
+	is.NoErr(err)
+
+	chain.pay(t, address, oneEther)
+	orphaned := chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	payments := deps.payments.forOrder("order-1")
+	is.Equal(len(payments), 1)
+	is.Equal(payments[0].BlockHash, orphaned.Hex())
+
+	// Replace block 1 with a longer side chain.
+	is.NoErr(chain.backend.Fork(genesis.Hash()))
+	chain.backend.Commit()
+	chain.backend.Commit()
+	head := chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+
+	// The transfer may or may not be mined again on the new chain, but whatever is recorded must be on it.
+	for _, p := range deps.payments.all() {
+		header, err := chain.client.HeaderByNumber(ctx, new(big.Int).SetUint64(p.BlockNumber))
+		is.NoErr(err)
+		is.True(p.BlockHash != orphaned.Hex())     // payment left in the orphaned block
+		is.Equal(p.BlockHash, header.Hash().Hex()) // payment recorded off the canonical chain
+	}
+	last, err := deps.cursor.Last(ctx, domain.ChainEthereum)
+	is.NoErr(err)
+	is.True(last != nil)
+	is.Equal(last.Hash, head.Hex())
+	block1, err := deps.cursor.Find(ctx, domain.ChainEthereum, 1)
+	is.NoErr(err)
+	is.True(block1 != nil)
+	is.True(block1.Hash != orphaned.Hex()) // block 1 is the side chain block
+}
+
+func TestPaymentWatcher_ResumesFromLastProcessedBlock(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", address, oneEther))
+	syncWatcher(t, deps.newWatcher(chain, 1))
+
+	// The payment is mined while no watcher is running and buried before the next one starts.
+	chain.pay(t, address, oneEther)
+	chain.backend.Commit()
+	chain.backend.Commit()
+	chain.backend.Commit()
+
+	syncWatcher(t, deps.newWatcher(chain, 1))
+	payments := deps.payments.forOrder("order-1")
+	is.Equal(len(payments), 1)
+	is.Equal(payments[0].BlockNumber, uint64(1))
+	is.Equal(deps.orders.get("order-1").Status, domain.StatusPaid)
+}
+
+// testMnemonic is the development mnemonic of config.yaml.
+const testMnemonic = "future guard belt volume list slim final where call topple vote brush"
+
+// newRegtestAddress derives the BIP84 regtest address at index, the way checkout does for BTC orders.
+func newRegtestAddress(t *testing.T, index int) string {
+	is := is.New(t)
+	is.Helper()
+
+	walletService, err := wallet.NewService(testMnemonic)
+	is.NoErr(err)
+	address, err := walletService.DeriveBitcoinAddress(fmt.Sprintf("m/84'/1'/0'/0/%d", index), wallet.BitcoinRegtest)
+	is.NoErr(err)
+	return address
+}
+
+func TestPaymentWatcher_PaysBitcoinOrderOnRegtest(t *testing.T) {
+	is := is.New(t)
+	regtest := chainstest.NewRegtest()
+
+	address := newRegtestAddress(t, 0)
+	other := newRegtestAddress(t, 1)
+	deps := newWatcherDeps(newAwaitingOrderIn(t, "order-1", domain.AssetBTC, address, big.NewInt(100_000_000)))
+	watcher := deps.newWatcherOn(regtest, 3)
+
+	// One transaction with change, the other paying the rest in two outputs.
+	regtest.Pay("bcrt1qsender", chainstest.Output{Address: address, Value: 40_000_000}, chainstest.Output{Address: other, Value: 5_000})
+	txID := regtest.Pay("bcrt1qsender", chainstest.Output{Address: address, Value: 30_000_000}, chainstest.Output{Address: address, Value: 30_000_000})
+	regtest.Mine(1)
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.payments.forOrder("order-1")), 3)                       // one payment per output into the address
+	is.Equal(deps.orders.get("order-1").Status, domain.StatusAwaitingPayment) // not paid before the confirmations
+
+	regtest.Mine(2)
+
+	syncWatcher(t, watcher)
+	order := deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusPaid)
+	is.Equal(order.AmountReceived.Cmp(big.NewInt(100_000_000)), 0)
+	is.True(order.AmountOverpaid == nil)
+	is.True(order.TransactionID != nil)
+	is.Equal(*order.TransactionID, txID)
+}
+
+func TestPaymentWatcher_DropsBitcoinPaymentsOfReorganisedBlocks(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	regtest := chainstest.NewRegtest()
+
+	address := newRegtestAddress(t, 0)
+	deps := newWatcherDeps(newAwaitingOrderIn(t, "order-1", domain.AssetBTC, address, big.NewInt(100_000_000)))
+	watcher := deps.newWatcherOn(regtest, 2)
+
+	regtest.Pay("bcrt1qsender", chainstest.Output{Address: address, Value: 100_000_000})
+	regtest.Mine(1)
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.payments.forOrder("order-1")), 1)
+
+	// The payment is double spent on a longer chain replacing its block.
+	regtest.Reorg(1, false)
+	head := regtest.Mine(2)
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.payments.all()), 0)
+	is.Equal(deps.orders.get("order-1").Status, domain.StatusAwaitingPayment)
+	last, err := deps.cursor.Last(ctx, domain.ChainBitcoin)
+	is.NoErr(err)
+	is.True(last != nil)
+	is.Equal(last.Hash, head)
+}
+
+func TestPaymentWatcher_LeavesOrdersOfOtherChains(t *testing.T) {
+	is := is.New(t)
+	regtest := chainstest.NewRegtest()
+
+	ethAddress := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", ethAddress, oneEther))
+	watcher := deps.newWatcherOn(regtest, 1)
+
+	regtest.Pay("bcrt1qsender", chainstest.Output{Address: newRegtestAddress(t, 0), Value: 100_000_000})
+	regtest.Mine(1)
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.payments.all()), 0)
+}
+
+func TestPaymentWatcher_RetriesFailedStockCommits(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	deps := newWatcherDeps(newAwaitingOrder(t, "order-1", address, oneEther))
+	deps.catalog.failures = 1
+	watcher := deps.newWatcher(chain, 1)
+
+	chain.pay(t, address, oneEther)
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	order := deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusPaid)
+	is.True(order.StockCommitPending)        // the failed commit is left pending
+	is.Equal(len(deps.catalog.committed), 0) // nothing committed yet
+
+	syncWatcher(t, watcher)
+	is.True(!deps.orders.get("order-1").StockCommitPending) // the retry clears the flag
+	is.Equal(deps.catalog.committed, []domain.OrderID{"order-1"})
+
+	syncWatcher(t, watcher)
+	is.Equal(len(deps.catalog.committed), 1) // committed once
+}
+
+func TestPaymentWatcher_RecordsLatePaymentsOfCancelledOrders(t *testing.T) {
+	is := is.New(t)
+	chain := newTestChain(t)
+
+	address := newAddress(t)
+	order := newAwaitingOrder(t, "order-1", address, oneEther)
+	is.NoErr(order.TransitionTo(domain.StatusCancelled, domain.ActorSystem, "payment window elapsed"))
+	deps := newWatcherDeps(order)
+	watcher := deps.newWatcher(chain, 1)
+
+	txHash := chain.pay(t, address, oneEther)
+	chain.backend.Commit()
+
+	syncWatcher(t, watcher)
+	payments := deps.payments.forOrder("order-1")
+	is.Equal(len(payments), 1)
+	is.Equal(payments[0].TxHash, txHash.Hex()) // the late transfer is recorded
+	order = deps.orders.get("order-1")
+	is.Equal(order.Status, domain.StatusCancelled)
+	is.True(order.AmountReceived == nil)
+	is.Equal(len(deps.catalog.committed), 0)
+}
+
+// fakeOrderRepo keeps orders in memory; only the methods the watcher uses are implemented.
+type fakeOrderRepo struct {
+	domain.OrderRepository
+	mu     sync.Mutex
+	orders map[domain.OrderID]*domain.Order
+}
+
+func (r *fakeOrderRepo) get(id domain.OrderID) *domain.Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.orders[id]
+}
+
+func (r *fakeOrderRepo) FindAwaitingPayment(_ context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orders []*domain.Order
+	for _, o := range r.orders {
+		if o.Status == domain.StatusAwaitingPayment || o.Status == domain.StatusPartiallyPaid {
+			orders = append(orders, o)
+		}
+	}
+	return keysetPage(orders, after, limit), nil
+}
+
+func (r *fakeOrderRepo) FindStockCommitPending(_ context.Context, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orders []*domain.Order
+	for _, o := range r.orders {
+		if o.Status == domain.StatusPaid && o.StockCommitPending {
+			orders = append(orders, o)
+		}
+	}
+	return keysetPage(orders, after, limit), nil
+}
+
+func (r *fakeOrderRepo) FindCancelledSince(_ context.Context, since time.Time, after *domain.OrderCursor, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orders []*domain.Order
+	for _, o := range r.orders {
+		if o.Status != domain.StatusCancelled || o.PaymentAddress == nil {
+			continue
+		}
+		if last := o.StatusHistory[len(o.StatusHistory)-1]; !last.ChangedAt.Before(since) {
+			orders = append(orders, o)
+		}
+	}
+	return keysetPage(orders, after, limit), nil
+}
+
+// keysetPage sorts orders oldest first and returns up to limit of them that come after the cursor.
+func keysetPage(orders []*domain.Order, after *domain.OrderCursor, limit int) []*domain.Order {
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+
+	page := make([]*domain.Order, 0, limit)
+	for _, o := range orders {
+		if after != nil && (o.CreatedAt.Before(after.CreatedAt) || (o.CreatedAt.Equal(after.CreatedAt) && o.ID <= after.ID)) {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, o)
+	}
+	return page
+}
+
+func (r *fakeOrderRepo) Update(_ context.Context, o *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range o.StatusHistory {
+		if o.StatusHistory[i].ID == 0 {
+			o.StatusHistory[i].ID = int64(i + 1)
+		}
+	}
+	r.orders[o.ID] = o
+	return nil
+}
+
+type fakePaymentRepo struct {
+	mu       sync.Mutex
+	payments []domain.Payment
+}
+
+func (r *fakePaymentRepo) all() []domain.Payment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.Payment(nil), r.payments...)
+}
+
+func (r *fakePaymentRepo) forOrder(id domain.OrderID) []domain.Payment {
+	var payments []domain.Payment
+	for _, p := range r.all() {
+		if p.OrderID == id {
+			payments = append(payments, p)
+		}
+	}
+	return payments
+}
+
+func (r *fakePaymentRepo) Save(_ context.Context, payment *domain.Payment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, p := range r.payments {
+		if p.TxHash == payment.TxHash && p.ToAddress == payment.ToAddress && p.LogIndex == payment.LogIndex {
+			r.payments[i].BlockNumber = payment.BlockNumber
+			r.payments[i].BlockHash = payment.BlockHash
+			r.payments[i].Confirmations = payment.Confirmations
+			payment.ID = p.ID
+			return nil
+		}
+	}
+	payment.ID = int64(len(r.payments) + 1)
+	r.payments = append(r.payments, *payment)
+	return nil
+}
+
+func (r *fakePaymentRepo) FindByOrderIDs(_ context.Context, ids []domain.OrderID) (map[domain.OrderID][]domain.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payments := make(map[domain.OrderID][]domain.Payment)
+	for _, id := range ids {
+		for _, p := range r.payments {
+			if p.OrderID == id {
+				payments[id] = append(payments[id], p)
+			}
+		}
+	}
+	return payments, nil
+}
+
+func (r *fakePaymentRepo) RefreshConfirmations(_ context.Context, chain string, head, upTo uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, p := range r.payments {
+		if p.Chain == chain && p.Confirmations < upTo && head >= p.BlockNumber {
+			r.payments[i].Confirmations = head - p.BlockNumber + 1
+		}
+	}
+	return nil
+}
+
+func (r *fakePaymentRepo) DeleteFromBlock(_ context.Context, chain string, number uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.payments[:0]
+	for _, p := range r.payments {
+		if p.Chain != chain || p.BlockNumber < number {
+			kept = append(kept, p)
+		}
+	}
+	r.payments = kept
+	return nil
+}
+
+type fakeCursorRepo struct {
+	mu     sync.Mutex
+	blocks map[uint64]domain.BlockRef
+}
+
+func (r *fakeCursorRepo) Last(_ context.Context, _ string) (*domain.BlockRef, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var last *domain.BlockRef
+	for _, b := range r.blocks {
+		if last == nil || b.Number > last.Number {
+			b := b
+			last = &b
+		}
+	}
+	return last, nil
+}
+
+func (r *fakeCursorRepo) Find(_ context.Context, _ string, number uint64) (*domain.BlockRef, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if b, ok := r.blocks[number]; ok {
+		return &b, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeCursorRepo) Save(_ context.Context, _ string, block domain.BlockRef) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocks[block.Number] = block
+	return nil
+}
+
+func (r *fakeCursorRepo) Rewind(_ context.Context, _ string, number uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n := range r.blocks {
+		if n >= number {
+			delete(r.blocks, n)
+		}
+	}
+	return nil
+}
+
+type fakeCatalog struct {
+	domain.ProductCatalog
+	committed []domain.OrderID
+	failures  int // Number of CommitStock calls still to fail
+}
+
+func (c *fakeCatalog) CommitStock(_ context.Context, orderID domain.OrderID) error {
+	if c.failures > 0 {
+		c.failures--
+		return fmt.Errorf("catalog unavailable")
+	}
+	c.committed = append(c.committed, orderID)
+	return nil
+}
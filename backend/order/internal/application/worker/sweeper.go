@@ -0,0 +1,401 @@
+package worker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"order/internal/domain"
+	"pkg/wallet"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// sweepBatchSize is the number of orders swept per run.
+const sweepBatchSize = 50
+
+// transferGas is the gas of a plain ETH transfer.
+const transferGas = 21000
+
+// droppedTxTimeout is how long a sent transaction may stay unknown to the node before it is taken as
+// dropped, e.g. evicted from the mempool, and sent again.
+const droppedTxTimeout = 30 * time.Minute
+
+// sweepRetryBackoff is how long an address whose sweep found nothing to move or reverted is left alone before
+// it is swept again, e.g. after a late transfer arrived or the gas price dropped.
+const sweepRetryBackoff = 6 * time.Hour
+
+var (
+	balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+	transferSelector  = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+)
+
+// SweepClient is the part of an Ethereum client the sweeper uses. *ethclient.Client implements it.
+type SweepClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// Sweeper periodically moves the ETH and tokens received on the payment addresses of paid orders to
+// the treasury. The keys are derived from the wallet with the path the address was derived from.
+// Token sweeps need ETH for gas on the payment address; it is topped up from the gas tank, an address
+// of the same wallet, and the tokens follow in a later run once the top-up arrived. In dry-run mode the
+// sweeps are only planned and recorded, nothing is signed or sent.
+type Sweeper struct {
+	sweepRepo     domain.SweepRepository
+	walletService *wallet.Service
+	client        SweepClient
+	treasury      common.Address
+	gasTankPath   string
+	dryRun        bool
+	interval      time.Duration
+	signer        types.Signer
+	logger        *zap.Logger
+}
+
+func NewSweeper(
+	sweepRepo domain.SweepRepository,
+	walletService *wallet.Service,
+	client SweepClient,
+	treasury string,
+	gasTankPath string,
+	interval time.Duration,
+	dryRun bool,
+	logger *zap.Logger,
+) (*Sweeper, error) {
+	if !common.IsHexAddress(treasury) {
+		return nil, fmt.Errorf("invalid treasury address %q", treasury)
+	}
+
+	return &Sweeper{
+		sweepRepo:     sweepRepo,
+		walletService: walletService,
+		client:        client,
+		treasury:      common.HexToAddress(treasury),
+		gasTankPath:   gasTankPath,
+		dryRun:        dryRun,
+		interval:      interval,
+		logger:        logger.Named("sweeper"),
+	}, nil
+}
+
+func (w *Sweeper) Start(ctx context.Context) {
+	w.logger.Info("Sweeper started...", zap.String("treasury", w.treasury.Hex()), zap.Bool("dry_run", w.dryRun))
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Run(ctx); err != nil {
+				w.logger.Error("Failed to sweep payment addresses", zap.Error(err))
+			}
+		case <-ctx.Done():
+			w.logger.Info("Sweeper shutting down.")
+			return
+		}
+	}
+}
+
+// Run settles the submitted sweeps and sweeps the orders that are not swept yet. A failing order is
+// recorded with its error and retried in the next run.
+func (w *Sweeper) Run(ctx context.Context) error {
+	if w.signer == nil {
+		chainID, err := w.client.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		w.signer = types.LatestSignerForChainID(chainID)
+	}
+
+	if err := w.checkSubmitted(ctx); err != nil {
+		return err
+	}
+
+	orders, err := w.sweepRepo.FindOrdersToSweep(ctx, time.Now().Add(-sweepRetryBackoff), sweepBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		sweep, err := w.sweepOrder(ctx, order)
+		if err == nil {
+			continue
+		}
+		w.logger.Error("Failed to sweep order", zap.String("order_id", string(order.ID)), zap.Error(err))
+		if sweep == nil {
+			continue
+		}
+		sweep.Error = err.Error()
+		if err := w.sweepRepo.Save(ctx, sweep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSubmitted marks the submitted sweeps whose transaction was mined as confirmed or failed. A sweep
+// whose transaction was dropped goes back to pending, so this run sends it again.
+func (w *Sweeper) checkSubmitted(ctx context.Context) error {
+	sweeps, err := w.sweepRepo.FindByStatus(ctx, domain.ChainEthereum, domain.SweepSubmitted)
+	if err != nil {
+		return err
+	}
+
+	for _, sweep := range sweeps {
+		receipt, err := w.client.TransactionReceipt(ctx, common.HexToHash(*sweep.TxHash))
+		if errors.Is(err, ethereum.NotFound) {
+			dropped, err := w.dropped(ctx, *sweep.TxHash, sweep.UpdatedAt)
+			if err != nil {
+				return err
+			}
+			if !dropped {
+				continue
+			}
+			w.logger.Warn("Sweep transaction was dropped, sending it again", zap.String("address", sweep.Address), zap.String("asset", sweep.Asset), zap.String("tx_hash", *sweep.TxHash))
+			sweep.Status = domain.SweepPending
+			sweep.Error = "transaction " + *sweep.TxHash + " was dropped"
+			sweep.TxHash = nil
+			if err := w.sweepRepo.Save(ctx, sweep); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			sweep.Status = domain.SweepConfirmed
+			w.logger.Info("Sweep confirmed", zap.String("address", sweep.Address), zap.String("asset", sweep.Asset), zap.String("tx_hash", *sweep.TxHash))
+		} else {
+			sweep.Status = domain.SweepFailed
+			sweep.Error = "sweep transaction reverted"
+			w.logger.Error("Sweep transaction reverted", zap.String("address", sweep.Address), zap.String("asset", sweep.Asset), zap.String("tx_hash", *sweep.TxHash))
+		}
+		if err := w.sweepRepo.Save(ctx, sweep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sweepOrder sweeps the quoted asset of order. It returns the sweep it worked on, also on error.
+func (w *Sweeper) sweepOrder(ctx context.Context, order *domain.Order) (*domain.Sweep, error) {
+	if order.PaymentQuote == nil || order.DerivationIndex == nil || order.PaymentAddress == nil {
+		return nil, fmt.Errorf("order %s has no payment address to sweep", order.ID)
+	}
+	address := common.HexToAddress(*order.PaymentAddress)
+
+	sweep, err := w.sweepRepo.Find(ctx, domain.ChainEthereum, address.Hex(), order.PaymentQuote.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if sweep == nil {
+		sweep = &domain.Sweep{
+			OrderID: order.ID,
+			Chain:   domain.ChainEthereum,
+			Asset:   order.PaymentQuote.Asset,
+			Address: address.Hex(),
+			Status:  domain.SweepPending,
+		}
+	}
+	sweep.Treasury = w.treasury.Hex()
+	sweep.Error = ""
+
+	// The same path as the payment address, see Service.CreateOrderFromCart.
+	key, err := w.walletService.DerivePrivateKey(wallet.EthereumPaymentPath(*order.DerivationIndex))
+	if err != nil {
+		return sweep, err
+	}
+	if crypto.PubkeyToAddress(key.PublicKey) != address {
+		return sweep, fmt.Errorf("derived key does not match payment address %s", address.Hex())
+	}
+
+	gasPrice, err := w.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return sweep, err
+	}
+
+	if order.PaymentToken == nil {
+		err = w.sweepETH(ctx, sweep, key, gasPrice)
+	} else {
+		err = w.sweepToken(ctx, sweep, order.PaymentToken, key, gasPrice)
+	}
+	return sweep, err
+}
+
+// sweepETH moves the whole balance minus the fee of the transfer.
+func (w *Sweeper) sweepETH(ctx context.Context, sweep *domain.Sweep, key *ecdsa.PrivateKey, gasPrice *big.Int) error {
+	address := common.HexToAddress(sweep.Address)
+	balance, err := w.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(transferGas))
+	amount := new(big.Int).Sub(balance, fee)
+	if amount.Sign() <= 0 {
+		w.logger.Info("Nothing to sweep", zap.String("address", sweep.Address), zap.String("balance", balance.String()), zap.String("fee", fee.String()))
+		sweep.Status = domain.SweepEmpty
+		return w.sweepRepo.Save(ctx, sweep)
+	}
+	sweep.Amount = amount
+
+	if w.dryRun {
+		return w.planned(ctx, sweep, "would sweep ETH")
+	}
+
+	txHash, err := w.send(ctx, key, w.treasury, amount, transferGas, gasPrice, nil)
+	if err != nil {
+		return err
+	}
+	return w.submitted(ctx, sweep, txHash)
+}
+
+// sweepToken moves the whole token balance, first topping up the gas of the payment address if needed.
+func (w *Sweeper) sweepToken(ctx context.Context, sweep *domain.Sweep, token *domain.Token, key *ecdsa.PrivateKey, gasPrice *big.Int) error {
+	address := common.HexToAddress(sweep.Address)
+	contract := common.HexToAddress(token.Contract)
+
+	result, err := w.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(address.Bytes(), 32)...)}, nil)
+	if err != nil {
+		return err
+	}
+	amount := new(big.Int).SetBytes(result)
+	if amount.Sign() == 0 {
+		w.logger.Info("Nothing to sweep", zap.String("address", sweep.Address), zap.String("asset", sweep.Asset))
+		sweep.Status = domain.SweepEmpty
+		return w.sweepRepo.Save(ctx, sweep)
+	}
+	sweep.Amount = amount
+
+	data := append(append([]byte{}, transferSelector...), common.LeftPadBytes(w.treasury.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	gas, err := w.client.EstimateGas(ctx, ethereum.CallMsg{From: address, To: &contract, Data: data})
+	if err != nil {
+		return err
+	}
+
+	balance, err := w.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return err
+	}
+	if fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)); balance.Cmp(fee) < 0 {
+		return w.topUpGas(ctx, sweep, new(big.Int).Sub(fee, balance), gasPrice)
+	}
+
+	if w.dryRun {
+		return w.planned(ctx, sweep, "would sweep tokens")
+	}
+
+	txHash, err := w.send(ctx, key, contract, new(big.Int), gas, gasPrice, data)
+	if err != nil {
+		return err
+	}
+	return w.submitted(ctx, sweep, txHash)
+}
+
+// topUpGas sends missing wei from the gas tank to the payment address, unless a top-up is still pending.
+func (w *Sweeper) topUpGas(ctx context.Context, sweep *domain.Sweep, missing, gasPrice *big.Int) error {
+	if sweep.Status == domain.SweepFundingGas && sweep.GasTopUpTxHash != nil {
+		_, err := w.client.TransactionReceipt(ctx, common.HexToHash(*sweep.GasTopUpTxHash))
+		if errors.Is(err, ethereum.NotFound) {
+			dropped, err := w.dropped(ctx, *sweep.GasTopUpTxHash, sweep.UpdatedAt)
+			if err != nil {
+				return err
+			}
+			if !dropped {
+				w.logger.Info("Waiting for gas top-up", zap.String("address", sweep.Address), zap.String("tx_hash", *sweep.GasTopUpTxHash))
+				return nil
+			}
+			w.logger.Warn("Gas top-up was dropped, topping up again", zap.String("address", sweep.Address), zap.String("tx_hash", *sweep.GasTopUpTxHash))
+		} else if err != nil {
+			return err
+		}
+		// Mined but not enough any more, e.g. because the gas price went up, or dropped: top up again.
+	}
+
+	if w.dryRun {
+		return w.planned(ctx, sweep, "would top up gas of "+missing.String()+" wei before sweeping tokens")
+	}
+
+	gasTank, err := w.walletService.DerivePrivateKey(w.gasTankPath)
+	if err != nil {
+		return err
+	}
+	txHash, err := w.send(ctx, gasTank, common.HexToAddress(sweep.Address), missing, transferGas, gasPrice, nil)
+	if err != nil {
+		return fmt.Errorf("failed to top up gas from %s: %w", crypto.PubkeyToAddress(gasTank.PublicKey).Hex(), err)
+	}
+
+	w.logger.Info("Topped up gas for token sweep", zap.String("address", sweep.Address), zap.String("amount", missing.String()), zap.String("tx_hash", txHash))
+	sweep.Status = domain.SweepFundingGas
+	sweep.GasTopUpTxHash = &txHash
+	return w.sweepRepo.Save(ctx, sweep)
+}
+
+// dropped reports whether the transaction sent at sentAt was dropped: it is not mined after droppedTxTimeout
+// and the node does not know it any more. A transaction still waiting in the mempool is left alone.
+func (w *Sweeper) dropped(ctx context.Context, txHash string, sentAt time.Time) (bool, error) {
+	if time.Since(sentAt) < droppedTxTimeout {
+		return false, nil
+	}
+
+	_, _, err := w.client.TransactionByHash(ctx, common.HexToHash(txHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (w *Sweeper) planned(ctx context.Context, sweep *domain.Sweep, action string) error {
+	w.logger.Info("Dry run: "+action, zap.String("address", sweep.Address), zap.String("asset", sweep.Asset), zap.String("amount", sweep.Amount.String()))
+	sweep.Status = domain.SweepDryRun
+	return w.sweepRepo.Save(ctx, sweep)
+}
+
+func (w *Sweeper) submitted(ctx context.Context, sweep *domain.Sweep, txHash string) error {
+	w.logger.Info("Submitted sweep", zap.String("address", sweep.Address), zap.String("asset", sweep.Asset), zap.String("amount", sweep.Amount.String()), zap.String("tx_hash", txHash))
+	sweep.Status = domain.SweepSubmitted
+	sweep.TxHash = &txHash
+	return w.sweepRepo.Save(ctx, sweep)
+}
+
+// send signs a legacy transaction from the address of key and submits it, returning its hash.
+func (w *Sweeper) send(ctx context.Context, key *ecdsa.PrivateKey, to common.Address, value *big.Int, gas uint64, gasPrice *big.Int, data []byte) (string, error) {
+	nonce, err := w.client.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	if err != nil {
+		return "", err
+	}
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		To:       &to,
+		Value:    value,
+		Data:     data,
+	})
+	signed, err := types.SignTx(tx, w.signer, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	if err := w.client.SendTransaction(ctx, signed); err != nil {
+		return "", err
+	}
+	return signed.Hash().Hex(), nil
+}
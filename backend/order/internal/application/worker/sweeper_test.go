@@ -0,0 +1,393 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+
+	"order/internal/application/worker"
+	"order/internal/domain"
+	"pkg/wallet"
+)
+
+const (
+	// sweepMnemonic is the mnemonic of the Hardhat and Anvil development accounts.
+	sweepMnemonic = "test test test test test test test test test test test junk"
+	gasTankPath   = "m/44'/60'/1'/0/0"
+	// sweepIndex is the derivation index of the payment address of the swept order.
+	sweepIndex = 7
+)
+
+// sweepEnv is a simulated chain with a paid order whose payment address holds funds, and a funded gas tank.
+type sweepEnv struct {
+	backend  *simulated.Backend
+	client   simulated.Client
+	wallet   *wallet.Service
+	sweeps   *fakeSweepRepo
+	order    *domain.Order
+	address  common.Address
+	treasury common.Address
+}
+
+// newSweepEnv funds the payment address with balance wei. The order is paid in token when it is set,
+// in ETH otherwise.
+func newSweepEnv(t *testing.T, balance *big.Int, token *domain.Token) *sweepEnv {
+	is := is.New(t)
+	is.Helper()
+
+	walletService, err := wallet.NewService(sweepMnemonic)
+	is.NoErr(err)
+	paymentAddress, err := walletService.DeriveAddress(wallet.EthereumPaymentPath(sweepIndex))
+	is.NoErr(err)
+	gasTank, err := walletService.DerivePrivateKey(gasTankPath)
+	is.NoErr(err)
+
+	address := common.HexToAddress(paymentAddress)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		address: {Balance: balance},
+		crypto.PubkeyToAddress(gasTank.PublicKey): {Balance: new(big.Int).Mul(big.NewInt(10), oneEther)},
+	})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	index := int64(sweepIndex)
+	quote := &domain.PaymentQuote{Asset: domain.AssetETH, ExpectedAmount: oneEther}
+	if token != nil {
+		quote.Asset = token.Symbol
+	}
+	order := &domain.Order{
+		ID:              "order-1",
+		Status:          domain.StatusPaid,
+		PaymentAddress:  &paymentAddress,
+		DerivationIndex: &index,
+		PaymentQuote:    quote,
+		PaymentToken:    token,
+	}
+
+	return &sweepEnv{
+		backend:  backend,
+		client:   backend.Client(),
+		wallet:   walletService,
+		sweeps:   &fakeSweepRepo{orders: []*domain.Order{order}, sweeps: make(map[string]*domain.Sweep)},
+		order:    order,
+		address:  address,
+		treasury: newAddress(t),
+	}
+}
+
+func (e *sweepEnv) newSweeper(t *testing.T, client worker.SweepClient, dryRun bool) *worker.Sweeper {
+	is := is.New(t)
+	is.Helper()
+	sweeper, err := worker.NewSweeper(e.sweeps, e.wallet, client, e.treasury.Hex(), gasTankPath, time.Minute, dryRun, zap.NewNop())
+	is.NoErr(err)
+	return sweeper
+}
+
+func (e *sweepEnv) balance(t *testing.T, address common.Address) *big.Int {
+	is := is.New(t)
+	is.Helper()
+	balance, err := e.client.BalanceAt(context.Background(), address, nil)
+	is.NoErr(err)
+	return balance
+}
+
+func (e *sweepEnv) gasPrice(t *testing.T) *big.Int {
+	is := is.New(t)
+	is.Helper()
+	gasPrice, err := e.client.SuggestGasPrice(context.Background())
+	is.NoErr(err)
+	return gasPrice
+}
+
+// waitForTxIndex mines a block and waits until the node reports unknown transactions as not found instead
+// of still being indexed.
+func (e *sweepEnv) waitForTxIndex(t *testing.T) {
+	is := is.New(t)
+	is.Helper()
+	e.backend.Commit()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		_, err := e.client.TransactionReceipt(context.Background(), common.Hash{})
+		if errors.Is(err, ethereum.NotFound) {
+			return
+		}
+		is.True(time.Now().Before(deadline)) // transactions are not indexed
+	}
+}
+
+func runSweeper(t *testing.T, sweeper *worker.Sweeper) {
+	is := is.New(t)
+	is.Helper()
+	is.NoErr(sweeper.Run(context.Background()))
+}
+
+// tokenClient fakes an ERC-20 contract on top of the simulated chain: balanceOf answers balance and
+// transfers are estimated at gas.
+type tokenClient struct {
+	simulated.Client
+	balance *big.Int
+	gas     uint64
+}
+
+func (c *tokenClient) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return common.LeftPadBytes(c.balance.Bytes(), 32), nil
+}
+
+func (c *tokenClient) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return c.gas, nil
+}
+
+var testToken = &domain.Token{Symbol: "USDC", Contract: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6}
+
+func TestSweeper_DryRunOnlyRecordsTheETHSweep(t *testing.T) {
+	is := is.New(t)
+	env := newSweepEnv(t, oneEther, nil)
+	sweeper := env.newSweeper(t, env.client, true)
+
+	fee := new(big.Int).Mul(env.gasPrice(t), big.NewInt(21000))
+	runSweeper(t, sweeper)
+	env.backend.Commit()
+
+	sweep := env.sweeps.get(env.address, domain.AssetETH)
+	is.True(sweep != nil)
+	is.Equal(sweep.Status, domain.SweepDryRun)
+	is.Equal(sweep.Amount.Cmp(new(big.Int).Sub(oneEther, fee)), 0) // the balance minus the fee
+	is.True(sweep.TxHash == nil)                                   // nothing sent
+	is.Equal(env.balance(t, env.treasury).Sign(), 0)
+}
+
+func TestSweeper_SweepsETHToTheTreasury(t *testing.T) {
+	is := is.New(t)
+	env := newSweepEnv(t, oneEther, nil)
+	sweeper := env.newSweeper(t, env.client, false)
+
+	runSweeper(t, sweeper)
+	sweep := env.sweeps.get(env.address, domain.AssetETH)
+	is.True(sweep != nil)
+	is.Equal(sweep.Status, domain.SweepSubmitted)
+	is.True(sweep.TxHash != nil)
+
+	env.backend.Commit()
+	runSweeper(t, sweeper)
+
+	sweep = env.sweeps.get(env.address, domain.AssetETH)
+	is.Equal(sweep.Status, domain.SweepConfirmed)
+	is.Equal(env.balance(t, env.treasury).Cmp(sweep.Amount), 0)
+	is.Equal(env.balance(t, env.address).Sign(), 0) // the payment address is emptied
+}
+
+func TestSweeper_TopsUpTheMissingGasBeforeSweepingTokens(t *testing.T) {
+	is := is.New(t)
+	const gas = 60000
+	env := newSweepEnv(t, big.NewInt(1000), testToken)
+	client := &tokenClient{Client: env.client, balance: big.NewInt(250_000_000), gas: gas}
+	sweeper := env.newSweeper(t, client, false)
+
+	fee := new(big.Int).Mul(env.gasPrice(t), big.NewInt(gas))
+	runSweeper(t, sweeper)
+
+	sweep := env.sweeps.get(env.address, testToken.Symbol)
+	is.True(sweep != nil)
+	is.Equal(sweep.Status, domain.SweepFundingGas)
+	is.True(sweep.GasTopUpTxHash != nil)
+
+	// The top-up covers what the address misses for the fee, on top of the 1000 wei it holds.
+	env.backend.Commit()
+	is.Equal(env.balance(t, env.address).Cmp(fee), 0)
+
+	runSweeper(t, sweeper)
+	sweep = env.sweeps.get(env.address, testToken.Symbol)
+	is.Equal(sweep.Status, domain.SweepSubmitted)
+	is.Equal(sweep.Amount.Cmp(client.balance), 0)
+
+	env.backend.Commit()
+	runSweeper(t, sweeper)
+	is.Equal(env.sweeps.get(env.address, testToken.Symbol).Status, domain.SweepConfirmed)
+}
+
+func TestSweeper_DryRunSendsNoGasTopUp(t *testing.T) {
+	is := is.New(t)
+	env := newSweepEnv(t, big.NewInt(0), testToken)
+	client := &tokenClient{Client: env.client, balance: big.NewInt(250_000_000), gas: 60000}
+	sweeper := env.newSweeper(t, client, true)
+
+	runSweeper(t, sweeper)
+	env.backend.Commit()
+
+	sweep := env.sweeps.get(env.address, testToken.Symbol)
+	is.True(sweep != nil)
+	is.Equal(sweep.Status, domain.SweepDryRun)
+	is.True(sweep.GasTopUpTxHash == nil)            // no top-up
+	is.Equal(env.balance(t, env.address).Sign(), 0) // no gas sent
+}
+
+func TestSweeper_ResendsDroppedSweeps(t *testing.T) {
+	is := is.New(t)
+	env := newSweepEnv(t, oneEther, nil)
+	sweeper := env.newSweeper(t, env.client, false)
+	env.waitForTxIndex(t)
+
+	// A sweep whose transaction the node never mined and does not know.
+	dropped := common.HexToHash("0x01").Hex()
+	env.sweeps.put(&domain.Sweep{
+		OrderID:   env.order.ID,
+		Chain:     domain.ChainEthereum,
+		Asset:     domain.AssetETH,
+		Address:   env.address.Hex(),
+		Amount:    oneEther,
+		TxHash:    &dropped,
+		Status:    domain.SweepSubmitted,
+		UpdatedAt: time.Now(),
+	})
+
+	runSweeper(t, sweeper)
+	sweep := env.sweeps.get(env.address, domain.AssetETH)
+	is.Equal(sweep.Status, domain.SweepSubmitted)
+	is.Equal(*sweep.TxHash, dropped) // still waiting for the dropped transaction
+
+	env.sweeps.age(env.address, domain.AssetETH, time.Hour)
+	runSweeper(t, sweeper)
+	sweep = env.sweeps.get(env.address, domain.AssetETH)
+	is.Equal(sweep.Status, domain.SweepSubmitted)
+	is.True(sweep.TxHash != nil)
+	is.True(*sweep.TxHash != dropped) // sent again
+
+	env.backend.Commit()
+	runSweeper(t, sweeper)
+	is.Equal(env.sweeps.get(env.address, domain.AssetETH).Status, domain.SweepConfirmed)
+}
+
+func TestSweeper_RetriesEmptyAndFailedSweepsAfterABackOff(t *testing.T) {
+	for _, status := range []domain.SweepStatus{domain.SweepEmpty, domain.SweepFailed} {
+		t.Run(string(status), func(t *testing.T) {
+			is := is.New(t)
+			env := newSweepEnv(t, oneEther, nil)
+			sweeper := env.newSweeper(t, env.client, false)
+
+			// The funds arrived after the last sweep of the address, or its transaction reverted.
+			env.sweeps.put(&domain.Sweep{
+				OrderID:   env.order.ID,
+				Chain:     domain.ChainEthereum,
+				Asset:     domain.AssetETH,
+				Address:   env.address.Hex(),
+				Status:    status,
+				UpdatedAt: time.Now(),
+			})
+
+			runSweeper(t, sweeper)
+			is.Equal(env.sweeps.get(env.address, domain.AssetETH).Status, status) // left alone within the back-off
+
+			env.sweeps.age(env.address, domain.AssetETH, 7*time.Hour)
+			runSweeper(t, sweeper)
+			is.Equal(env.sweeps.get(env.address, domain.AssetETH).Status, domain.SweepSubmitted) // swept again
+
+			env.backend.Commit()
+			runSweeper(t, sweeper)
+			is.Equal(env.sweeps.get(env.address, domain.AssetETH).Status, domain.SweepConfirmed)
+			is.True(env.balance(t, env.treasury).Sign() > 0)
+		})
+	}
+}
+
+// fakeSweepRepo keeps sweeps in memory and sweeps orders until they have a sweep underway or done,
+// retrying empty and failed ones after the back-off.
+type fakeSweepRepo struct {
+	domain.SweepRepository
+	mu     sync.Mutex
+	orders []*domain.Order
+	sweeps map[string]*domain.Sweep // By address and asset
+}
+
+func sweepKey(address, asset string) string {
+	return address + "/" + asset
+}
+
+func (r *fakeSweepRepo) get(address common.Address, asset string) *domain.Sweep {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sweeps[sweepKey(address.Hex(), asset)]
+}
+
+// put stores sweep as is, keeping its UpdatedAt.
+func (r *fakeSweepRepo) put(sweep *domain.Sweep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweeps[sweepKey(sweep.Address, sweep.Asset)] = sweep
+}
+
+// age moves the last update of a sweep back by d.
+func (r *fakeSweepRepo) age(address common.Address, asset string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sweep := r.sweeps[sweepKey(address.Hex(), asset)]
+	sweep.UpdatedAt = sweep.UpdatedAt.Add(-d)
+}
+
+func (r *fakeSweepRepo) Save(_ context.Context, sweep *domain.Sweep) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sweep.UpdatedAt = time.Now()
+	saved := *sweep
+	r.sweeps[sweepKey(sweep.Address, sweep.Asset)] = &saved
+	return nil
+}
+
+func (r *fakeSweepRepo) Find(_ context.Context, _, address, asset string) (*domain.Sweep, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sweep, ok := r.sweeps[sweepKey(address, asset)]; ok {
+		found := *sweep
+		return &found, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeSweepRepo) FindByStatus(_ context.Context, _ string, status domain.SweepStatus) ([]*domain.Sweep, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sweeps []*domain.Sweep
+	for _, sweep := range r.sweeps {
+		if sweep.Status == status {
+			found := *sweep
+			sweeps = append(sweeps, &found)
+		}
+	}
+	return sweeps, nil
+}
+
+func (r *fakeSweepRepo) FindOrdersToSweep(_ context.Context, retryBefore time.Time, limit int) ([]*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var orders []*domain.Order
+	for _, order := range r.orders {
+		sweep, ok := r.sweeps[sweepKey(*order.PaymentAddress, order.PaymentQuote.Asset)]
+		if ok {
+			switch sweep.Status {
+			case domain.SweepSubmitted, domain.SweepConfirmed:
+				continue
+			case domain.SweepFailed, domain.SweepEmpty:
+				if !sweep.UpdatedAt.Before(retryBefore) {
+					continue
+				}
+			}
+		}
+		orders = append(orders, order)
+	}
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders, nil
+}
@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	pb "api/proto/order/v1"
+	"context"
+	"errors"
+	"order/internal/domain"
+	"pkg/contextkeys"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *GRPCServer) ApplyCoupon(ctx context.Context, req *pb.ApplyCouponRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received ApplyCoupon request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("code", req.Code))
+
+	cart, err := s.service.ApplyCoupon(ctx, owner, req.Code)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyCart) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
+		return nil, toCouponStatusError(err, "failed to apply coupon")
+	}
+
+	return toPBCart(cart), nil
+}
+
+func (s *GRPCServer) RemoveCoupon(ctx context.Context, req *pb.RemoveCouponRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received RemoveCoupon request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+
+	cart, err := s.service.RemoveCoupon(ctx, owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove coupon")
+	}
+
+	return toPBCart(cart), nil
+}
+
+func (s *GRPCServer) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.Coupon, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received CreateCoupon request", zap.String("user_id", userID))
+
+	if !s.isAdmin(userID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can create coupons")
+	}
+	if req.Coupon == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "coupon is required")
+	}
+
+	coupon, err := s.service.CreateCoupon(ctx, toDomainCoupon(req.Coupon))
+	if err != nil {
+		return nil, toCouponStatusError(err, "failed to create coupon")
+	}
+
+	return toPBCoupon(coupon), nil
+}
+
+func (s *GRPCServer) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received ListCoupons request", zap.String("user_id", userID))
+
+	if !s.isAdmin(userID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can list coupons")
+	}
+
+	coupons, err := s.service.ListCoupons(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list coupons")
+	}
+
+	res := &pb.ListCouponsResponse{Coupons: make([]*pb.Coupon, len(coupons))}
+	for i, coupon := range coupons {
+		res.Coupons[i] = toPBCoupon(coupon)
+	}
+
+	return res, nil
+}
+
+// toCouponStatusError maps coupon domain errors to gRPC codes, falling back to Internal with msg.
+func toCouponStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrCouponNotFound):
+		return status.Errorf(codes.NotFound, "%s", err.Error())
+	case errors.Is(err, domain.ErrInvalidCoupon):
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	case errors.Is(err, domain.ErrCouponAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "%s", err.Error())
+	case domain.IsCouponRejection(err):
+		return status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+	}
+	return status.Errorf(codes.Internal, "%s", msg)
+}
+
+func toDomainCoupon(c *pb.Coupon) *domain.Coupon {
+	coupon := &domain.Coupon{
+		Code:        c.Code,
+		Type:        domain.CouponType(c.Type),
+		Value:       c.Value,
+		Amount:      toDomainMoney(c.Amount),
+		MinBasket:   toDomainMoney(c.MinBasket),
+		ProductIDs:  c.ProductIds,
+		CategoryIDs: c.CategoryIds,
+		ValidFrom:   toTimePtr(c.ValidFrom),
+		ValidUntil:  toTimePtr(c.ValidUntil),
+	}
+	if c.MaxUses != nil {
+		maxUses := int(*c.MaxUses)
+		coupon.MaxUses = &maxUses
+	}
+	if c.MaxUsesPerUser != nil {
+		maxUsesPerUser := int(*c.MaxUsesPerUser)
+		coupon.MaxUsesPerUser = &maxUsesPerUser
+	}
+	return coupon
+}
+
+func toPBCoupon(c *domain.Coupon) *pb.Coupon {
+	coupon := &pb.Coupon{
+		Id:          c.ID,
+		Code:        c.Code,
+		Type:        string(c.Type),
+		Value:       c.Value,
+		Amount:      toPBMoney(c.Amount),
+		MinBasket:   toPBMoney(c.MinBasket),
+		UsedCount:   int32(c.UsedCount),
+		ProductIds:  c.ProductIDs,
+		CategoryIds: c.CategoryIDs,
+		Active:      c.Active,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+	}
+	if c.MaxUses != nil {
+		maxUses := int32(*c.MaxUses)
+		coupon.MaxUses = &maxUses
+	}
+	if c.MaxUsesPerUser != nil {
+		maxUsesPerUser := int32(*c.MaxUsesPerUser)
+		coupon.MaxUsesPerUser = &maxUsesPerUser
+	}
+	if c.ValidFrom != nil {
+		coupon.ValidFrom = timestamppb.New(*c.ValidFrom)
+	}
+	if c.ValidUntil != nil {
+		coupon.ValidUntil = timestamppb.New(*c.ValidUntil)
+	}
+	return coupon
+}
+
+func toPBDiscounts(discounts []domain.DiscountLine) []*pb.DiscountLine {
+	pbDiscounts := make([]*pb.DiscountLine, len(discounts))
+	for i, d := range discounts {
+		pbDiscounts[i] = &pb.DiscountLine{
+			CouponCode:  d.CouponCode,
+			Type:        string(d.Type),
+			Description: d.Description,
+			Amount:      toPBMoney(d.Amount),
+		}
+	}
+	return pbDiscounts
+}
+
+func toTimePtr(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -1,103 +1,574 @@
 package grpc
 
 import (
-	pb "black-shop/api/proto/v1"
-	"black-shop/internal/domain/order"
-	"black-shop/pkg/contextkeys"
+	pb "api/proto/order/v1"
 	"context"
 	"errors"
+	"fmt"
+	"order/internal/application/services"
+	"order/internal/domain"
+	"pkg/contextkeys"
+	"pkg/money"
+
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// guestCartTokenMetadataKey carries the guest cart token; the REST gateway maps the X-Cart-Token header onto it.
+const guestCartTokenMetadataKey = "x-cart-token"
+
+// GuestMethods can be called without signing in, on a guest cart token.
+var GuestMethods = []string{
+	pb.OrderService_CreateGuestCart_FullMethodName,
+	pb.OrderService_AddItemToCart_FullMethodName,
+	pb.OrderService_GetCart_FullMethodName,
+	pb.OrderService_UpdateCartItemQuantity_FullMethodName,
+	pb.OrderService_RemoveCartItem_FullMethodName,
+	pb.OrderService_ClearCart_FullMethodName,
+	pb.OrderService_ApplyCoupon_FullMethodName,
+	pb.OrderService_RemoveCoupon_FullMethodName,
+}
+
+// ServiceMethods can only be called by other services, with the internal service key.
+var ServiceMethods = []string{
+	pb.OrderService_HasPurchasedProduct_FullMethodName,
+}
+
 type GRPCServer struct {
 	pb.UnimplementedOrderServiceServer
-	service *Service
-	logger  *zap.Logger
+	service  services.OrderService
+	adminIDs map[string]struct{}
+	logger   *zap.Logger
 }
 
-func NewGRPCServer(server *Service, logger *zap.Logger) *GRPCServer {
+func NewGRPCServer(service services.OrderService, adminUserIDs []string, logger *zap.Logger) *GRPCServer {
+	adminIDs := make(map[string]struct{}, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		adminIDs[id] = struct{}{}
+	}
+
 	return &GRPCServer{
-		service: server,
-		logger:  logger.Named("order_grpc_handler"),
+		service:  service,
+		adminIDs: adminIDs,
+		logger:   logger.Named("order_grpc_handler"),
+	}
+}
+
+func (s *GRPCServer) CreateGuestCart(ctx context.Context, req *pb.CreateGuestCartRequest) (*pb.CreateGuestCartResponse, error) {
+	s.logger.Info("received CreateGuestCart request")
+
+	token, err := s.service.CreateGuestCart(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create guest cart")
 	}
+
+	return &pb.CreateGuestCartResponse{GuestToken: token}, nil
 }
 
 func (s *GRPCServer) AddItemToCart(ctx context.Context, req *pb.AddItemToCartRequest) (*pb.Cart, error) {
-	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	owner, err := s.cartOwner(ctx)
 	if err != nil {
 		return nil, err
 	}
-	s.logger.Info("received AddItemToCart request", zap.String("user_id", userID), zap.String("product_id", req.ProductId))
+	s.logger.Info("received AddItemToCart request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", req.ProductId))
 
-	item := order.CartItem{
+	item := domain.CartItem{
 		ProductID: req.ProductId,
+		VariantID: req.VariantId,
 		Quantity:  int(req.Quantity),
 	}
 
-	cart, err := s.service.AddItemToCart(ctx, userID, item)
+	cart, err := s.service.AddItemToCart(ctx, owner, item)
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuantity):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case errors.Is(err, domain.ErrProductUnavailable):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add item to cart")
 	}
 
-	pbItems := make([]*pb.CartItem, len(cart.Items))
-	for i, item := range cart.Items {
-		pbItems[i] = &pb.CartItem{ProductId: item.ProductID, Quantity: int32(item.Quantity)}
+	return toPBCart(cart), nil
+}
+
+func (s *GRPCServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received GetCart request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+
+	cart, err := s.service.GetCart(ctx, owner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get cart")
 	}
 
-	return &pb.Cart{UserId: cart.UserID, Items: pbItems}, nil
+	return toPBCart(cart), nil
 }
 
-func (s *GRPCServer) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, error) {
-	userID, err := contextkeys.GetUserIDFromContext(ctx)
+func (s *GRPCServer) UpdateCartItemQuantity(ctx context.Context, req *pb.UpdateCartItemQuantityRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received UpdateCartItemQuantity request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", req.ProductId))
+
+	if req.ProductId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product id cannot be empty")
+	}
+
+	cart, err := s.service.UpdateCartItemQuantity(ctx, owner, req.ProductId, req.VariantId, int(req.Quantity))
+	if err != nil {
+		return nil, toCartStatusError(err, "failed to update cart item")
+	}
+
+	return toPBCart(cart), nil
+}
+
+func (s *GRPCServer) RemoveCartItem(ctx context.Context, req *pb.RemoveCartItemRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
 	if err != nil {
 		return nil, err
 	}
-	s.logger.Info("received GetCart request", zap.String("user_id", userID))
+	s.logger.Info("received RemoveCartItem request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()), zap.String("product_id", req.ProductId))
+
+	if req.ProductId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product id cannot be empty")
+	}
 
-	cart, err := s.service.GetCart(ctx, userID)
+	cart, err := s.service.RemoveCartItem(ctx, owner, req.ProductId, req.VariantId)
 	if err != nil {
-		if errors.Is(err, order.ErrCartNotFound) {
-			return &pb.Cart{UserId: userID, Items: []*pb.CartItem{}}, nil
+		return nil, toCartStatusError(err, "failed to remove cart item")
+	}
+
+	return toPBCart(cart), nil
+}
+
+func (s *GRPCServer) ClearCart(ctx context.Context, req *pb.ClearCartRequest) (*pb.Cart, error) {
+	owner, err := s.cartOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received ClearCart request", zap.String("user_id", owner.UserID), zap.Bool("guest", owner.IsGuest()))
+
+	if err := s.service.ClearCart(ctx, owner); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to clear cart")
+	}
+
+	return toPBCart(&domain.PricedCart{UserID: owner.UserID, GuestToken: owner.GuestToken}), nil
+}
+
+func (s *GRPCServer) MergeCart(ctx context.Context, req *pb.MergeCartRequest) (*pb.Cart, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user is not authenticated")
+	}
+	s.logger.Info("received MergeCart request", zap.String("user_id", userID))
+
+	cart, err := s.service.MergeCart(ctx, userID, req.GuestToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGuestCartToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get cart")
+		return nil, status.Errorf(codes.Internal, "failed to merge cart")
 	}
 
-	pbItems := make([]*pb.CartItem, len(cart.Items))
-	for i, item := range cart.Items {
-		pbItems[i] = &pb.CartItem{ProductId: item.ProductID, Quantity: int32(item.Quantity)}
+	return toPBCart(cart), nil
+}
+
+// cartOwner resolves whose cart a request addresses: the signed-in user if the request carries a token,
+// otherwise the guest cart named by the x-cart-token metadata. A signed-in request that still carries a
+// guest cart token, as the first one after login does, has the guest cart merged into the user's first.
+func (s *GRPCServer) cartOwner(ctx context.Context) (domain.CartOwner, error) {
+	if userID, err := contextkeys.GetUserIDFromContext(ctx); err == nil {
+		s.mergeGuestCart(ctx, userID)
+		return domain.UserCart(userID), nil
+	}
+
+	token := guestCartToken(ctx)
+	if token == "" {
+		return domain.CartOwner{}, status.Errorf(codes.Unauthenticated, "authorization token or guest cart token is required")
+	}
+
+	if err := domain.ValidateGuestCartToken(token); err != nil {
+		return domain.CartOwner{}, status.Errorf(codes.Unauthenticated, "%s", err.Error())
 	}
 
-	return &pb.Cart{UserId: cart.UserID, Items: pbItems}, nil
+	return domain.GuestCart(token), nil
 }
 
-func (s *GRPCServer) CreateOrderFromCart(ctx context.Context, req *pb.CreateOrderFromCartRequest) (*pb.Order, error) {
+// mergeGuestCart moves the guest cart named by the x-cart-token metadata, if any, into the user's cart.
+// A failed merge is only logged: the guest cart is kept and merged on the user's next cart request.
+func (s *GRPCServer) mergeGuestCart(ctx context.Context, userID string) {
+	token := guestCartToken(ctx)
+	if token == "" {
+		return
+	}
+
+	if _, err := s.service.MergeGuestCart(ctx, userID, token); err != nil {
+		s.logger.Warn("failed to merge guest cart on a signed-in request", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+func guestCartToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get(guestCartTokenMetadataKey); len(tokens) > 0 {
+		return tokens[0]
+	}
+	return ""
+}
+
+// toCartStatusError maps cart domain errors to gRPC codes, falling back to Internal with msg.
+func toCartStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidQuantity):
+		return status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	case errors.Is(err, domain.ErrCartNotFound), errors.Is(err, domain.ErrCartItemNotFound):
+		return status.Errorf(codes.NotFound, "%s", domain.ErrCartItemNotFound.Error())
+	}
+	return status.Errorf(codes.Internal, "%s", msg)
+}
+
+func (s *GRPCServer) CreateOrderFromCart(ctx context.Context, req *pb.CreateOrderFromCartRequest) (*pb.CreateOrderFromCartResponse, error) {
 	userID, err := contextkeys.GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 	s.logger.Info("received CreateOrderFromCart request", zap.String("user_id", userID))
+	s.mergeGuestCart(ctx, userID)
 
-	newOrder, err := s.service.CreateOrderFromCart(ctx, userID)
+	newOrder, err := s.service.CreateOrderFromCart(ctx, userID, req.PaymentMethod, req.PaymentAsset)
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrCartNotFound), errors.Is(err, domain.ErrEmptyCart):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", domain.ErrEmptyCart.Error())
+		case errors.Is(err, domain.ErrProductUnavailable), errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrNothingToPay):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err.Error())
+		case domain.IsCouponRejection(err):
+			return nil, toCouponStatusError(err, "failed to create order")
+		case errors.Is(err, domain.ErrUnsupportedAsset), errors.Is(err, domain.ErrUnsupportedPayment):
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		case errors.Is(err, domain.ErrRateUnavailable):
+			return nil, status.Errorf(codes.Unavailable, "%s", err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create order")
 	}
 
-	pbOrderItems := make([]*pb.OrderItem, len(newOrder.Items))
-	for i, item := range newOrder.Items {
+	return &pb.CreateOrderFromCartResponse{Order: toPBOrder(newOrder)}, nil
+}
+
+func (s *GRPCServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received GetOrder request", zap.String("user_id", userID), zap.String("order_id", req.OrderId))
+
+	if req.OrderId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order id cannot be empty")
+	}
+
+	order, err := s.service.GetOrder(ctx, userID, domain.OrderID(req.OrderId))
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil, status.Errorf(codes.NotFound, "order with id '%s' not found", req.OrderId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order")
+	}
+
+	return toPBOrder(order), nil
+}
+
+func (s *GRPCServer) ListMyOrders(ctx context.Context, req *pb.ListMyOrdersRequest) (*pb.ListOrdersResponse, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received ListMyOrders request", zap.String("user_id", userID))
+
+	filter, err := toOrderFilter(req.PageSize, req.Cursor, req.Statuses, req.CreatedFrom, req.CreatedTo)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	}
+
+	page, err := s.service.ListMyOrders(ctx, userID, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list orders")
+	}
+
+	return toPBListOrdersResponse(page), nil
+}
+
+func (s *GRPCServer) SearchOrders(ctx context.Context, req *pb.SearchOrdersRequest) (*pb.ListOrdersResponse, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received SearchOrders request", zap.String("user_id", userID))
+
+	if !s.isAdmin(userID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only admins can search orders")
+	}
+
+	filter, err := toOrderFilter(req.PageSize, req.Cursor, req.Statuses, req.CreatedFrom, req.CreatedTo)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	}
+	filter.UserID = req.UserId
+
+	page, err := s.service.SearchOrders(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search orders")
+	}
+
+	return toPBListOrdersResponse(page), nil
+}
+
+func (s *GRPCServer) GetOrderStatusHistory(ctx context.Context, req *pb.GetOrderStatusHistoryRequest) (*pb.GetOrderStatusHistoryResponse, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received GetOrderStatusHistory request", zap.String("user_id", userID), zap.String("order_id", req.OrderId))
+
+	if req.OrderId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order id cannot be empty")
+	}
+
+	history, err := s.service.GetOrderStatusHistory(ctx, userID, domain.OrderID(req.OrderId))
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return nil, status.Errorf(codes.NotFound, "order with id '%s' not found", req.OrderId)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get order status history")
+	}
+
+	return &pb.GetOrderStatusHistoryResponse{History: toPBStatusHistory(history)}, nil
+}
+
+func (s *GRPCServer) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	userID, err := contextkeys.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("received CancelOrder request", zap.String("user_id", userID), zap.String("order_id", req.OrderId))
+
+	if req.OrderId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "order id cannot be empty")
+	}
+
+	var order *domain.Order
+	if s.isAdmin(userID) {
+		order, err = s.service.AdminCancelOrder(ctx, userID, domain.OrderID(req.OrderId), req.Reason)
+	} else {
+		order, err = s.service.CancelOrder(ctx, userID, domain.OrderID(req.OrderId), req.Reason)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrderNotFound):
+			return nil, status.Errorf(codes.NotFound, "order with id '%s' not found", req.OrderId)
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			return nil, status.Errorf(codes.FailedPrecondition, "order can no longer be cancelled")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel order")
+	}
+
+	return toPBOrder(order), nil
+}
+
+// HasPurchasedProduct is reserved for other services (see ServiceMethods), which name the user in the request.
+func (s *GRPCServer) HasPurchasedProduct(ctx context.Context, req *pb.HasPurchasedProductRequest) (*pb.HasPurchasedProductResponse, error) {
+	s.logger.Info("received HasPurchasedProduct request", zap.String("user_id", req.UserId), zap.String("product_id", req.ProductId))
+
+	if req.UserId == "" || req.ProductId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user id and product id cannot be empty")
+	}
+
+	purchased, err := s.service.HasPurchasedProduct(ctx, req.UserId, req.ProductId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check product purchase")
+	}
+
+	return &pb.HasPurchasedProductResponse{Purchased: purchased}, nil
+}
+
+func (s *GRPCServer) isAdmin(userID string) bool {
+	_, ok := s.adminIDs[userID]
+	return ok
+}
+
+func toOrderFilter(pageSize int32, cursor string, statuses []string, createdFrom, createdTo *timestamppb.Timestamp) (domain.OrderFilter, error) {
+	filter := domain.OrderFilter{Limit: int(pageSize)}
+
+	if cursor != "" {
+		after, err := domain.DecodeOrderCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = after
+	}
+
+	for _, st := range statuses {
+		orderStatus := domain.OrderStatus(st)
+		if !orderStatus.IsValid() {
+			return filter, fmt.Errorf("unknown order status '%s'", st)
+		}
+		filter.Statuses = append(filter.Statuses, orderStatus)
+	}
+
+	if createdFrom != nil {
+		t := createdFrom.AsTime()
+		filter.CreatedFrom = &t
+	}
+	if createdTo != nil {
+		t := createdTo.AsTime()
+		filter.CreatedTo = &t
+	}
+
+	return filter, nil
+}
+
+func toPBListOrdersResponse(page *domain.OrderPage) *pb.ListOrdersResponse {
+	pbOrders := make([]*pb.Order, len(page.Orders))
+	for i, o := range page.Orders {
+		pbOrders[i] = toPBOrder(o)
+	}
+
+	res := &pb.ListOrdersResponse{Orders: pbOrders}
+	if page.NextCursor != nil {
+		res.NextCursor = domain.EncodeOrderCursor(*page.NextCursor)
+	}
+
+	return res
+}
+
+func toPBCart(cart *domain.PricedCart) *pb.Cart {
+	pbItems := make([]*pb.CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		pbItems[i] = &pb.CartItem{
+			ProductId:    item.ProductID,
+			VariantId:    item.VariantID,
+			Quantity:     int32(item.Quantity),
+			Sku:          item.SKU,
+			Name:         item.Name,
+			ImageUrl:     item.ImageURL,
+			UnitPrice:    toPBMoney(item.UnitPrice),
+			LineTotal:    toPBMoney(item.LineTotal),
+			AddedPrice:   toPBMoney(item.AddedPrice),
+			Unavailable:  item.Unavailable,
+			OutOfStock:   item.OutOfStock,
+			PriceChanged: item.PriceChanged,
+		}
+	}
+
+	return &pb.Cart{
+		UserId:        cart.UserID,
+		GuestToken:    cart.GuestToken,
+		Items:         pbItems,
+		Subtotal:      toPBMoney(cart.Subtotal),
+		CouponCode:    cart.CouponCode,
+		Discounts:     toPBDiscounts(cart.Discounts),
+		ShippingFee:   toPBMoney(cart.ShippingFee),
+		DiscountTotal: toPBMoney(cart.DiscountTotal),
+		Total:         toPBMoney(cart.Total),
+		CouponError:   cart.CouponError,
+	}
+}
+
+func toPBOrder(o *domain.Order) *pb.Order {
+	pbOrderItems := make([]*pb.OrderItem, len(o.Items))
+	for i, item := range o.Items {
 		pbOrderItems[i] = &pb.OrderItem{
 			ProductId: item.ProductID,
+			VariantId: item.VariantID,
+			Sku:       item.SKU,
+			Name:      item.Name,
 			Quantity:  int32(item.Quantity),
-			Price:     item.Price,
+			Price:     toPBMoney(item.Price),
+		}
+	}
+
+	pbOrder := &pb.Order{
+		Id:             string(o.ID),
+		UserId:         o.UserID,
+		TotalPrice:     toPBMoney(o.TotalPrice),
+		Status:         string(o.Status),
+		Items:          pbOrderItems,
+		StatusHistory:  toPBStatusHistory(o.StatusHistory),
+		CreatedAt:      timestamppb.New(o.CreatedAt),
+		PaymentMethod:  o.PaymentMethod,
+		PaymentAddress: o.PaymentAddress,
+		TransactionId:  o.TransactionID,
+		ShippingFee:    toPBMoney(o.ShippingFee),
+		Discounts:      toPBDiscounts(o.Discounts),
+	}
+	if q := o.PaymentQuote; q != nil {
+		pbOrder.PaymentQuote = &pb.PaymentQuote{
+			Asset:          q.Asset,
+			Rate:           toPBMoney(q.Rate),
+			ExpectedAmount: q.ExpectedAmount.String(),
+			QuotedAt:       timestamppb.New(q.QuotedAt),
+		}
+	}
+	if t := o.PaymentToken; t != nil {
+		pbOrder.PaymentToken = &pb.Token{
+			Symbol:   t.Symbol,
+			Contract: t.Contract,
+			Decimals: int32(t.Decimals),
+		}
+	}
+	if o.AmountReceived != nil {
+		pbOrder.AmountReceived = o.AmountReceived.String()
+	}
+	if o.AmountOverpaid != nil {
+		pbOrder.AmountOverpaid = o.AmountOverpaid.String()
+	}
+	for _, p := range o.Payments {
+		pbOrder.Payments = append(pbOrder.Payments, &pb.Payment{
+			Asset:         p.Asset,
+			TxHash:        p.TxHash,
+			BlockNumber:   p.BlockNumber,
+			FromAddress:   p.FromAddress,
+			ToAddress:     p.ToAddress,
+			Value:         p.Value.String(),
+			Confirmations: p.Confirmations,
+			DetectedAt:    timestamppb.New(p.DetectedAt),
+		})
+	}
+
+	return pbOrder
+}
+
+// toPBMoney maps an amount to the API, leaving it unset when it is unknown.
+func toPBMoney(m money.Money) *pb.Money {
+	if m == (money.Money{}) {
+		return nil
+	}
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func toDomainMoney(m *pb.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
+
+func toPBStatusHistory(history []domain.StatusChange) []*pb.OrderStatusChange {
+	pbHistory := make([]*pb.OrderStatusChange, len(history))
+	for i, change := range history {
+		pbChange := &pb.OrderStatusChange{
+			ToStatus:  string(change.ToStatus),
+			ChangedBy: change.ChangedBy,
+			Reason:    change.Reason,
+			ChangedAt: timestamppb.New(change.ChangedAt),
+		}
+		if change.FromStatus != nil {
+			pbChange.FromStatus = string(*change.FromStatus)
 		}
+		pbHistory[i] = pbChange
 	}
 
-	return &pb.Order{
-		Id:         string(newOrder.ID),
-		UserId:     newOrder.UserID,
-		TotalPrice: newOrder.TotalPrice,
-		Status:     string(newOrder.Status),
-		Items:      pbOrderItems,
-	}, nil
+	return pbHistory
 }
@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	pb "api/proto/order/v1"
+	"context"
+	"errors"
+	"order/internal/application/services"
+	"order/internal/domain"
+	"pkg/contextkeys"
+	"testing"
+
+	"github.com/matryer/is"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+func newTestServer(service *fakeOrderService) *GRPCServer {
+	return NewGRPCServer(service, nil, zap.NewNop())
+}
+
+func signedIn(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, contextkeys.UserIDKey, userID)
+}
+
+func withGuestCart(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(guestCartTokenMetadataKey, token))
+}
+
+func TestGRPCServer_MergesTheGuestCartOnTheFirstSignedInRequest(t *testing.T) {
+	is := is.New(t)
+	service := &fakeOrderService{}
+	server := newTestServer(service)
+
+	token, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+
+	cart, err := server.GetCart(withGuestCart(signedIn(context.Background(), "user-1"), token), &pb.GetCartRequest{})
+	is.NoErr(err)
+	is.Equal(cart.UserId, "user-1") // the user's cart is returned
+	is.Equal(service.merged, []string{"user-1 " + token})
+	is.Equal(service.pricedFor, []domain.CartOwner{domain.UserCart("user-1")})
+}
+
+func TestGRPCServer_MergesTheGuestCartBeforeCheckout(t *testing.T) {
+	is := is.New(t)
+	service := &fakeOrderService{}
+	server := newTestServer(service)
+
+	token, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+
+	_, err = server.CreateOrderFromCart(withGuestCart(signedIn(context.Background(), "user-1"), token), &pb.CreateOrderFromCartRequest{PaymentMethod: "card"})
+	is.NoErr(err)
+	is.Equal(service.merged, []string{"user-1 " + token})
+}
+
+func TestGRPCServer_LeavesCartsAloneWithoutBothTokens(t *testing.T) {
+	is := is.New(t)
+	service := &fakeOrderService{}
+	server := newTestServer(service)
+
+	token, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+
+	_, err = server.GetCart(signedIn(context.Background(), "user-1"), &pb.GetCartRequest{})
+	is.NoErr(err)
+	_, err = server.GetCart(withGuestCart(context.Background(), token), &pb.GetCartRequest{})
+	is.NoErr(err)
+
+	is.Equal(len(service.merged), 0)
+	is.Equal(service.pricedFor, []domain.CartOwner{domain.UserCart("user-1"), domain.GuestCart(token)})
+}
+
+func TestGRPCServer_FailedMergeStillServesTheUserCart(t *testing.T) {
+	is := is.New(t)
+	service := &fakeOrderService{mergeErr: errors.New("redis unavailable")}
+	server := newTestServer(service)
+
+	token, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+
+	cart, err := server.GetCart(withGuestCart(signedIn(context.Background(), "user-1"), token), &pb.GetCartRequest{})
+	is.NoErr(err)
+	is.Equal(cart.UserId, "user-1")
+}
+
+// fakeOrderService records the carts it merges and prices.
+type fakeOrderService struct {
+	services.OrderService
+	merged    []string // "<userID> <guestToken>"
+	mergeErr  error
+	pricedFor []domain.CartOwner
+}
+
+func (s *fakeOrderService) MergeGuestCart(_ context.Context, userID, guestToken string) (int, error) {
+	if s.mergeErr != nil {
+		return 0, s.mergeErr
+	}
+	s.merged = append(s.merged, userID+" "+guestToken)
+	return 1, nil
+}
+
+func (s *fakeOrderService) GetCart(_ context.Context, owner domain.CartOwner) (*domain.PricedCart, error) {
+	s.pricedFor = append(s.pricedFor, owner)
+	return domain.NewPricedCart(&domain.Cart{UserID: owner.UserID, GuestToken: owner.GuestToken}, nil, "USD"), nil
+}
+
+func (s *fakeOrderService) CreateOrderFromCart(_ context.Context, userID, paymentMethod, _ string) (*domain.Order, error) {
+	return &domain.Order{ID: "order-1", UserID: userID, PaymentMethod: paymentMethod, Status: domain.StatusPending}, nil
+}
@@ -1,15 +1,164 @@
 package domain
 
-import "errors"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"pkg/money"
+)
 
-var ErrCartNotFound = errors.New("cart not found")
+// MaxCartItemQuantity caps the quantity of a single cart line.
+const MaxCartItemQuantity = 99
+
+// guestCartTokenBytes is the amount of randomness behind a guest cart token.
+const guestCartTokenBytes = 16
 
 type CartItem struct {
-	ProductID string
-	Quantity  int
+	ProductID  string
+	VariantID  string
+	Quantity   int
+	AddedPrice money.Money // Unit price when the line was last added; zero if unknown
 }
 
 type Cart struct {
-	UserID string
-	Items  []CartItem
+	UserID     string
+	GuestToken string
+	Items      []CartItem
+	CouponCode string
+}
+
+// PricedCartItem is a cart line enriched with its current catalog data.
+type PricedCartItem struct {
+	CartItem
+	SKU        string
+	Name       string
+	ImageURL   string
+	CategoryID string
+	UnitPrice  money.Money
+	LineTotal  money.Money
+	// Unavailable is set when the product no longer exists, is unpublished or is not priced in the shop currency.
+	Unavailable bool
+	// OutOfStock is set when the catalog cannot cover the requested quantity.
+	OutOfStock bool
+	// PriceChanged is set when the unit price differs from the one seen when the line was added.
+	PriceChanged bool
+}
+
+// PricedCart is the cart as shown to the shopper, in a single currency. Subtotal only counts lines that can be ordered.
+type PricedCart struct {
+	UserID        string
+	GuestToken    string
+	Items         []PricedCartItem
+	Subtotal      money.Money
+	ShippingFee   money.Money
+	CouponCode    string
+	CouponError   string // Why the applied coupon currently grants nothing; empty when it applies
+	Discounts     []DiscountLine
+	DiscountTotal money.Money
+	Total         money.Money
+}
+
+// NewPricedCart combines a cart with the catalog prices of its items, given in the same order.
+// Lines priced in another currency than currency cannot be ordered and are marked unavailable.
+func NewPricedCart(cart *Cart, prices []ProductPrice, currency string) *PricedCart {
+	priced := &PricedCart{
+		UserID:        cart.UserID,
+		GuestToken:    cart.GuestToken,
+		CouponCode:    cart.CouponCode,
+		Items:         make([]PricedCartItem, len(cart.Items)),
+		Subtotal:      money.Zero(currency),
+		ShippingFee:   money.Zero(currency),
+		DiscountTotal: money.Zero(currency),
+		Total:         money.Zero(currency),
+	}
+
+	quantities := VariantQuantities(cart.Items, prices)
+	for i, item := range cart.Items {
+		price := prices[i]
+		line := PricedCartItem{CartItem: item}
+
+		if !price.Found || !price.Published || price.UnitPrice.Currency != currency {
+			line.Unavailable = true
+			priced.Items[i] = line
+			continue
+		}
+
+		line.SKU = price.SKU
+		line.Name = price.Name
+		line.ImageURL = price.ImageURL
+		line.CategoryID = price.CategoryID
+		line.UnitPrice = price.UnitPrice
+		line.LineTotal = price.UnitPrice.Mul(item.Quantity)
+		line.OutOfStock = price.Stock < quantities[price.VariantID]
+		line.PriceChanged = !item.AddedPrice.IsZero() && item.AddedPrice != price.UnitPrice
+
+		if !line.OutOfStock {
+			priced.Subtotal = priced.Subtotal.Add(line.LineTotal)
+		}
+		priced.Items[i] = line
+	}
+
+	return priced
+}
+
+// CouponLines returns the lines that can be ordered, as seen by a coupon.
+func (c *PricedCart) CouponLines() []CouponLine {
+	lines := make([]CouponLine, 0, len(c.Items))
+	for _, item := range c.Items {
+		if item.Unavailable || item.OutOfStock {
+			continue
+		}
+		lines = append(lines, CouponLine{ProductID: item.ProductID, CategoryID: item.CategoryID, Amount: item.LineTotal})
+	}
+	return lines
+}
+
+// ApplyPricing sets the shipping fee and discounts and computes the cart total.
+func (c *PricedCart) ApplyPricing(shippingFee money.Money, discounts []DiscountLine) {
+	c.ShippingFee = shippingFee
+	c.Discounts = discounts
+	c.Total, c.DiscountTotal = TotalAfterDiscounts(c.Subtotal, shippingFee, discounts)
+}
+
+// CartOwner identifies a cart: either a signed-in user's cart or an anonymous one held by a guest token.
+type CartOwner struct {
+	UserID     string
+	GuestToken string
+}
+
+func UserCart(userID string) CartOwner {
+	return CartOwner{UserID: userID}
+}
+
+func GuestCart(token string) CartOwner {
+	return CartOwner{GuestToken: token}
+}
+
+func (o CartOwner) IsGuest() bool {
+	return o.UserID == ""
+}
+
+// NewGuestCartToken returns a random, unguessable token for an anonymous cart.
+func NewGuestCartToken() (string, error) {
+	b := make([]byte, guestCartTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ValidateGuestCartToken checks that token has the shape of a token issued by NewGuestCartToken.
+func ValidateGuestCartToken(token string) error {
+	b, err := hex.DecodeString(token)
+	if err != nil || len(b) != guestCartTokenBytes {
+		return ErrInvalidGuestCartToken
+	}
+	return nil
+}
+
+// ValidateCartQuantity checks a quantity requested for a single cart line.
+func ValidateCartQuantity(quantity int) error {
+	if quantity <= 0 || quantity > MaxCartItemQuantity {
+		return ErrInvalidQuantity
+	}
+	return nil
 }
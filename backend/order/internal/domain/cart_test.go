@@ -0,0 +1,150 @@
+package domain_test
+
+import (
+	"errors"
+	"order/internal/domain"
+	"pkg/money"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func price(productID, variantID, unitPrice string, stock int) domain.ProductPrice {
+	return domain.ProductPrice{
+		ProductID:  productID,
+		VariantID:  variantID,
+		SKU:        "SKU-" + variantID,
+		Name:       "Product " + productID,
+		CategoryID: "category-1",
+		UnitPrice:  usd(unitPrice),
+		Stock:      stock,
+		Published:  true,
+		Found:      true,
+	}
+}
+
+func TestNewPricedCart_Totals(t *testing.T) {
+	is := is.New(t)
+
+	cart := &domain.Cart{UserID: "user-1", CouponCode: "SUMMER", Items: []domain.CartItem{
+		{ProductID: "p1", VariantID: "v1", Quantity: 2},
+		{ProductID: "p2", Quantity: 3},
+	}}
+	prices := []domain.ProductPrice{price("p1", "v1", "10.50", 5), price("p2", "v2", "1.99", 5)}
+
+	priced := domain.NewPricedCart(cart, prices, "USD")
+	is.Equal(priced.UserID, "user-1")
+	is.Equal(priced.CouponCode, "SUMMER")
+	is.Equal(len(priced.Items), 2)
+	is.Equal(priced.Items[0].LineTotal, usd("21.00"))
+	is.Equal(priced.Items[0].SKU, "SKU-v1")
+	is.Equal(priced.Items[1].UnitPrice, usd("1.99"))
+	is.Equal(priced.Items[1].LineTotal, usd("5.97"))
+	is.Equal(priced.Subtotal, usd("26.97"))
+	is.Equal(priced.Total, usd("0")) // nothing is totalled before pricing is applied
+
+	priced.ApplyPricing(usd("4.99"), []domain.DiscountLine{{Amount: usd("2.00")}})
+	is.Equal(priced.ShippingFee, usd("4.99"))
+	is.Equal(priced.DiscountTotal, usd("2.00"))
+	is.Equal(priced.Total, usd("29.96"))
+}
+
+func TestNewPricedCart_UnavailableLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		price domain.ProductPrice
+	}{
+		{name: "missing product", price: domain.ProductPrice{ProductID: "p1"}},
+		{name: "unpublished product", price: func() domain.ProductPrice {
+			p := price("p1", "v1", "10.00", 5)
+			p.Published = false
+			return p
+		}()},
+		{name: "priced in another currency", price: func() domain.ProductPrice {
+			p := price("p1", "v1", "10.00", 5)
+			p.UnitPrice = money.MustParse("10.00", "EUR")
+			return p
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			cart := &domain.Cart{Items: []domain.CartItem{{ProductID: "p1", Quantity: 1}, {ProductID: "p2", Quantity: 1}}}
+			priced := domain.NewPricedCart(cart, []domain.ProductPrice{tt.price, price("p2", "v2", "3.00", 5)}, "USD")
+
+			is.True(priced.Items[0].Unavailable)
+			is.True(priced.Items[0].UnitPrice.IsZero()) // no catalog data is shown
+			is.Equal(priced.Subtotal, usd("3.00"))      // only the available line counts
+			is.Equal(len(priced.CouponLines()), 1)
+		})
+	}
+}
+
+func TestNewPricedCart_StockIsCheckedPerVariant(t *testing.T) {
+	is := is.New(t)
+
+	// Both lines resolve to v1: once by its id and once through its product's oldest variant.
+	cart := &domain.Cart{Items: []domain.CartItem{
+		{ProductID: "p1", VariantID: "v1", Quantity: 2},
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "p2", VariantID: "v2", Quantity: 1},
+	}}
+	prices := []domain.ProductPrice{price("p1", "v1", "5.00", 3), price("p1", "v1", "5.00", 3), price("p2", "v2", "1.00", 1)}
+
+	priced := domain.NewPricedCart(cart, prices, "USD")
+	is.True(priced.Items[0].OutOfStock)
+	is.True(priced.Items[1].OutOfStock)
+	is.True(!priced.Items[2].OutOfStock)              // exactly the stock left
+	is.Equal(priced.Subtotal, usd("1.00"))            // out of stock lines do not count
+	is.Equal(priced.Items[0].LineTotal, usd("10.00")) // but are still priced
+}
+
+func TestNewPricedCart_FlagsChangedPrices(t *testing.T) {
+	is := is.New(t)
+
+	cart := &domain.Cart{Items: []domain.CartItem{
+		{ProductID: "p1", Quantity: 1, AddedPrice: usd("9.99")},
+		{ProductID: "p2", Quantity: 1, AddedPrice: usd("4.00")},
+		{ProductID: "p3", Quantity: 1},
+	}}
+	prices := []domain.ProductPrice{price("p1", "v1", "10.99", 5), price("p2", "v2", "4.00", 5), price("p3", "v3", "1.00", 5)}
+
+	priced := domain.NewPricedCart(cart, prices, "USD")
+	is.True(priced.Items[0].PriceChanged)
+	is.True(!priced.Items[1].PriceChanged)
+	is.True(!priced.Items[2].PriceChanged) // lines without a recorded price are never flagged
+}
+
+func TestPricedCart_CouponLines(t *testing.T) {
+	is := is.New(t)
+
+	cart := &domain.Cart{Items: []domain.CartItem{{ProductID: "p1", Quantity: 2}, {ProductID: "p2", Quantity: 9}}}
+	priced := domain.NewPricedCart(cart, []domain.ProductPrice{price("p1", "v1", "2.50", 5), price("p2", "v2", "1.00", 1)}, "USD")
+
+	is.Equal(priced.CouponLines(), []domain.CouponLine{{ProductID: "p1", CategoryID: "category-1", Amount: usd("5.00")}})
+}
+
+func TestValidateCartQuantity(t *testing.T) {
+	is := is.New(t)
+	is.NoErr(domain.ValidateCartQuantity(1))
+	is.NoErr(domain.ValidateCartQuantity(domain.MaxCartItemQuantity))
+	is.True(errors.Is(domain.ValidateCartQuantity(0), domain.ErrInvalidQuantity))
+	is.True(errors.Is(domain.ValidateCartQuantity(domain.MaxCartItemQuantity+1), domain.ErrInvalidQuantity))
+}
+
+func TestGuestCartToken(t *testing.T) {
+	is := is.New(t)
+
+	token, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+	is.NoErr(domain.ValidateGuestCartToken(token))
+
+	other, err := domain.NewGuestCartToken()
+	is.NoErr(err)
+	is.True(token != other)
+
+	for _, invalid := range []string{"", "not-hex", token[:10], token + "00"} {
+		is.True(errors.Is(domain.ValidateGuestCartToken(invalid), domain.ErrInvalidGuestCartToken))
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"pkg/money"
+)
+
+// ProductPrice is the catalog-service view of a cart item at checkout time.
+type ProductPrice struct {
+	ProductID  string
+	VariantID  string
+	SKU        string
+	Name       string
+	ImageURL   string
+	CategoryID string
+	UnitPrice  money.Money
+	Stock      int
+	Published  bool
+	Found      bool
+}
+
+// VariantQuantities sums the quantities of the found items per resolved variant, so that lines naming the
+// same variant (e.g. once by its id and once by its product alone) are checked against its stock together.
+func VariantQuantities(items []CartItem, prices []ProductPrice) map[string]int {
+	quantities := make(map[string]int, len(items))
+	for i, item := range items {
+		if prices[i].Found {
+			quantities[prices[i].VariantID] += item.Quantity
+		}
+	}
+	return quantities
+}
+
+type ProductCatalog interface {
+	// ResolvePrices returns one ProductPrice per item, in the same order as items.
+	ResolvePrices(ctx context.Context, items []CartItem) ([]ProductPrice, error)
+	// ReserveStock takes the order items out of stock, failing with ErrInsufficientStock
+	// if any of them cannot be covered. The order id makes retries safe.
+	ReserveStock(ctx context.Context, orderID OrderID, items []OrderItem) error
+	// CommitStock makes the reservation final once the order is paid.
+	CommitStock(ctx context.Context, orderID OrderID) error
+	// ReleaseStock returns any stock the catalog holds for the order; it is safe to call more than once.
+	ReleaseStock(ctx context.Context, orderID OrderID) error
+}
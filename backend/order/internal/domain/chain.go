@@ -0,0 +1,43 @@
+package domain
+
+import "context"
+
+const (
+	ChainEthereum = "ethereum"
+	ChainBitcoin  = "bitcoin"
+)
+
+// BlockRef identifies a block a chain watcher has processed. The hash tells whether the block
+// is still part of the canonical chain.
+type BlockRef struct {
+	Number uint64
+	Hash   string
+}
+
+// ChainBlock is a block of a chain followed by the payment watcher.
+type ChainBlock struct {
+	Number     uint64
+	Hash       string
+	ParentHash string
+}
+
+// ChainBackend is a chain the payment watcher can follow. A backend only reports blocks and the
+// transfers into watched addresses; keeping the cursor, handling reorganisations, counting
+// confirmations and settling orders is up to the watcher.
+type ChainBackend interface {
+	// Chain is the name payments and processed blocks of the chain are stored under.
+	Chain() string
+	// Head returns the number of the latest block.
+	Head(ctx context.Context) (uint64, error)
+	// Block returns the canonical block at number.
+	Block(ctx context.Context, number uint64) (*ChainBlock, error)
+	// Transfers returns the transfers in block into one of addresses, which are normalized. The
+	// payments are returned without order, confirmations and detection time.
+	Transfers(ctx context.Context, block *ChainBlock, addresses map[string]bool) ([]Payment, error)
+	// NormalizeAddress returns the canonical form of address. It reports false if address does not
+	// belong to the chain.
+	NormalizeAddress(address string) (string, bool)
+	// WatchHeads signals on heads whenever the head may have changed, until ctx is done or the
+	// connection to the chain is lost.
+	WatchHeads(ctx context.Context, heads chan<- struct{}) error
+}
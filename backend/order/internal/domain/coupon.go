@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"pkg/money"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type CouponType string
+
+const (
+	CouponPercentage   CouponType = "PERCENTAGE"
+	CouponFixedAmount  CouponType = "FIXED_AMOUNT"
+	CouponFreeShipping CouponType = "FREE_SHIPPING"
+)
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Coupon is a promotion code. When ProductIDs or CategoryIDs are set, only matching
+// cart lines are discounted; otherwise the whole basket is.
+type Coupon struct {
+	ID             string
+	Code           string
+	Type           CouponType
+	Value          float64     // Percent for PERCENTAGE, unused otherwise
+	Amount         money.Money // Discount for FIXED_AMOUNT, unused otherwise
+	MinBasket      money.Money // Zero means no minimum
+	MaxUses        *int        // Across all users; nil means unlimited
+	MaxUsesPerUser *int        // nil means unlimited
+	UsedCount      int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	ProductIDs     []string
+	CategoryIDs    []string
+	Active         bool
+	CreatedAt      time.Time
+}
+
+// DiscountLine is one discount applied to a cart or order, kept on the order so its total can be audited.
+type DiscountLine struct {
+	ID          int64
+	CouponID    string
+	CouponCode  string
+	Type        CouponType
+	Description string
+	Amount      money.Money
+}
+
+// CouponLine is the part of a cart line a coupon looks at.
+type CouponLine struct {
+	ProductID  string
+	CategoryID string
+	Amount     money.Money
+}
+
+// NormalizeCouponCode makes coupon codes case-insensitive.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func NewCoupon(code string, couponType CouponType, value float64, amount, minBasket money.Money, maxUses, maxUsesPerUser *int, validFrom, validUntil *time.Time, productIDs, categoryIDs []string) (*Coupon, error) {
+	code = NormalizeCouponCode(code)
+	if !couponCodePattern.MatchString(code) {
+		return nil, ErrInvalidCoupon
+	}
+
+	switch couponType {
+	case CouponPercentage:
+		if value <= 0 || value > 100 {
+			return nil, ErrInvalidCoupon
+		}
+		amount = money.Money{}
+	case CouponFixedAmount:
+		if !amount.IsPositive() || money.ValidateCurrency(amount.Currency) != nil {
+			return nil, ErrInvalidCoupon
+		}
+		value = 0
+	case CouponFreeShipping:
+		value, amount = 0, money.Money{}
+	default:
+		return nil, ErrInvalidCoupon
+	}
+
+	if minBasket.IsNegative() || (!minBasket.IsZero() && money.ValidateCurrency(minBasket.Currency) != nil) {
+		return nil, ErrInvalidCoupon
+	}
+	if !amount.IsZero() && !minBasket.IsZero() && amount.Currency != minBasket.Currency {
+		return nil, ErrInvalidCoupon
+	}
+	if (maxUses != nil && *maxUses <= 0) || (maxUsesPerUser != nil && *maxUsesPerUser <= 0) {
+		return nil, ErrInvalidCoupon
+	}
+	if validFrom != nil && validUntil != nil && !validFrom.Before(*validUntil) {
+		return nil, ErrInvalidCoupon
+	}
+
+	return &Coupon{
+		Code:           code,
+		Type:           couponType,
+		Value:          value,
+		Amount:         amount,
+		MinBasket:      minBasket,
+		MaxUses:        maxUses,
+		MaxUsesPerUser: maxUsesPerUser,
+		ValidFrom:      validFrom,
+		ValidUntil:     validUntil,
+		ProductIDs:     productIDs,
+		CategoryIDs:    categoryIDs,
+		Active:         true,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// Evaluate returns the discounts the coupon grants on a basket at the given time.
+// subtotal is the value of the whole basket, userRedemptions how often the shopper already used the coupon.
+// A coupon whose amounts are in another currency than the basket does not apply.
+func (c *Coupon) Evaluate(now time.Time, lines []CouponLine, subtotal, shippingFee money.Money, userRedemptions int) ([]DiscountLine, error) {
+	if !c.Active || (c.ValidFrom != nil && now.Before(*c.ValidFrom)) || (c.ValidUntil != nil && !now.Before(*c.ValidUntil)) {
+		return nil, ErrCouponExpired
+	}
+	if c.MaxUses != nil && c.UsedCount >= *c.MaxUses {
+		return nil, ErrCouponUsageLimitReached
+	}
+	if c.MaxUsesPerUser != nil && userRedemptions >= *c.MaxUsesPerUser {
+		return nil, ErrCouponUsageLimitReached
+	}
+	if !c.MinBasket.IsZero() {
+		if c.MinBasket.Currency != subtotal.Currency {
+			return nil, ErrCouponNotApplicable
+		}
+		if subtotal.Cmp(c.MinBasket) < 0 {
+			return nil, ErrCouponMinBasketNotMet
+		}
+	}
+	if c.Type == CouponFixedAmount && c.Amount.Currency != subtotal.Currency {
+		return nil, ErrCouponNotApplicable
+	}
+
+	eligible := money.Zero(subtotal.Currency)
+	for _, line := range lines {
+		if c.covers(line) {
+			eligible = eligible.Add(line.Amount)
+		}
+	}
+	if !eligible.IsPositive() {
+		return nil, ErrCouponNotApplicable
+	}
+
+	var amount money.Money
+	var description string
+	switch c.Type {
+	case CouponPercentage:
+		amount = eligible.Percent(c.Value)
+		description = "percentage discount"
+	case CouponFixedAmount:
+		amount = c.Amount.Min(eligible)
+		description = "fixed amount discount"
+	case CouponFreeShipping:
+		amount = shippingFee
+		description = "free shipping"
+	}
+	if !amount.IsPositive() {
+		return nil, ErrCouponNotApplicable
+	}
+
+	return []DiscountLine{{
+		CouponID:    c.ID,
+		CouponCode:  c.Code,
+		Type:        c.Type,
+		Description: description,
+		Amount:      amount,
+	}}, nil
+}
+
+func (c *Coupon) covers(line CouponLine) bool {
+	if len(c.ProductIDs) == 0 && len(c.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range c.ProductIDs {
+		if id == line.ProductID {
+			return true
+		}
+	}
+	for _, id := range c.CategoryIDs {
+		if id == line.CategoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// TotalAfterDiscounts adds shipping to subtotal and subtracts the discounts, never going below zero.
+func TotalAfterDiscounts(subtotal, shippingFee money.Money, discounts []DiscountLine) (total, discountTotal money.Money) {
+	discountTotal = money.Zero(subtotal.Currency)
+	for _, d := range discounts {
+		discountTotal = discountTotal.Add(d.Amount)
+	}
+	total = subtotal.Add(shippingFee).Sub(discountTotal).Max(money.Zero(subtotal.Currency))
+	return total, discountTotal
+}
+
+// IsCouponRejection reports whether err means a coupon does not apply, as opposed to a failure to check it.
+func IsCouponRejection(err error) bool {
+	return errors.Is(err, ErrCouponNotFound) ||
+		errors.Is(err, ErrCouponExpired) ||
+		errors.Is(err, ErrCouponUsageLimitReached) ||
+		errors.Is(err, ErrCouponMinBasketNotMet) ||
+		errors.Is(err, ErrCouponNotApplicable)
+}
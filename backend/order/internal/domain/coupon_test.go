@@ -0,0 +1,168 @@
+package domain_test
+
+import (
+	"errors"
+	"order/internal/domain"
+	"pkg/money"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func usd(amount string) money.Money {
+	return money.MustParse(amount, "USD")
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestNewCoupon(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		code       string
+		couponType domain.CouponType
+		value      float64
+		amount     money.Money
+		minBasket  money.Money
+		maxUses    *int
+		maxPerUser *int
+		validFrom  *time.Time
+		validUntil *time.Time
+		wantErr    bool
+	}{
+		{name: "percentage", code: "summer10", couponType: domain.CouponPercentage, value: 10},
+		{name: "full percentage", code: "FREE", couponType: domain.CouponPercentage, value: 100},
+		{name: "fixed amount", code: "FIVE_OFF", couponType: domain.CouponFixedAmount, amount: usd("5.00"), minBasket: usd("20.00")},
+		{name: "free shipping", code: "SHIPFREE", couponType: domain.CouponFreeShipping, maxUses: intPtr(100), maxPerUser: intPtr(1)},
+		{name: "validity window", code: "WEEKEND", couponType: domain.CouponPercentage, value: 5, validFrom: timePtr(now), validUntil: timePtr(now.Add(time.Hour))},
+		{name: "short code", code: "AB", couponType: domain.CouponPercentage, value: 10, wantErr: true},
+		{name: "code with spaces", code: "SUMMER SALE", couponType: domain.CouponPercentage, value: 10, wantErr: true},
+		{name: "unknown type", code: "MYSTERY", couponType: "BOGO", wantErr: true},
+		{name: "zero percentage", code: "NOTHING", couponType: domain.CouponPercentage, value: 0, wantErr: true},
+		{name: "percentage over 100", code: "TOOMUCH", couponType: domain.CouponPercentage, value: 101, wantErr: true},
+		{name: "zero fixed amount", code: "ZERO", couponType: domain.CouponFixedAmount, amount: usd("0"), wantErr: true},
+		{name: "unknown currency", code: "FUNNY", couponType: domain.CouponFixedAmount, amount: money.New(500, "XXX"), wantErr: true},
+		{name: "negative min basket", code: "NEGMIN", couponType: domain.CouponPercentage, value: 10, minBasket: usd("-1.00"), wantErr: true},
+		{name: "min basket in another currency", code: "MIXED", couponType: domain.CouponFixedAmount, amount: usd("5.00"), minBasket: money.MustParse("20.00", "EUR"), wantErr: true},
+		{name: "zero max uses", code: "NOUSES", couponType: domain.CouponPercentage, value: 10, maxUses: intPtr(0), wantErr: true},
+		{name: "zero max uses per user", code: "NOUSER", couponType: domain.CouponPercentage, value: 10, maxPerUser: intPtr(0), wantErr: true},
+		{name: "empty validity window", code: "NEVER", couponType: domain.CouponPercentage, value: 10, validFrom: timePtr(now), validUntil: timePtr(now), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			coupon, err := domain.NewCoupon(tt.code, tt.couponType, tt.value, tt.amount, tt.minBasket, tt.maxUses, tt.maxPerUser, tt.validFrom, tt.validUntil, nil, nil)
+			if tt.wantErr {
+				is.True(errors.Is(err, domain.ErrInvalidCoupon))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(coupon.Code, domain.NormalizeCouponCode(tt.code))
+			is.True(coupon.Active)
+		})
+	}
+}
+
+func TestNewCoupon_ClearsFieldsOfOtherTypes(t *testing.T) {
+	is := is.New(t)
+
+	percentage, err := domain.NewCoupon("TEN", domain.CouponPercentage, 10, usd("5.00"), money.Money{}, nil, nil, nil, nil, nil, nil)
+	is.NoErr(err)
+	is.True(percentage.Amount.IsZero())
+
+	fixed, err := domain.NewCoupon("FIVE", domain.CouponFixedAmount, 10, usd("5.00"), money.Money{}, nil, nil, nil, nil, nil, nil)
+	is.NoErr(err)
+	is.Equal(fixed.Value, 0.0)
+
+	shipping, err := domain.NewCoupon("SHIP", domain.CouponFreeShipping, 10, usd("5.00"), money.Money{}, nil, nil, nil, nil, nil, nil)
+	is.NoErr(err)
+	is.Equal(shipping.Value, 0.0)
+	is.True(shipping.Amount.IsZero())
+}
+
+func TestCoupon_Evaluate(t *testing.T) {
+	now := time.Now()
+	lines := []domain.CouponLine{
+		{ProductID: "p1", CategoryID: "shoes", Amount: usd("30.00")},
+		{ProductID: "p2", CategoryID: "socks", Amount: usd("10.00")},
+	}
+	subtotal, shipping := usd("40.00"), usd("4.99")
+
+	tests := []struct {
+		name            string
+		coupon          domain.Coupon
+		lines           []domain.CouponLine
+		subtotal        money.Money
+		userRedemptions int
+		inactive        bool
+		want            money.Money
+		wantErr         error
+	}{
+		{name: "percentage of the basket", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10}, want: usd("4.00")},
+		{name: "fixed amount", coupon: domain.Coupon{Type: domain.CouponFixedAmount, Amount: usd("5.00")}, want: usd("5.00")},
+		{name: "fixed amount capped at the eligible lines", coupon: domain.Coupon{Type: domain.CouponFixedAmount, Amount: usd("15.00"), ProductIDs: []string{"p2"}}, want: usd("10.00")},
+		{name: "free shipping", coupon: domain.Coupon{Type: domain.CouponFreeShipping}, want: shipping},
+		{name: "scoped to a product", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 50, ProductIDs: []string{"p1"}}, want: usd("15.00")},
+		{name: "scoped to a category", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 50, CategoryIDs: []string{"socks"}}, want: usd("5.00")},
+		{name: "scoped to a product or a category", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 50, ProductIDs: []string{"p1"}, CategoryIDs: []string{"socks"}}, want: usd("20.00")},
+		{name: "no line in scope", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, ProductIDs: []string{"p9"}}, wantErr: domain.ErrCouponNotApplicable},
+		{name: "empty basket", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10}, lines: []domain.CouponLine{}, subtotal: usd("0"), wantErr: domain.ErrCouponNotApplicable},
+		{name: "inactive", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10}, inactive: true, wantErr: domain.ErrCouponExpired},
+		{name: "not valid yet", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, ValidFrom: timePtr(now.Add(time.Minute))}, wantErr: domain.ErrCouponExpired},
+		{name: "expired", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, ValidUntil: timePtr(now)}, wantErr: domain.ErrCouponExpired},
+		{name: "within the validity window", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, ValidFrom: timePtr(now), ValidUntil: timePtr(now.Add(time.Minute))}, want: usd("4.00")},
+		{name: "total limit reached", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MaxUses: intPtr(5), UsedCount: 5}, wantErr: domain.ErrCouponUsageLimitReached},
+		{name: "total limit not reached", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MaxUses: intPtr(5), UsedCount: 4}, want: usd("4.00")},
+		{name: "per user limit reached", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MaxUsesPerUser: intPtr(1)}, userRedemptions: 1, wantErr: domain.ErrCouponUsageLimitReached},
+		{name: "per user limit not reached", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MaxUsesPerUser: intPtr(2)}, userRedemptions: 1, want: usd("4.00")},
+		{name: "min basket not met", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MinBasket: usd("40.01")}, wantErr: domain.ErrCouponMinBasketNotMet},
+		{name: "min basket met", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MinBasket: usd("40.00")}, want: usd("4.00")},
+		{name: "min basket in another currency", coupon: domain.Coupon{Type: domain.CouponPercentage, Value: 10, MinBasket: money.MustParse("10.00", "EUR")}, wantErr: domain.ErrCouponNotApplicable},
+		{name: "fixed amount in another currency", coupon: domain.Coupon{Type: domain.CouponFixedAmount, Amount: money.MustParse("5.00", "EUR")}, wantErr: domain.ErrCouponNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			coupon := tt.coupon
+			coupon.ID, coupon.Code, coupon.Active = "coupon-1", "CODE", !tt.inactive
+			basket, total := lines, subtotal
+			if tt.lines != nil {
+				basket, total = tt.lines, tt.subtotal
+			}
+
+			discounts, err := coupon.Evaluate(now, basket, total, shipping, tt.userRedemptions)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+				is.True(domain.IsCouponRejection(err))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(len(discounts), 1)
+			is.Equal(discounts[0].Amount, tt.want)
+			is.Equal(discounts[0].CouponID, "coupon-1")
+			is.Equal(discounts[0].CouponCode, "CODE")
+			is.Equal(discounts[0].Type, coupon.Type)
+		})
+	}
+}
+
+func TestTotalAfterDiscounts(t *testing.T) {
+	is := is.New(t)
+
+	discounts := []domain.DiscountLine{{Amount: usd("5.00")}, {Amount: usd("4.99")}}
+	total, discountTotal := domain.TotalAfterDiscounts(usd("40.00"), usd("4.99"), discounts)
+	is.Equal(total, usd("35.00"))
+	is.Equal(discountTotal, usd("9.99"))
+
+	total, _ = domain.TotalAfterDiscounts(usd("3.00"), usd("0"), []domain.DiscountLine{{Amount: usd("5.00")}})
+	is.Equal(total, usd("0")) // never below zero
+}
@@ -3,7 +3,27 @@ package domain
 import "errors"
 
 var (
-	ErrOrderNotFound  = errors.New("order not found")
-	ErrCartNotFound   = errors.New("cart not found")
-	ErrUserIDNotEmpty = errors.New("user ID cannot be empty")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrCartNotFound            = errors.New("cart not found")
+	ErrCartItemNotFound        = errors.New("cart item not found")
+	ErrInvalidQuantity         = errors.New("quantity must be between 1 and 99")
+	ErrInvalidGuestCartToken   = errors.New("invalid guest cart token")
+	ErrEmptyCart               = errors.New("cannot create order from an empty cart")
+	ErrUserIDNotEmpty          = errors.New("user ID cannot be empty")
+	ErrProductUnavailable      = errors.New("product is not available")
+	ErrInsufficientStock       = errors.New("insufficient stock for product")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidCursor           = errors.New("invalid page cursor")
+	ErrCouponNotFound          = errors.New("coupon not found")
+	ErrCouponAlreadyExists     = errors.New("a coupon with this code already exists")
+	ErrInvalidCoupon           = errors.New("invalid coupon definition")
+	ErrCouponExpired           = errors.New("coupon is not valid at this time")
+	ErrCouponUsageLimitReached = errors.New("coupon usage limit reached")
+	ErrCouponMinBasketNotMet   = errors.New("basket is below the coupon minimum")
+	ErrCouponNotApplicable     = errors.New("coupon does not apply to any item in the cart")
+	ErrRateUnavailable         = errors.New("exchange rate is not available")
+	ErrUnsupportedAsset        = errors.New("unsupported payment asset")
+	ErrUnsupportedPayment      = errors.New("unsupported payment method")
+	ErrNothingToPay            = errors.New("order total is zero, there is nothing to pay")
+	ErrNoPaymentQuote          = errors.New("order has no payment quote")
 )
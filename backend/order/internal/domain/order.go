@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"math/big"
+	"pkg/money"
 	"time"
 )
 
@@ -10,46 +12,133 @@ type OrderStatus string
 const (
 	StatusPending         OrderStatus = "PENDING"
 	StatusAwaitingPayment OrderStatus = "AWAITING_PAYMENT"
+	StatusPartiallyPaid   OrderStatus = "PARTIALLY_PAID"
 	StatusPaid            OrderStatus = "PAID"
 	StatusShipped         OrderStatus = "SHIPPED"
 	StatusCancelled       OrderStatus = "CANCELLED"
 )
 
+// PaymentMethodCrypto is the only payment method orders can be placed with.
+const PaymentMethodCrypto = "CRYPTO"
+
 type OrderItem struct {
 	ProductID string
+	VariantID string
+	SKU       string
+	Name      string
 	Quantity  int
-	Price     float64
+	Price     money.Money // Unit price
 }
 
 type Order struct {
-	ID              OrderID
-	UserID          string
-	Items           []OrderItem
-	TotalPrice      float64
-	PaymentMethod   string
-	PaymentAddress  *string // Crypto address
-	TransactionID   *string // Blockchain hash transaction
-	DerivationIndex *int64
-	Status          OrderStatus
-	CreatedAt       time.Time
+	ID                  OrderID
+	UserID              string
+	Items               []OrderItem
+	TotalPrice          money.Money // Items plus shipping, minus discounts
+	ShippingFee         money.Money
+	Discounts           []DiscountLine
+	PaymentMethod       string
+	PaymentAddress      *string // Crypto address
+	TransactionID       *string // Blockchain hash transaction
+	DerivationIndex     *int64
+	PaymentQuote        *PaymentQuote // Set for crypto orders
+	PaymentToken        *Token        // Set for crypto orders paid with an ERC-20 token instead of ETH
+	AmountReceived      *big.Int      // Confirmed amount received at PaymentAddress, in the smallest unit of the quoted asset
+	AmountOverpaid      *big.Int      // Received above the expected amount, owed back to the customer
+	StockCommitPending  bool          // Paid, but the catalog has not confirmed making the stock reservation final yet
+	StockReleasePending bool          // Cancelled, but the catalog has not confirmed handing the reserved stock back yet
+	Payments            []Payment
+	Status              OrderStatus
+	StatusHistory       []StatusChange
+	CreatedAt           time.Time
 }
 
+// NewOrder creates a pending order. All items must be priced in the same currency.
 func NewOrder(userID, paymentMethod string, items []OrderItem) (*Order, error) {
-	var totalPrice float64
-	for _, item := range items {
-		totalPrice += item.Price * float64(item.Quantity)
-	}
-
 	if userID == "" {
 		return nil, ErrUserIDNotEmpty
 	}
 
-	return &Order{
+	now := time.Now()
+	order := &Order{
 		UserID:        userID,
 		Items:         items,
-		TotalPrice:    totalPrice,
 		Status:        StatusPending,
 		PaymentMethod: paymentMethod,
-		CreatedAt:     time.Now(),
-	}, nil
+		StatusHistory: []StatusChange{{
+			ToStatus:  StatusPending,
+			ChangedBy: userID,
+			Reason:    "order created",
+			ChangedAt: now,
+		}},
+		CreatedAt: now,
+	}
+	order.TotalPrice = order.Subtotal()
+
+	return order, nil
+}
+
+// Subtotal is the value of the order items before shipping and discounts.
+func (o *Order) Subtotal() money.Money {
+	var subtotal money.Money
+	for _, item := range o.Items {
+		subtotal = subtotal.Add(item.Price.Mul(item.Quantity))
+	}
+	return subtotal
+}
+
+// AwaitsConfirmation reports whether payments hold more of the quoted asset than the confirmed amount
+// recorded on the order, i.e. a transfer was detected that has not reached the required confirmations yet.
+func (o *Order) AwaitsConfirmation(payments []Payment) bool {
+	if o.PaymentQuote == nil {
+		return false
+	}
+	detected, _ := ConfirmedAmount(payments, o.PaymentQuote.Asset, 0)
+	received := o.AmountReceived
+	if received == nil {
+		received = new(big.Int)
+	}
+	return detected.Cmp(received) > 0
+}
+
+// ApplyPricing sets the shipping fee and discounts and recomputes the order total.
+func (o *Order) ApplyPricing(shippingFee money.Money, discounts []DiscountLine) {
+	o.ShippingFee = shippingFee
+	o.Discounts = discounts
+	o.TotalPrice, _ = TotalAfterDiscounts(o.Subtotal(), shippingFee, discounts)
+}
+
+// RecordPayment updates the order with the confirmed amount received at its payment address.
+// The order becomes PAID once the expected amount is covered, with any excess recorded as overpaid,
+// and PARTIALLY_PAID while less has arrived. A paid order has its stock commit pending until the catalog
+// confirms it. It reports whether the order changed.
+func (o *Order) RecordPayment(received *big.Int, changedBy string) (bool, error) {
+	if o.PaymentQuote == nil {
+		return false, ErrNoPaymentQuote
+	}
+	if o.AmountReceived != nil && o.AmountReceived.Cmp(received) == 0 {
+		return false, nil
+	}
+	if received.Sign() <= 0 {
+		return false, nil
+	}
+
+	expected := o.PaymentQuote.ExpectedAmount
+	switch {
+	case received.Cmp(expected) >= 0:
+		if err := o.TransitionTo(StatusPaid, changedBy, "received "+received.String()+" of "+expected.String()); err != nil {
+			return false, err
+		}
+		if overpaid := new(big.Int).Sub(received, expected); overpaid.Sign() > 0 {
+			o.AmountOverpaid = overpaid
+		}
+		o.StockCommitPending = true
+	case o.Status != StatusPartiallyPaid:
+		if err := o.TransitionTo(StatusPartiallyPaid, changedBy, "received "+received.String()+" of "+expected.String()); err != nil {
+			return false, err
+		}
+	}
+
+	o.AmountReceived = new(big.Int).Set(received)
+	return true, nil
 }
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultOrderPageSize = 20
+	MaxOrderPageSize     = 100
+)
+
+// OrderCursor points at the last order of a page; orders are listed newest first,
+// ordered by (CreatedAt, ID) descending.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        OrderID
+}
+
+// OrderFilter narrows an order listing. Zero values mean "no restriction".
+type OrderFilter struct {
+	UserID      string
+	Statuses    []OrderStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	After       *OrderCursor
+	Limit       int
+	// StockReleasePending keeps only orders whose stock release the catalog has not confirmed yet.
+	StockReleasePending bool
+}
+
+type OrderPage struct {
+	Orders     []*Order
+	NextCursor *OrderCursor // nil on the last page
+}
+
+// EncodeOrderCursor returns the opaque page token handed to API clients.
+func EncodeOrderCursor(c OrderCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + string(c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeOrderCursor(token string) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &OrderCursor{CreatedAt: t, ID: OrderID(id)}, nil
+}
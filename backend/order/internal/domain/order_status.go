@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Actors recorded in the status history for changes not made by the order owner.
+const (
+	ActorSystem         = "system"
+	ActorPaymentWatcher = "payment_watcher"
+)
+
+// orderTransitions lists, for every status, the statuses an order may move to next.
+// SHIPPED and CANCELLED are terminal.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	StatusPending:         {StatusAwaitingPayment, StatusPaid, StatusCancelled},
+	StatusAwaitingPayment: {StatusPartiallyPaid, StatusPaid, StatusCancelled},
+	StatusPartiallyPaid:   {StatusPaid, StatusCancelled},
+	StatusPaid:            {StatusShipped},
+	StatusShipped:         {},
+	StatusCancelled:       {},
+}
+
+// StatusChange is one audited entry of an order's status history.
+// ID is zero until the entry has been persisted.
+type StatusChange struct {
+	ID         int64
+	FromStatus *OrderStatus // nil for the entry recorded when the order is created
+	ToStatus   OrderStatus
+	ChangedBy  string
+	Reason     string
+	ChangedAt  time.Time
+}
+
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomerCancellable reports whether the owner may still cancel the order: only while nothing has been
+// paid. Orders that received funds need a refund and are cancelled by an admin.
+func (o *Order) CustomerCancellable() bool {
+	return o.Status == StatusPending || o.Status == StatusAwaitingPayment
+}
+
+// TransitionTo moves the order to next and appends the change to its history,
+// rejecting moves the transition table does not allow.
+func (o *Order) TransitionTo(next OrderStatus, changedBy, reason string) error {
+	if !o.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, o.Status, next)
+	}
+
+	from := o.Status
+	o.Status = next
+	o.StatusHistory = append(o.StatusHistory, StatusChange{
+		FromStatus: &from,
+		ToStatus:   next,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		ChangedAt:  time.Now(),
+	})
+
+	return nil
+}
+
+// PendingStatusChanges returns the history entries that have not been persisted yet.
+func (o *Order) PendingStatusChanges() []StatusChange {
+	for i, change := range o.StatusHistory {
+		if change.ID == 0 {
+			return o.StatusHistory[i:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package domain_test
+
+import (
+	"errors"
+	"order/internal/domain"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var allStatuses = []domain.OrderStatus{
+	domain.StatusPending,
+	domain.StatusAwaitingPayment,
+	domain.StatusPartiallyPaid,
+	domain.StatusPaid,
+	domain.StatusShipped,
+	domain.StatusCancelled,
+}
+
+// allowedTransitions is the transition table spelled out; every pair not listed must be rejected.
+var allowedTransitions = map[domain.OrderStatus][]domain.OrderStatus{
+	domain.StatusPending:         {domain.StatusAwaitingPayment, domain.StatusPaid, domain.StatusCancelled},
+	domain.StatusAwaitingPayment: {domain.StatusPartiallyPaid, domain.StatusPaid, domain.StatusCancelled},
+	domain.StatusPartiallyPaid:   {domain.StatusPaid, domain.StatusCancelled},
+	domain.StatusPaid:            {domain.StatusShipped},
+}
+
+func isAllowed(from, to domain.OrderStatus) bool {
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func TestOrderStatus_Transitions(t *testing.T) {
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			want := isAllowed(from, to)
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				is := is.New(t)
+
+				is.Equal(from.CanTransitionTo(to), want)
+
+				order := &domain.Order{Status: from}
+				err := order.TransitionTo(to, "admin-1", "test")
+				if want {
+					is.NoErr(err)
+					is.Equal(order.Status, to)
+					return
+				}
+				is.True(errors.Is(err, domain.ErrInvalidStatusTransition))
+				is.Equal(order.Status, from)          // a rejected transition leaves the status alone
+				is.Equal(len(order.StatusHistory), 0) // and records nothing
+			})
+		}
+	}
+}
+
+func TestOrderStatus_IsValid(t *testing.T) {
+	is := is.New(t)
+	for _, status := range allStatuses {
+		is.True(status.IsValid())
+	}
+	is.True(!domain.OrderStatus("REFUNDED").IsValid())
+	is.True(!domain.OrderStatus("").IsValid())
+}
+
+func TestOrder_CustomerCancellable(t *testing.T) {
+	tests := []struct {
+		status domain.OrderStatus
+		want   bool
+	}{
+		{domain.StatusPending, true},
+		{domain.StatusAwaitingPayment, true},
+		{domain.StatusPartiallyPaid, false},
+		{domain.StatusPaid, false},
+		{domain.StatusShipped, false},
+		{domain.StatusCancelled, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			is := is.New(t)
+			order := &domain.Order{Status: tt.status}
+			is.Equal(order.CustomerCancellable(), tt.want)
+		})
+	}
+}
+
+func TestOrder_StatusHistory(t *testing.T) {
+	is := is.New(t)
+
+	order, err := domain.NewOrder("user-1", "card", nil)
+	is.NoErr(err)
+	is.Equal(len(order.StatusHistory), 1)
+	created := order.StatusHistory[0]
+	is.True(created.FromStatus == nil) // the creation entry has no previous status
+	is.Equal(created.ToStatus, domain.StatusPending)
+	is.Equal(created.ChangedBy, "user-1")
+	is.True(!created.ChangedAt.IsZero())
+
+	is.NoErr(order.TransitionTo(domain.StatusAwaitingPayment, domain.ActorSystem, "payment address assigned"))
+	is.NoErr(order.TransitionTo(domain.StatusCancelled, "user-1", "changed my mind"))
+	is.Equal(len(order.StatusHistory), 3)
+
+	cancelled := order.StatusHistory[2]
+	is.True(cancelled.FromStatus != nil)
+	is.Equal(*cancelled.FromStatus, domain.StatusAwaitingPayment)
+	is.Equal(cancelled.ToStatus, domain.StatusCancelled)
+	is.Equal(cancelled.ChangedBy, "user-1")
+	is.Equal(cancelled.Reason, "changed my mind")
+	is.True(!cancelled.ChangedAt.Before(order.StatusHistory[1].ChangedAt)) // entries are in order
+
+	err = order.TransitionTo(domain.StatusPaid, domain.ActorPaymentWatcher, "late payment")
+	is.True(errors.Is(err, domain.ErrInvalidStatusTransition)) // CANCELLED is terminal
+	is.Equal(len(order.StatusHistory), 3)
+}
+
+func TestOrder_PendingStatusChanges(t *testing.T) {
+	is := is.New(t)
+
+	order, err := domain.NewOrder("user-1", "card", nil)
+	is.NoErr(err)
+	is.Equal(len(order.PendingStatusChanges()), 1) // nothing is persisted yet
+
+	// The repository assigns IDs to the entries it saves.
+	order.StatusHistory[0].ID = 1
+	is.Equal(len(order.PendingStatusChanges()), 0)
+
+	is.NoErr(order.TransitionTo(domain.StatusPaid, domain.ActorPaymentWatcher, "paid"))
+	pending := order.PendingStatusChanges()
+	is.Equal(len(pending), 1)
+	is.Equal(pending[0].ToStatus, domain.StatusPaid)
+}
+
+func TestNewOrder_RequiresAUser(t *testing.T) {
+	is := is.New(t)
+	_, err := domain.NewOrder("", "card", nil)
+	is.True(errors.Is(err, domain.ErrUserIDNotEmpty))
+}
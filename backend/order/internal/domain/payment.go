@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+// Payment is an on-chain transfer into an order's payment address.
+type Payment struct {
+	ID            int64
+	OrderID       OrderID
+	Chain         string
+	Asset         string
+	TxHash        string
+	BlockNumber   uint64
+	BlockHash     string
+	LogIndex      int // Index of the ERC-20 Transfer log in its block or of the Bitcoin output in its transaction, -1 for ETH
+	FromAddress   string
+	ToAddress     string
+	Value         *big.Int // In the smallest unit of Asset
+	Confirmations uint64   // Counting the block the transfer is included in
+	DetectedAt    time.Time
+}
+
+// ConfirmedAmount sums the payments in asset with at least confirmations confirmations and returns
+// it with the latest of them, nil if there is none. Transfers of other assets do not count.
+func ConfirmedAmount(payments []Payment, asset string, confirmations uint64) (*big.Int, *Payment) {
+	total := new(big.Int)
+	var latest *Payment
+	for i := range payments {
+		p := &payments[i]
+		if p.Asset != asset || p.Confirmations < confirmations {
+			continue
+		}
+		total.Add(total, p.Value)
+		if latest == nil || p.BlockNumber >= latest.BlockNumber {
+			latest = p
+		}
+	}
+	return total, latest
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"pkg/money"
+	"time"
+)
+
+const (
+	AssetETH = "ETH"
+	AssetBTC = "BTC"
+)
+
+// ExchangeRate is a snapshot of the price of one whole unit of a crypto asset in a fiat currency.
+type ExchangeRate struct {
+	ID        int64
+	Asset     string
+	Price     money.Money
+	Source    string
+	FetchedAt time.Time
+}
+
+// RateProvider returns the current price of asset in currency.
+type RateProvider interface {
+	Rate(ctx context.Context, asset, currency string) (*ExchangeRate, error)
+}
+
+// PaymentQuote records the rate a crypto order was priced at and the amount the customer has to send.
+type PaymentQuote struct {
+	Asset          string
+	RateID         int64
+	Rate           money.Money // Price of one whole unit of Asset
+	ExpectedAmount *big.Int    // In the smallest unit of Asset
+	QuotedAt       time.Time
+}
+
+// NewPaymentQuote converts total into the asset of rate, whose smallest unit has decimals decimals.
+// The expected amount is rounded up, so paying it always covers the order total.
+func NewPaymentQuote(total money.Money, rate *ExchangeRate, decimals int) (*PaymentQuote, error) {
+	if !rate.Price.IsPositive() || rate.Price.Currency != total.Currency {
+		return nil, ErrRateUnavailable
+	}
+
+	// total and price share the same minor unit, so the fiat exponent cancels out.
+	numerator := new(big.Int).Mul(big.NewInt(total.Amount), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	price := big.NewInt(rate.Price.Amount)
+	expected, remainder := new(big.Int).QuoRem(numerator, price, new(big.Int))
+	if remainder.Sign() > 0 {
+		expected.Add(expected, big.NewInt(1))
+	}
+
+	return &PaymentQuote{
+		Asset:          rate.Asset,
+		RateID:         rate.ID,
+		Rate:           rate.Price,
+		ExpectedAmount: expected,
+		QuotedAt:       time.Now(),
+	}, nil
+}
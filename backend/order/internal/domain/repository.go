@@ -1,17 +1,104 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// AddressDeriver returns the payment address for an HD wallet derivation index.
+type AddressDeriver func(index int64) (string, error)
 
 type OrderRepository interface {
-	Save(ctx context.Context, order *Order) error
+	// Save inserts the order. When deriveAddress is set, a derivation index is reserved for the order
+	// and the address derived from it is stored as its PaymentAddress in the same transaction.
+	Save(ctx context.Context, order *Order, deriveAddress AddressDeriver) error
+	// Update saves the status and payment fields of the order. It fails with ErrInvalidStatusTransition
+	// when the stored status is no longer the one the order was loaded with.
 	Update(ctx context.Context, order *Order) error
 	FindByID(ctx context.Context, id OrderID) (*Order, error)
-	FindByUserID(ctx context.Context, userID string) ([]*Order, error)
-	FindAwaitingPayment(ctx context.Context, limit, offset int) ([]*Order, error)
+	FindByUserID(ctx context.Context, userID string, filter OrderFilter) ([]*Order, error)
+	Search(ctx context.Context, filter OrderFilter) ([]*Order, error)
+	// The finders below return up to limit orders, oldest first, that come after the after cursor,
+	// the last order of the previous page; after is nil for the first page.
+
+	// FindAwaitingPayment returns crypto orders that are AWAITING_PAYMENT or PARTIALLY_PAID.
+	FindAwaitingPayment(ctx context.Context, after *OrderCursor, limit int) ([]*Order, error)
+	// FindStockCommitPending returns PAID orders whose stock commit the catalog has not confirmed yet.
+	FindStockCommitPending(ctx context.Context, after *OrderCursor, limit int) ([]*Order, error)
+	// FindCancelledSince returns crypto orders with a payment address that were cancelled at or after since.
+	FindCancelledSince(ctx context.Context, since time.Time, after *OrderCursor, limit int) ([]*Order, error)
+	// HasPaidOrderWithProduct reports whether the user has a PAID or SHIPPED order containing the product.
+	HasPaidOrderWithProduct(ctx context.Context, userID, productID string) (bool, error)
+	FindStatusHistory(ctx context.Context, id OrderID) ([]StatusChange, error)
+}
+
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *Coupon) error
+	FindByCode(ctx context.Context, code string) (*Coupon, error)
+	List(ctx context.Context) ([]*Coupon, error)
+	CountRedemptions(ctx context.Context, couponID, userID string) (int, error)
+}
+
+// PaymentRepository records the transfers seen for crypto orders. Saving a transfer that is already
+// recorded updates its block and confirmations.
+type PaymentRepository interface {
+	Save(ctx context.Context, payment *Payment) error
+	FindByOrderIDs(ctx context.Context, ids []OrderID) (map[OrderID][]Payment, error)
+	// RefreshConfirmations recomputes the confirmations of the payments on chain against head,
+	// leaving alone those that already have at least upTo.
+	RefreshConfirmations(ctx context.Context, chain string, head, upTo uint64) error
+	// DeleteFromBlock removes the payments on chain recorded in block number or later, once those
+	// blocks have been reorganised away.
+	DeleteFromBlock(ctx context.Context, chain string, number uint64) error
+}
+
+// ChainCursorRepository remembers the recent blocks a chain watcher has processed, so it can resume
+// after a restart and notice when a processed block is no longer part of the chain.
+type ChainCursorRepository interface {
+	// Last returns the newest processed block of chain, or nil if none has been processed yet.
+	Last(ctx context.Context, chain string) (*BlockRef, error)
+	// Find returns the processed block at number, or nil if it is unknown or was pruned.
+	Find(ctx context.Context, chain string, number uint64) (*BlockRef, error)
+	Save(ctx context.Context, chain string, block BlockRef) error
+	// Rewind forgets the processed blocks from number on.
+	Rewind(ctx context.Context, chain string, number uint64) error
+}
+
+// SweepRepository records the sweeps of payment addresses to the treasury.
+type SweepRepository interface {
+	// Save inserts the sweep or updates the one of the same chain, address and asset.
+	Save(ctx context.Context, sweep *Sweep) error
+	// Find returns the sweep of asset from address on chain, or nil if there is none.
+	Find(ctx context.Context, chain, address, asset string) (*Sweep, error)
+	FindByStatus(ctx context.Context, chain string, status SweepStatus) ([]*Sweep, error)
+	// FindOrdersToSweep returns PAID and SHIPPED orders with an Ethereum payment address that has
+	// no sweep underway or done. An address whose sweep found nothing to move or failed is returned
+	// again once that sweep was last updated before retryBefore.
+	FindOrdersToSweep(ctx context.Context, retryBefore time.Time, limit int) ([]*Order, error)
+}
+
+// ExchangeRateRepository keeps the rate snapshots orders were quoted against.
+type ExchangeRateRepository interface {
+	Save(ctx context.Context, rate *ExchangeRate) error
+	// Latest returns the most recent snapshot for asset in currency, or ErrRateUnavailable.
+	Latest(ctx context.Context, asset, currency string) (*ExchangeRate, error)
 }
 
+// CartRepository stores carts line by line so concurrent updates of the same cart do not overwrite each other.
+// A cart line is identified by its product and variant ids.
 type CartRepository interface {
-	Save(ctx context.Context, cart *Cart) error
-	GetByUserID(ctx context.Context, userID string) (*Cart, error)
-	Delete(ctx context.Context, userID string) error
+	Get(ctx context.Context, owner CartOwner) (*Cart, error)
+	// AddItem adds item.Quantity to the matching line, creating it if needed, and fails with
+	// ErrInvalidQuantity if the line would exceed MaxCartItemQuantity.
+	AddItem(ctx context.Context, owner CartOwner, item CartItem) error
+	// SetItemQuantity replaces the quantity of an existing line.
+	SetItemQuantity(ctx context.Context, owner CartOwner, item CartItem) error
+	RemoveItem(ctx context.Context, owner CartOwner, productID, variantID string) error
+	SetCoupon(ctx context.Context, owner CartOwner, code string) error
+	ClearCoupon(ctx context.Context, owner CartOwner) error
+	// Merge moves every line of from into to, adding quantities of matching lines capped at
+	// MaxCartItemQuantity, and deletes from. from's coupon is kept only if to has none.
+	// It returns the number of lines moved.
+	Merge(ctx context.Context, from, to CartOwner) (int, error)
+	Delete(ctx context.Context, owner CartOwner) error
 }
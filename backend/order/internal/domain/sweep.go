@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+type SweepStatus string
+
+const (
+	SweepPending    SweepStatus = "PENDING"     // Not sent yet, Error tells why when an attempt failed
+	SweepDryRun     SweepStatus = "DRY_RUN"     // Planned by a dry run, nothing was sent
+	SweepFundingGas SweepStatus = "FUNDING_GAS" // Gas was sent to the address, the tokens follow once it arrived
+	SweepSubmitted  SweepStatus = "SUBMITTED"
+	SweepConfirmed  SweepStatus = "CONFIRMED"
+	SweepFailed     SweepStatus = "FAILED" // Reverted on chain, tried again after a back-off
+	SweepEmpty      SweepStatus = "EMPTY"  // Nothing worth moving, e.g. a balance below the fee; checked again after a back-off
+)
+
+// Sweep moves the funds received on an order's payment address to the treasury. There is one sweep
+// per chain, address and asset.
+type Sweep struct {
+	ID             int64
+	OrderID        OrderID
+	Chain          string
+	Asset          string
+	Address        string
+	Treasury       string
+	Amount         *big.Int // Moved to the treasury, in the smallest unit of Asset
+	GasTopUpTxHash *string
+	TxHash         *string
+	Status         SweepStatus
+	Error          string
+	UpdatedAt      time.Time
+}
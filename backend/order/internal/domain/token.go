@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of decimals of the smallest unit of the native assets: wei for ETH and satoshi for BTC.
+const (
+	ethDecimals = 18
+	btcDecimals = 8
+)
+
+// Token is an ERC-20 contract accepted for crypto payments. Its symbol is the asset name
+// used for exchange rates and payment quotes, e.g. USDC.
+type Token struct {
+	Symbol   string
+	Contract string // Contract address, hex encoded
+	Decimals int
+}
+
+// TokenRegistry holds the ERC-20 tokens accepted for crypto payments, keyed by symbol.
+// The native assets ETH and BTC are always accepted and are not part of the registry.
+type TokenRegistry map[string]Token
+
+// NewTokenRegistry validates tokens and indexes them by symbol.
+func NewTokenRegistry(tokens []Token) (TokenRegistry, error) {
+	registry := make(TokenRegistry, len(tokens))
+	for _, token := range tokens {
+		switch {
+		case token.Symbol == "" || token.Symbol == AssetETH || token.Symbol == AssetBTC:
+			return nil, fmt.Errorf("invalid token symbol %q", token.Symbol)
+		case token.Contract == "":
+			return nil, fmt.Errorf("token %s has no contract address", token.Symbol)
+		case token.Decimals < 0 || token.Decimals > ethDecimals:
+			return nil, fmt.Errorf("token %s has invalid decimals %d", token.Symbol, token.Decimals)
+		}
+		if _, ok := registry[token.Symbol]; ok {
+			return nil, fmt.Errorf("token %s is registered twice", token.Symbol)
+		}
+		if _, ok := registry.ByContract(token.Contract); ok {
+			return nil, fmt.Errorf("token contract %s is registered twice", token.Contract)
+		}
+		registry[token.Symbol] = token
+	}
+	return registry, nil
+}
+
+// Decimals returns the number of decimals of the smallest unit of asset.
+func (r TokenRegistry) Decimals(asset string) (int, error) {
+	switch asset {
+	case AssetETH:
+		return ethDecimals, nil
+	case AssetBTC:
+		return btcDecimals, nil
+	}
+	token, ok := r[asset]
+	if !ok {
+		return 0, ErrUnsupportedAsset
+	}
+	return token.Decimals, nil
+}
+
+// ByContract returns the token deployed at contract. Addresses are compared case-insensitively,
+// so checksummed and lower case forms match.
+func (r TokenRegistry) ByContract(contract string) (Token, bool) {
+	for _, token := range r {
+		if strings.EqualFold(token.Contract, contract) {
+			return token, true
+		}
+	}
+	return Token{}, false
+}
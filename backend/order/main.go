@@ -7,30 +7,41 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
-	pb "black-shop/api/proto/v1"
-	"black-shop/internal/adapters/storage/postgresql"
-	redisStorage "black-shop/internal/adapters/storage/redis"
-	"black-shop/internal/app/order"
-	"black-shop/pkg/auth"
-	"black-shop/pkg/config"
-	"black-shop/pkg/logger"
+	pb "api/proto/order/v1"
+	"order/internal/adapters/catalog"
+	"order/internal/adapters/chains"
+	"order/internal/adapters/rates"
+	"order/internal/adapters/storage/postgresql"
+	redisStorage "order/internal/adapters/storage/redis"
+	"order/internal/adapters/tokens"
+	"order/internal/application/services"
+	"order/internal/application/worker"
+	grpcserver "order/internal/delivery/grpc"
+	"pkg/auth"
+	"pkg/config"
+	"pkg/logger"
+	"pkg/money"
+	"pkg/wallet"
 )
 
 func main() {
-	cfg, err := config.LoadConfig("./configs")
+	cfg, err := config.LoadConfig("./config")
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	appLogger := logger.New(cfg.AppEnv)
+	logger.Init(cfg.AppEnv)
+	appLogger := logger.Logger
 	defer func(appLogger *zap.Logger) {
 		_ = appLogger.Sync()
 	}(appLogger)
@@ -43,13 +54,16 @@ func main() {
 func run(ctx context.Context, cfg *config.Config, appLogger *zap.Logger) error {
 	// --- Database Connections ---
 	appLogger.Info("connecting to Postgresql...")
-	pgConn, err := pgx.Connect(ctx, cfg.PostgresOrderURI)
+	// The API handlers and the background workers query concurrently, so they share a pool rather than one
+	// connection.
+	pgPool, err := pgxpool.New(ctx, cfg.PostgresOrderURI)
 	if err != nil {
 		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
-	defer func(pgConn *pgx.Conn, ctx context.Context) {
-		_ = pgConn.Close(ctx)
-	}(pgConn, ctx)
+	defer pgPool.Close()
+	if err := pgPool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
 	appLogger.Info("Successfully connected to PostgreSQL")
 
 	appLogger.Info("connecting to Redis...")
@@ -62,22 +76,113 @@ func run(ctx context.Context, cfg *config.Config, appLogger *zap.Logger) error {
 	}(redisClient)
 	appLogger.Info("Successfully connected to Redis")
 
+	appLogger.Info("connecting to catalog-service...", zap.String("addr", cfg.CatalogGRPCAddr))
+	catalogConn, err := grpc.NewClient(cfg.CatalogGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to create catalog-service client: %w", err)
+	}
+	defer func(catalogConn *grpc.ClientConn) {
+		_ = catalogConn.Close()
+	}(catalogConn)
+
 	// --- Layers ---
 	tokenManager := auth.NewTokenManager("a_very_secret_key")
 
 	cartRepo := redisStorage.NewCartRepository(redisClient, appLogger)
-	orderRepo, err := postgresql.NewOrderRepository(pgConn, appLogger)
+	orderRepo, err := postgresql.NewOrderRepository(pgPool, appLogger)
 	if err != nil {
 		return fmt.Errorf("failed to create order repository: %w", err)
 	}
+	couponRepo, err := postgresql.NewCouponRepository(pgPool, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create coupon repository: %w", err)
+	}
+	paymentRepo, err := postgresql.NewPaymentRepository(pgPool, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create payment repository: %w", err)
+	}
+	cursorRepo, err := postgresql.NewChainCursorRepository(pgPool, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create chain cursor repository: %w", err)
+	}
+	sweepRepo, err := postgresql.NewSweepRepository(pgPool, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create sweep repository: %w", err)
+	}
+	rateRepo, err := postgresql.NewExchangeRateRepository(pgPool, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to create exchange rate repository: %w", err)
+	}
+
+	catalogClient := catalog.NewCatalogClient(catalogConn, appLogger)
+
+	walletService, err := newWalletService(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet service: %w", err)
+	}
+
+	shippingFee, err := money.Parse(cfg.OrderShippingFee, cfg.OrderCurrency)
+	if err != nil {
+		return fmt.Errorf("invalid shipping fee: %w", err)
+	}
+
+	tokenRegistry, err := tokens.LoadRegistry(cfg.OrderTokensFile)
+	if err != nil {
+		return fmt.Errorf("failed to load token registry: %w", err)
+	}
+
+	rateService := services.NewRateService(rates.NewStaticProvider(cfg.OrderRatesFile, appLogger), rateRepo, cfg.OrderRateMaxAge, appLogger)
+
+	orderService := services.NewService(cartRepo, orderRepo, paymentRepo, couponRepo, catalogClient, walletService, rateService, tokenRegistry, cfg.BtcNetwork, shippingFee, appLogger)
+	grpcHandler := grpcserver.NewGRPCServer(orderService, cfg.OrderAdminUserIDs, appLogger)
 
-	orderService := order.NewService(cartRepo, orderRepo, appLogger)
-	grpcHandler := order.NewGRPCServer(orderService, appLogger)
+	// --- Workers ---
+	orderExpiry := worker.NewOrderExpiry(orderService, cfg.OrderPaymentWindow, appLogger)
+	go orderExpiry.Start(ctx)
+
+	if cfg.EthRPCURL != "" {
+		ethClient, err := ethclient.Dial(cfg.EthRPCURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to ethereum node: %w", err)
+		}
+		defer ethClient.Close()
+
+		ethBackend := chains.NewEthereumBackend(ethClient, tokenRegistry, appLogger)
+		paymentWatcher := worker.NewPaymentWatcher(ctx, orderRepo, paymentRepo, cursorRepo, catalogClient, ethBackend, cfg.OrderPaymentConfirmations, cfg.OrderLatePaymentWindow, appLogger)
+		go paymentWatcher.Start()
+
+		if cfg.WalletMnemonic == "" {
+			appLogger.Warn("the wallet is watch-only, received funds stay on the payment addresses")
+		} else if cfg.SweepTreasuryAddress != "" {
+			sweeper, err := worker.NewSweeper(sweepRepo, walletService, ethClient, cfg.SweepTreasuryAddress, cfg.SweepGasTankPath, cfg.SweepInterval, cfg.SweepDryRun, appLogger)
+			if err != nil {
+				return fmt.Errorf("failed to create sweeper: %w", err)
+			}
+			go sweeper.Start(ctx)
+		} else {
+			appLogger.Warn("SWEEP_TREASURY_ADDRESS is not set, received funds stay on the payment addresses")
+		}
+	} else {
+		appLogger.Warn("ETH_RPC_URL is not set, ETH and token payments will not be detected")
+	}
+
+	if cfg.BtcNetwork != "" {
+		if _, err := wallet.BitcoinCoinType(cfg.BtcNetwork); err != nil {
+			return fmt.Errorf("invalid BTC_NETWORK: %w", err)
+		}
+		if cfg.BtcEsploraURL == "" {
+			appLogger.Warn("BTC_ESPLORA_URL is not set, BTC payments will not be detected")
+		} else {
+			btcBackend := chains.NewEsploraBackend(cfg.BtcEsploraURL, cfg.BtcNetwork, cfg.BtcPollInterval, appLogger)
+			btcWatcher := worker.NewPaymentWatcher(ctx, orderRepo, paymentRepo, cursorRepo, catalogClient, btcBackend, cfg.OrderBtcConfirmations, cfg.OrderLatePaymentWindow, appLogger)
+			go btcWatcher.Start()
+		}
+	}
 
 	// --- Servers ---
 	errCh := make(chan error, 1)
 	go func() {
-		errCh <- runGRPCServer(cfg.OrderGRPCPort, grpcHandler, tokenManager, appLogger)
+		errCh <- runGRPCServer(cfg.OrderGRPCPort, grpcHandler, tokenManager, cfg.InternalServiceKey, appLogger)
 	}()
 	go func() {
 		errCh <- runRESTGateway(ctx, cfg.OrderHTTPPort, cfg.OrderGRPCAddr, appLogger)
@@ -91,21 +196,54 @@ func run(ctx context.Context, cfg *config.Config, appLogger *zap.Logger) error {
 	}
 }
 
-func runGRPCServer(port string, handler pb.OrderServiceServer, tm *auth.TokenManager, appLogger *zap.Logger) error {
+// newWalletService only loads the mnemonic when it is configured; otherwise payment addresses are derived
+// from the account xpubs the order service derives them under.
+func newWalletService(cfg *config.Config) (*wallet.Service, error) {
+	if cfg.WalletMnemonic != "" {
+		return wallet.NewService(cfg.WalletMnemonic)
+	}
+
+	var accounts []wallet.Account
+	if cfg.WalletEthXpub != "" {
+		accounts = append(accounts, wallet.Account{Path: wallet.EthereumAccountPath, Xpub: cfg.WalletEthXpub})
+	}
+	if cfg.WalletBtcXpub != "" {
+		coinType, err := wallet.BitcoinCoinType(cfg.BtcNetwork)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BTC_NETWORK: %w", err)
+		}
+		accounts = append(accounts, wallet.Account{Path: fmt.Sprintf("m/84'/%d'/0'", coinType), Xpub: cfg.WalletBtcXpub})
+	}
+	return wallet.NewWatchOnlyService(accounts...)
+}
+
+func runGRPCServer(port string, handler pb.OrderServiceServer, tm *auth.TokenManager, serviceKey string, appLogger *zap.Logger) error {
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", port, err)
 	}
 
-	gRPCServer := grpc.NewServer(grpc.UnaryInterceptor(tm.AuthenticationInterceptor))
+	interceptor := tm.NewAuthenticationInterceptor(
+		auth.WithGuestMethods(grpcserver.GuestMethods...),
+		auth.WithServiceMethods(serviceKey, grpcserver.ServiceMethods...),
+	)
+	gRPCServer := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
 	pb.RegisterOrderServiceServer(gRPCServer, handler)
 
 	appLogger.Info("Order gRPC Server is running", zap.String("port", port))
 	return gRPCServer.Serve(lis)
 }
 
+// headerMatcher forwards the guest cart token header as gRPC metadata on top of the default headers.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "X-Cart-Token") {
+		return "x-cart-token", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
 func runRESTGateway(ctx context.Context, httpPort, grpcAddr string, appLogger *zap.Logger) error {
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(headerMatcher))
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
 	if err := pb.RegisterOrderServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"pkg/contextkeys"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EchoMiddleware authenticates HTTP requests with a bearer token and puts the
+// JWT subject into the request context under contextkeys.UserIDKey.
+func (tm *TokenManager) EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+			if authHeader == "" {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "authorization token is not provided"})
+			}
+
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "authorization token format is invalid"})
+			}
+
+			userID, err := tm.Validate(parts[1])
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, echo.Map{"error": "token is invalid"})
+			}
+
+			ctx := context.WithValue(c.Request().Context(), contextkeys.UserIDKey, userID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
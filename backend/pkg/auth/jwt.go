@@ -1,14 +1,15 @@
 package auth
 
 import (
-	"black-shop/pkg/contextkeys"
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"pkg/contextkeys"
 	"strings"
 	"time"
 )
@@ -54,25 +55,98 @@ func (tm *TokenManager) Validate(tokenString string) (string, error) {
 	return "", fmt.Errorf("invalid token")
 }
 
-func (tm *TokenManager) AuthenticationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+// ServiceKeyHeader is the gRPC metadata key services send their shared service key in.
+const ServiceKeyHeader = "x-service-key"
 
-	publicMethods := []string{
-		"/v1.IdentityService/Login",
-		"/v1.IdentityService/Register",
+// WithServiceKey attaches the service key to the outgoing metadata of ctx, to call a method that is
+// reserved for services with WithServiceMethods.
+func WithServiceKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, ServiceKeyHeader, key)
+}
+
+// InterceptorOption configures the interceptor returned by NewAuthenticationInterceptor.
+type InterceptorOption func(*interceptorOptions)
+
+type interceptorOptions struct {
+	serviceKey     string
+	serviceMethods map[string]bool
+	publicMethods  map[string]bool
+	guestMethods   map[string]bool
+}
+
+// WithPublicMethods lets methods be called without a token, e.g. to sign in.
+func WithPublicMethods(methods ...string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		for _, method := range methods {
+			o.publicMethods[method] = true
+		}
 	}
+}
 
-	for _, publicMethod := range publicMethods {
-		if info.FullMethod == publicMethod {
-			return handler(ctx, req)
+// WithGuestMethods lets methods be called without a token; when one is sent it is still validated,
+// so the handler can tell a signed-in user from a guest.
+func WithGuestMethods(methods ...string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		for _, method := range methods {
+			o.guestMethods[method] = true
 		}
 	}
+}
 
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+// WithServiceMethods reserves methods for other services: they are served to callers sending key in
+// ServiceKeyHeader, never on a user token. An empty key refuses every call to them.
+func WithServiceMethods(key string, methods ...string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.serviceKey = key
+		for _, method := range methods {
+			o.serviceMethods[method] = true
+		}
 	}
+}
 
+// NewAuthenticationInterceptor returns an interceptor that puts the user id of the bearer token in the
+// context. Every method needs a valid token unless opts make it public, guest or service only.
+func (tm *TokenManager) NewAuthenticationInterceptor(opts ...InterceptorOption) grpc.UnaryServerInterceptor {
+	o := &interceptorOptions{
+		serviceMethods: map[string]bool{},
+		publicMethods:  map[string]bool{},
+		guestMethods:   map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return tm.authenticate(ctx, req, info, handler, o)
+	}
+}
+
+func (tm *TokenManager) authenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, o *interceptorOptions) (interface{}, error) {
+
+	if o.serviceMethods[info.FullMethod] {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(ServiceKeyHeader)
+		if o.serviceKey == "" || len(keys) == 0 || subtle.ConstantTimeCompare([]byte(keys[0]), []byte(o.serviceKey)) != 1 {
+			return nil, status.Errorf(codes.Unauthenticated, "service key is invalid")
+		}
+		return handler(ctx, req)
+	}
+
+	if o.publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
 	authHeader := md.Get("authorization")
+
+	if len(authHeader) == 0 && o.guestMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	if md == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
+
 	if len(authHeader) == 0 {
 		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
 	}
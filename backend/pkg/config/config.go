@@ -1,6 +1,10 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	// Catalog Service
@@ -15,9 +19,25 @@ type Config struct {
 	IdentityGRPCAddr string `mapstructure:"IDENTITY_GRPC_ADDR"`
 
 	// Order Service
-	OrderGRPCPort string `mapstructure:"ORDER_GRPC_PORT"`
-	OrderHTTPPort string `mapstructure:"ORDER_HTTP_PORT"`
-	OrderGRPCAddr string `mapstructure:"ORDER_GRPC_ADDR"`
+	OrderGRPCPort      string        `mapstructure:"ORDER_GRPC_PORT"`
+	OrderHTTPPort      string        `mapstructure:"ORDER_HTTP_PORT"`
+	OrderGRPCAddr      string        `mapstructure:"ORDER_GRPC_ADDR"`
+	OrderAdminUserIDs  []string      `mapstructure:"ORDER_ADMIN_USER_IDS"`
+	OrderPaymentWindow time.Duration `mapstructure:"ORDER_PAYMENT_WINDOW"`
+	OrderShippingFee   string        `mapstructure:"ORDER_SHIPPING_FEE"`
+	OrderCurrency      string        `mapstructure:"ORDER_CURRENCY"`
+	OrderRatesFile     string        `mapstructure:"ORDER_RATES_FILE"`
+	OrderRateMaxAge    time.Duration `mapstructure:"ORDER_RATE_MAX_AGE"`
+	OrderTokensFile    string        `mapstructure:"ORDER_TOKENS_FILE"`
+
+	OrderPaymentConfirmations uint64 `mapstructure:"ORDER_PAYMENT_CONFIRMATIONS"`
+	OrderBtcConfirmations     uint64 `mapstructure:"ORDER_BTC_CONFIRMATIONS"`
+
+	// How long the addresses of cancelled orders stay watched for late transfers to refund
+	OrderLatePaymentWindow time.Duration `mapstructure:"ORDER_LATE_PAYMENT_WINDOW"`
+
+	// Key other services authenticate their internal calls with, e.g. catalog checking purchases
+	InternalServiceKey string `mapstructure:"INTERNAL_SERVICE_KEY"`
 
 	// Database
 	MongoURI            string `mapstructure:"MONGO_URI"`
@@ -34,6 +54,23 @@ type Config struct {
 	// Local Storage
 	LocalStoragePath string `mapstructure:"LOCAL_STORAGE_PATH"`
 
+	// Wallet
+	WalletMnemonic string `mapstructure:"WALLET_MNEMONIC"`
+	WalletEthXpub  string `mapstructure:"WALLET_ETH_XPUB"`
+	WalletBtcXpub  string `mapstructure:"WALLET_BTC_XPUB"`
+	EthRPCURL      string `mapstructure:"ETH_RPC_URL"`
+
+	// Sweeper
+	SweepTreasuryAddress string        `mapstructure:"SWEEP_TREASURY_ADDRESS"`
+	SweepInterval        time.Duration `mapstructure:"SWEEP_INTERVAL"`
+	SweepDryRun          bool          `mapstructure:"SWEEP_DRY_RUN"`
+	SweepGasTankPath     string        `mapstructure:"SWEEP_GAS_TANK_PATH"`
+
+	// Bitcoin
+	BtcNetwork      string        `mapstructure:"BTC_NETWORK"`
+	BtcEsploraURL   string        `mapstructure:"BTC_ESPLORA_URL"`
+	BtcPollInterval time.Duration `mapstructure:"BTC_POLL_INTERVAL"`
+
 	// General
 	AppEnv string `mapstructure:"APP_ENV"`
 }
@@ -7,9 +7,12 @@ import (
 )
 
 type FilterSet struct {
-	FilterFields   map[string]string
-	SearchFields   []string
-	OrderingFields []string
+	FilterFields map[string]string
+	// ListFilterFields match array fields against comma-separated values (?tags=a,b).
+	// By default a document matches any of the values; add <param>_mode=all to require every value.
+	ListFilterFields map[string]string
+	SearchFields     []string
+	OrderingFields   []string
 }
 
 type QueryBuilderResult struct {
@@ -63,6 +66,22 @@ func (fs *FilterSet) BuildMongoQuery(c echo.Context) QueryBuilderResult {
 			continue
 		}
 
+		// Filter (list: any, all)
+		if dbField, ok := fs.ListFilterFields[key]; ok {
+			items := splitList(value)
+			if len(items) == 0 {
+				continue
+			}
+
+			mongoOperator := "$in"
+			if queryParams.Get(key+"_mode") == "all" {
+				mongoOperator = "$all"
+			}
+
+			result.FilterQuery[dbField] = bson.M{mongoOperator: items}
+			continue
+		}
+
 		// Filter (gte, lte, in, ...)
 		parts := strings.Split(key, "__") // price__gte, price__lte
 		if len(parts) == 2 {
@@ -97,3 +116,13 @@ func (fs *FilterSet) BuildMongoQuery(c echo.Context) QueryBuilderResult {
 
 	return result
 }
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
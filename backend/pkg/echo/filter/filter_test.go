@@ -0,0 +1,84 @@
+package filter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/matryer/is"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"pkg/echo/filter"
+)
+
+var productFilters = &filter.FilterSet{
+	FilterFields:     map[string]string{"brand": "brandId", "price": "price"},
+	ListFilterFields: map[string]string{"tags": "tagIds"},
+	SearchFields:     []string{"name"},
+	OrderingFields:   []string{"price"},
+}
+
+func buildQuery(query url.Values) filter.QueryBuilderResult {
+	req := httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	return productFilters.BuildMongoQuery(c)
+}
+
+func TestBuildMongoQuery_ListFilters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		want  bson.M
+	}{
+		{
+			"Any of the values by default",
+			url.Values{"tags": {"a,b"}},
+			bson.M{"tagIds": bson.M{"$in": []string{"a", "b"}}},
+		},
+		{
+			"Every value in all mode",
+			url.Values{"tags": {"a,b"}, "tags_mode": {"all"}},
+			bson.M{"tagIds": bson.M{"$all": []string{"a", "b"}}},
+		},
+		{
+			"Unknown mode falls back to any",
+			url.Values{"tags": {"a"}, "tags_mode": {"none"}},
+			bson.M{"tagIds": bson.M{"$in": []string{"a"}}},
+		},
+		{
+			"Blank items are dropped",
+			url.Values{"tags": {" a, ,b ,"}},
+			bson.M{"tagIds": bson.M{"$in": []string{"a", "b"}}},
+		},
+		{
+			"Only blank items filter nothing",
+			url.Values{"tags": {" , ,"}},
+			bson.M{},
+		},
+		{
+			"Combined with other filters",
+			url.Values{"tags": {"a"}, "brand": {"b1"}},
+			bson.M{"tagIds": bson.M{"$in": []string{"a"}}, "brandId": "b1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(buildQuery(tt.query).FilterQuery, tt.want)
+		})
+	}
+}
+
+func TestBuildMongoQuery_RangeAndOrdering(t *testing.T) {
+	is := is.New(t)
+
+	result := buildQuery(url.Values{"price__gte": {"10"}, "price__lte": {"20"}, "ordering": {"-price"}})
+	is.Equal(result.FilterQuery, bson.M{"price": bson.M{"$gte": "10", "$lte": "20"}})
+	is.Equal(result.SortOptions, bson.D{{Key: "price", Value: -1}})
+
+	result = buildQuery(url.Values{"ordering": {"secret"}})
+	is.Equal(len(result.SortOptions), 0) // fields that are not orderable are ignored
+}
@@ -18,6 +18,7 @@ require (
 	github.com/webstradev/echo-pagination v1.1.1
 	go.mongodb.org/mongo-driver/v2 v2.2.3
 	go.uber.org/zap v1.27.0
+	golang.org/x/crypto v0.39.0
 	google.golang.org/grpc v1.74.2
 )
 
@@ -51,7 +52,6 @@ require (
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
-	golang.org/x/crypto v0.39.0 // indirect
 	golang.org/x/net v0.41.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.26.0 // indirect
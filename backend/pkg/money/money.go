@@ -0,0 +1,215 @@
+// Package money represents monetary amounts as integers in the minor unit of an ISO 4217 currency,
+// so prices and totals can be added and compared without floating point drift.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts stored before a currency was recorded.
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount   = errors.New("invalid money amount")
+	ErrUnknownCurrency = errors.New("unknown currency")
+)
+
+// exponents is the number of minor-unit digits of the supported currencies.
+var exponents = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"IRR": 0,
+	"JPY": 0,
+	"KWD": 3,
+}
+
+// Money is an amount in the minor unit of Currency, e.g. cents for USD.
+// The zero value has no currency and adopts the currency of the first amount added to it.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Zero returns an amount of zero in currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// ValidateCurrency reports whether currency is a supported ISO 4217 code.
+func ValidateCurrency(currency string) error {
+	if _, ok := exponents[currency]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return nil
+}
+
+// Parse reads a decimal amount in major units such as "12.34" or "-0.5". More fraction digits
+// than the currency has are rejected rather than rounded.
+func Parse(amount, currency string) (Money, error) {
+	exp, ok := exponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > exp || strings.ContainsAny(whole+frac, "+-") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	if negative {
+		minor = -minor
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for constants and tests.
+func MustParse(amount, currency string) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Exponent returns the number of minor-unit digits of the currency, 2 when it is unknown.
+func (m Money) Exponent() int {
+	if exp, ok := exponents[m.Currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Exponents returns the number of minor-unit digits of every supported currency, keyed by currency code.
+func Exponents() map[string]int {
+	copied := make(map[string]int, len(exponents))
+	for currency, exp := range exponents {
+		copied[currency] = exp
+	}
+	return copied
+}
+
+// Decimal formats the amount in major units without the currency, e.g. "12.34".
+func (m Money) Decimal() string {
+	exp := m.Exponent()
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String formats the amount with its currency, e.g. "12.34 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Float returns the amount in major units. It is only meant for display and conversions
+// that are rounded again, never for arithmetic on totals.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(m.Exponent())
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add returns m + o. Adding amounts of different currencies is a programming error and panics.
+func (m Money) Add(o Money) Money {
+	currency := m.currencyWith(o)
+	return Money{Amount: m.Amount + o.Amount, Currency: currency}
+}
+
+// Sub returns m - o. Subtracting amounts of different currencies is a programming error and panics.
+func (m Money) Sub(o Money) Money {
+	currency := m.currencyWith(o)
+	return Money{Amount: m.Amount - o.Amount, Currency: currency}
+}
+
+// Mul returns m multiplied by a quantity.
+func (m Money) Mul(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// Percent returns percent of m, rounded half away from zero to the minor unit.
+func (m Money) Percent(percent float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * percent / 100)), Currency: m.Currency}
+}
+
+// Cmp returns -1, 0 or 1 when m is less than, equal to or greater than o.
+func (m Money) Cmp(o Money) int {
+	m.currencyWith(o)
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Min returns the smaller of m and o.
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// Max returns the larger of m and o.
+func (m Money) Max(o Money) Money {
+	if m.Cmp(o) >= 0 {
+		return m
+	}
+	return o
+}
+
+// currencyWith returns the currency shared by m and o, treating an empty currency as a wildcard.
+func (m Money) currencyWith(o Money) string {
+	switch {
+	case m.Currency == "":
+		return o.Currency
+	case o.Currency == "" || o.Currency == m.Currency:
+		return m.Currency
+	}
+	panic(fmt.Sprintf("money: currency mismatch %s and %s", m.Currency, o.Currency))
+}
@@ -0,0 +1,94 @@
+package money_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"pkg/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		want     int64
+		wantErr  error
+	}{
+		{"Whole amount", "12", "USD", 1200, nil},
+		{"Two decimals", "12.34", "USD", 1234, nil},
+		{"One decimal", "0.5", "USD", 50, nil},
+		{"Negative", "-3.07", "EUR", -307, nil},
+		{"No minor unit", "1500", "JPY", 1500, nil},
+		{"Three decimals", "1.005", "KWD", 1005, nil},
+		{"Too many decimals", "1.005", "USD", 0, money.ErrInvalidAmount},
+		{"Not a number", "abc", "USD", 0, money.ErrInvalidAmount},
+		{"Missing whole part", ".5", "USD", 0, money.ErrInvalidAmount},
+		{"Unknown currency", "1", "XXX", 0, money.ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			m, err := money.Parse(tt.amount, tt.currency)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(m, money.New(tt.want, tt.currency))
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(money.New(1234, "USD").Decimal(), "12.34")
+	is.Equal(money.New(5, "USD").Decimal(), "0.05")
+	is.Equal(money.New(-5, "USD").Decimal(), "-0.05")
+	is.Equal(money.New(1500, "JPY").Decimal(), "1500")
+	is.Equal(money.New(1005, "KWD").String(), "1.005 KWD")
+}
+
+func TestArithmetic(t *testing.T) {
+	is := is.New(t)
+
+	// Ten lines of 0.10 add up to exactly 1.00, unlike float64.
+	var total money.Money
+	for i := 0; i < 10; i++ {
+		total = total.Add(money.MustParse("0.10", "USD"))
+	}
+	is.Equal(total, money.MustParse("1.00", "USD"))
+
+	is.Equal(money.MustParse("19.99", "USD").Mul(3), money.MustParse("59.97", "USD"))
+	is.Equal(money.MustParse("10.05", "USD").Percent(10), money.MustParse("1.01", "USD")) // 100.5 cents rounds up
+	is.Equal(money.MustParse("5", "USD").Min(money.MustParse("3", "USD")), money.MustParse("3", "USD"))
+	is.Equal(money.MustParse("5", "USD").Sub(money.MustParse("7", "USD")).IsNegative(), true)
+}
+
+func TestCurrencyMismatchPanics(t *testing.T) {
+	is := is.New(t)
+
+	defer func() {
+		is.True(recover() != nil)
+	}()
+	money.MustParse("1", "USD").Add(money.MustParse("1", "EUR"))
+}
+
+func TestExponents(t *testing.T) {
+	is := is.New(t)
+
+	exponents := money.Exponents()
+	for currency, exp := range exponents {
+		is.Equal(money.Zero(currency).Exponent(), exp) // same digits as the amounts of the currency
+	}
+	is.Equal(exponents["JPY"], 0)
+	is.Equal(exponents["KWD"], 3)
+
+	exponents["USD"] = 0
+	is.Equal(money.Exponents()["USD"], 2) // callers get a copy
+}
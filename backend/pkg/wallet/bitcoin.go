@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Bitcoin networks addresses can be encoded for.
+const (
+	BitcoinMainnet = "mainnet"
+	BitcoinTestnet = "testnet"
+	BitcoinRegtest = "regtest"
+)
+
+func bitcoinParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case BitcoinMainnet:
+		return &chaincfg.MainNetParams, nil
+	case BitcoinTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case BitcoinRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	}
+	return nil, fmt.Errorf("unknown bitcoin network %q", network)
+}
+
+// BitcoinCoinType returns the BIP44 coin type of network: 0 on mainnet and 1 on the test networks.
+func BitcoinCoinType(network string) (uint32, error) {
+	params, err := bitcoinParams(network)
+	if err != nil {
+		return 0, err
+	}
+	return params.HDCoinType, nil
+}
+
+// DeriveBitcoinAddress derives the key at path (e.g. "m/84'/0'/0'/0/0") and returns its native SegWit
+// (P2WPKH, BIP84) address on network.
+func (s *Service) DeriveBitcoinAddress(path, network string) (string, error) {
+	params, err := bitcoinParams(network)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := s.deriveKey(path)
+	if err != nil {
+		return "", err
+	}
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	address, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create bitcoin address: %w", err)
+	}
+	return address.EncodeAddress(), nil
+}
+
+// NormalizeBitcoinAddress returns the canonical encoding of address, which is lower case for bech32
+// addresses. It reports false if address is not a valid address on network.
+func NormalizeBitcoinAddress(address, network string) (string, bool) {
+	params, err := bitcoinParams(network)
+	if err != nil {
+		return "", false
+	}
+	decoded, err := btcutil.DecodeAddress(address, params)
+	if err != nil || !decoded.IsForNet(params) {
+		return "", false
+	}
+	return decoded.EncodeAddress(), true
+}
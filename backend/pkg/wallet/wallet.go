@@ -1,17 +1,52 @@
 package wallet
 
 import (
+	"crypto/ecdsa"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/cosmos/go-bip39"
+	"golang.org/x/crypto/sha3"
 )
 
+var (
+	ErrInvalidPath = errors.New("invalid derivation path")
+	// ErrWatchOnly is returned when a private key, or a hardened child below a watched account, is derived
+	// from a service that only knows the account xpubs.
+	ErrWatchOnly = errors.New("not available in watch-only mode")
+)
+
+// EthereumAccountPath is the BIP44 account the payment addresses of ETH and ERC-20 tokens are derived below.
+const EthereumAccountPath = "m/44'/60'/0'"
+
+// EthereumPaymentPath returns the path of the Ethereum payment address with the given derivation index, on
+// the receiving (non-change) chain of EthereumAccountPath.
+func EthereumPaymentPath(index int64) string {
+	return fmt.Sprintf("%s/0/%d", EthereumAccountPath, index)
+}
+
+// Account is an account-level extended public key (e.g. the xpub of "m/44'/60'/0'") a watch-only
+// service derives the addresses below it from.
+type Account struct {
+	Path string
+	Xpub string
+}
+
+// account is a key the service derives from, along with the path it is found at.
+type account struct {
+	path []uint32
+	key  *hdkeychain.ExtendedKey
+}
+
 type Service struct {
-	masterKey *hdkeychain.ExtendedKey
-	mnemonic  string
+	// accounts holds the master key, or the watched accounts in watch-only mode.
+	accounts  []account
+	watchOnly bool
 }
 
 func NewService(mnemonic string) (*Service, error) {
@@ -20,28 +55,183 @@ func NewService(mnemonic string) (*Service, error) {
 		return nil, errors.New("invalid mnemonic phrase")
 	}
 
-	seed := bip39.NewSeed(mnemonic, "")
+	return newServiceFromSeed(bip39.NewSeed(mnemonic, ""))
+}
 
+func newServiceFromSeed(seed []byte) (*Service, error) {
 	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create master key: %w", err)
 	}
 
 	return &Service{
-		masterKey: masterKey,
-		mnemonic:  mnemonic,
+		accounts: []account{{key: masterKey}},
 	}, nil
 }
 
+// NewWatchOnlyService creates a service that derives addresses from account xpubs alone, so the mnemonic
+// never has to be loaded by services that only hand out payment addresses. Only non-hardened paths below
+// one of the accounts can be derived, and private keys are never available.
+func NewWatchOnlyService(accounts ...Account) (*Service, error) {
+	if len(accounts) == 0 {
+		return nil, errors.New("no account xpub given")
+	}
+
+	s := &Service{watchOnly: true}
+	for _, a := range accounts {
+		path, err := parseDerivationPath(a.Path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := hdkeychain.NewKeyFromString(a.Xpub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xpub for %s: %w", a.Path, err)
+		}
+		if key.IsPrivate() {
+			return nil, fmt.Errorf("the key for %s must be an extended public key", a.Path)
+		}
+		if int(key.Depth()) != len(path) || (len(path) > 0 && key.ChildIndex() != path[len(path)-1]) {
+			return nil, fmt.Errorf("the xpub given for %s is not at that path", a.Path)
+		}
+		s.accounts = append(s.accounts, account{path: path, key: key})
+	}
+	return s, nil
+}
+
+// DeriveAddress derives the key at path (e.g. "m/44'/60'/0'/0/0") and returns its EIP-55 checksummed
+// Ethereum address.
 func (s *Service) DeriveAddress(path string) (string, error) {
+	key, err := s.deriveKey(path)
+	if err != nil {
+		return "", err
+	}
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key: %w", err)
+	}
 
-	// 1. Parse the derivation path string (e.g., "m/44'/60'/0'/0/0")
-	parts, err := parseDerivationPath(path)
+	// The address is the last 20 bytes of the Keccak-256 hash of the uncompressed public key, without
+	// its 0x04 prefix.
+	hash := keccak256(pubKey.SerializeUncompressed()[1:])
+	return toChecksumAddress(hash[12:]), nil
+}
+
+// AccountXpub returns the extended public key at path, to configure a watch-only service with.
+func (s *Service) AccountXpub(path string) (string, error) {
+	key, err := s.deriveKey(path)
 	if err != nil {
 		return "", err
 	}
+	pubKey, err := key.Neuter()
+	if err != nil {
+		return "", fmt.Errorf("failed to get extended public key: %w", err)
+	}
+	return pubKey.String(), nil
+}
 
-	// 2. Start with the master key and derive child keys sequentially
-	currentKey := s.masterKey
+// deriveKey derives the key at path from the deepest account the path goes through, one path element
+// at a time.
+func (s *Service) deriveKey(path string) (*hdkeychain.ExtendedKey, error) {
+	parts, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var from *account
+	for i := range s.accounts {
+		a := &s.accounts[i]
+		if hasPathPrefix(parts, a.path) && (from == nil || len(a.path) > len(from.path)) {
+			from = a
+		}
+	}
+	if from == nil {
+		return nil, fmt.Errorf("%w: %s is not below a watched account", ErrWatchOnly, path)
+	}
+
+	key := from.key
+	for _, part := range parts[len(from.path):] {
+		key, err = key.Derive(part)
+		if errors.Is(err, hdkeychain.ErrDeriveHardFromPublic) {
+			return nil, fmt.Errorf("%w: %s has a hardened element below the watched account", ErrWatchOnly, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key at %s: %w", path, err)
+		}
+	}
+	return key, nil
+}
+
+// DerivePrivateKey derives the private key at path, e.g. to move the funds received on the address
+// derived from the same path.
+func (s *Service) DerivePrivateKey(path string) (*ecdsa.PrivateKey, error) {
+	if s.watchOnly {
+		return nil, ErrWatchOnly
+	}
+	key, err := s.deriveKey(path)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key: %w", err)
+	}
+	return privateKey.ToECDSA(), nil
+}
 
+// parseDerivationPath parses a BIP32 path such as "m/44'/60'/0'/0/0" into child indexes. Hardened
+// elements are marked with ' (or h) and are offset by hdkeychain.HardenedKeyStart.
+func parseDerivationPath(path string) ([]uint32, error) {
+	elements := strings.Split(path, "/")
+	if elements[0] != "m" {
+		return nil, fmt.Errorf("%w %q: it must start with m", ErrInvalidPath, path)
+	}
+
+	parts := make([]uint32, 0, len(elements)-1)
+	for _, element := range elements[1:] {
+		index := element
+		var offset uint32
+		if n := len(index); n > 0 && strings.ContainsRune("'hH", rune(index[n-1])) {
+			index, offset = index[:n-1], hdkeychain.HardenedKeyStart
+		}
+		// Indexes take 31 bits, the upper half of the range is reserved for hardened keys.
+		value, err := strconv.ParseUint(index, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: bad element %q", ErrInvalidPath, path, element)
+		}
+		parts = append(parts, uint32(value)+offset)
+	}
+	return parts, nil
+}
+
+func hasPathPrefix(path, prefix []uint32) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// toChecksumAddress encodes a 20 byte address as EIP-55 mixed-case hex: a letter is upper case when the
+// matching nibble of the Keccak-256 hash of the lower case hex address is 8 or more.
+func toChecksumAddress(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := hex.EncodeToString(keccak256([]byte(lower)))
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		if c >= 'a' && hash[i] >= '8' {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
 }
@@ -0,0 +1,308 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+const (
+	// hardhatMnemonic is the mnemonic of the Hardhat and Anvil development accounts.
+	hardhatMnemonic = "test test test test test test test test test test test junk"
+	// bip84Mnemonic is the mnemonic of the BIP84 test vectors.
+	bip84Mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	// vector2Seed is the seed of BIP32 test vector 2.
+	vector2Seed = "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542"
+)
+
+// TestBIP32Vectors checks the extended public keys of test vectors 1 and 2 of BIP32.
+func TestBIP32Vectors(t *testing.T) {
+	tests := []struct {
+		name string
+		seed string
+		path string
+		want string
+	}{
+		{"Vector 1 m", "000102030405060708090a0b0c0d0e0f", "m", "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+		{"Vector 1 m/0H", "000102030405060708090a0b0c0d0e0f", "m/0'", "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+		{"Vector 1 m/0H/1", "000102030405060708090a0b0c0d0e0f", "m/0'/1", "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"},
+		{"Vector 1 m/0H/1/2H", "000102030405060708090a0b0c0d0e0f", "m/0'/1/2'", "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"},
+		{"Vector 1 m/0H/1/2H/2", "000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2", "xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV"},
+		{"Vector 1 m/0H/1/2H/2/1000000000", "000102030405060708090a0b0c0d0e0f", "m/0'/1/2'/2/1000000000", "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy"},
+		{"Vector 2 m", vector2Seed, "m", "xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB"},
+		{"Vector 2 m/0", vector2Seed, "m/0", "xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH"},
+		{"Vector 2 m/0/2147483647H", vector2Seed, "m/0/2147483647'", "xpub6ASAVgeehLbnwdqV6UKMHVzgqAG8Gr6riv3Fxxpj8ksbH9ebxaEyBLZ85ySDhKiLDBrQSARLq1uNRts8RuJiHjaDMBU4Zn9h8LZNnBC5y4a"},
+		{"Vector 2 m/0/2147483647H/1", vector2Seed, "m/0/2147483647'/1", "xpub6DF8uhdarytz3FWdA8TvFSvvAh8dP3283MY7p2V4SeE2wyWmG5mg5EwVvmdMVCQcoNJxGoWaU9DCWh89LojfZ537wTfunKau47EL2dhHKon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			seed, err := hex.DecodeString(tt.seed)
+			is.NoErr(err)
+			s, err := newServiceFromSeed(seed)
+			is.NoErr(err)
+
+			xpub, err := s.AccountXpub(tt.path)
+			is.NoErr(err)
+			is.Equal(xpub, tt.want)
+		})
+	}
+}
+
+// TestDeriveAddress checks the BIP44 Ethereum addresses of well known mnemonics, e.g. the first Hardhat
+// accounts.
+func TestDeriveAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		path     string
+		want     string
+	}{
+		{"Hardhat account 0", hardhatMnemonic, "m/44'/60'/0'/0/0", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"Hardhat account 1", hardhatMnemonic, "m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"Hardened markers", hardhatMnemonic, "m/44h/60H/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"BIP84 mnemonic", bip84Mnemonic, "m/44'/60'/0'/0/0", "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{"Payment path", hardhatMnemonic, EthereumPaymentPath(1), "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			s, err := NewService(tt.mnemonic)
+			is.NoErr(err)
+
+			address, err := s.DeriveAddress(tt.path)
+			is.NoErr(err)
+			is.Equal(address, tt.want)
+		})
+	}
+}
+
+// TestDeriveBitcoinAddress checks the native SegWit addresses of the BIP84 test vectors.
+func TestDeriveBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		network string
+		want    string
+		wantErr bool
+	}{
+		{"First receiving address", "m/84'/0'/0'/0/0", BitcoinMainnet, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", false},
+		{"Second receiving address", "m/84'/0'/0'/0/1", BitcoinMainnet, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", false},
+		{"First change address", "m/84'/0'/0'/1/0", BitcoinMainnet, "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el", false},
+		{"Testnet", "m/84'/1'/0'/0/0", BitcoinTestnet, "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", false},
+		{"Unknown network", "m/84'/0'/0'/0/0", "signet", "", true},
+		{"Invalid path", "84'/0'/0'/0/0", BitcoinMainnet, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			s, err := NewService(bip84Mnemonic)
+			is.NoErr(err)
+
+			address, err := s.DeriveBitcoinAddress(tt.path, tt.network)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(address, tt.want)
+		})
+	}
+}
+
+func TestBitcoinCoinType(t *testing.T) {
+	tests := []struct {
+		network string
+		want    uint32
+		wantErr bool
+	}{
+		{BitcoinMainnet, 0, false},
+		{BitcoinTestnet, 1, false},
+		{BitcoinRegtest, 1, false},
+		{"signet", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			is := is.New(t)
+
+			coinType, err := BitcoinCoinType(tt.network)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(coinType, tt.want)
+		})
+	}
+}
+
+func TestNormalizeBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		network string
+		want    string
+		ok      bool
+	}{
+		{"Bech32", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", BitcoinMainnet, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", true},
+		{"Upper case bech32", "BC1QCR8TE4KR609GCAWUTMRZA0J4XV80JY8Z306FYU", BitcoinMainnet, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", true},
+		{"Legacy", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", BitcoinMainnet, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", true},
+		{"Testnet", "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", BitcoinTestnet, "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", true},
+		{"Mainnet address on testnet", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", BitcoinTestnet, "", false},
+		{"Testnet address on mainnet", "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl", BitcoinMainnet, "", false},
+		{"Bad checksum", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyv", BitcoinMainnet, "", false},
+		{"Ethereum address", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", BitcoinMainnet, "", false},
+		{"Unknown network", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", "signet", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			address, ok := NormalizeBitcoinAddress(tt.address, tt.network)
+			is.Equal(ok, tt.ok)
+			is.Equal(address, tt.want)
+		})
+	}
+}
+
+func TestToChecksumAddress(t *testing.T) {
+	// Test cases of EIP-55.
+	tests := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			is := is.New(t)
+
+			address, err := hex.DecodeString(want[2:])
+			is.NoErr(err)
+			is.Equal(toChecksumAddress(address), want)
+		})
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	const h = 0x80000000
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []uint32
+		wantErr bool
+	}{
+		{"Master", "m", []uint32{}, false},
+		{"BIP44", "m/44'/60'/0'/0/7", []uint32{44 + h, 60 + h, h, 0, 7}, false},
+		{"h markers", "m/84h/1H/0", []uint32{84 + h, 1 + h, 0}, false},
+		{"Largest index", "m/2147483647'", []uint32{2147483647 + h}, false},
+		{"Index too large", "m/2147483648", nil, true},
+		{"No master", "44'/60'", nil, true},
+		{"Empty", "", nil, true},
+		{"Empty element", "m//0", nil, true},
+		{"Trailing slash", "m/0/", nil, true},
+		{"Negative", "m/-1", nil, true},
+		{"Double marker", "m/1''", nil, true},
+		{"Not a number", "m/x", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			parts, err := parseDerivationPath(tt.path)
+			if tt.wantErr {
+				is.True(errors.Is(err, ErrInvalidPath))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(parts, tt.want)
+		})
+	}
+}
+
+func TestWatchOnlyService(t *testing.T) {
+	is := is.New(t)
+
+	full, err := NewService(hardhatMnemonic)
+	is.NoErr(err)
+	ethXpub, err := full.AccountXpub("m/44'/60'/0'")
+	is.NoErr(err)
+	btcXpub, err := full.AccountXpub("m/84'/0'/0'")
+	is.NoErr(err)
+
+	s, err := NewWatchOnlyService(
+		Account{Path: "m/44'/60'/0'", Xpub: ethXpub},
+		Account{Path: "m/84'/0'/0'", Xpub: btcXpub},
+	)
+	is.NoErr(err)
+
+	// Addresses below the accounts match the ones derived from the mnemonic.
+	address, err := s.DeriveAddress("m/44'/60'/0'/0/0")
+	is.NoErr(err)
+	is.Equal(address, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	for _, path := range []string{"m/84'/0'/0'/0/3", "m/84'/0'/0'/1/0"} {
+		want, err := full.DeriveBitcoinAddress(path, BitcoinMainnet)
+		is.NoErr(err)
+		got, err := s.DeriveBitcoinAddress(path, BitcoinMainnet)
+		is.NoErr(err)
+		is.Equal(got, want)
+	}
+
+	// Hardened children, other accounts and private keys need the mnemonic.
+	_, err = s.DeriveAddress("m/44'/60'/0'/0'/0")
+	is.True(errors.Is(err, ErrWatchOnly))
+	_, err = s.DeriveAddress("m/44'/60'/1'/0/0")
+	is.True(errors.Is(err, ErrWatchOnly))
+	_, err = s.DerivePrivateKey("m/44'/60'/0'/0/0")
+	is.True(errors.Is(err, ErrWatchOnly))
+}
+
+func TestNewWatchOnlyService(t *testing.T) {
+	// Account xpub of the BIP84 mnemonic at m/84'/0'/0'.
+	const xpub = "xpub6CatWdiZiodmUeTDp8LT5or8nmbKNcuyvz7WyksVFkKB4RHwCD3XyuvPEbvqAQY3rAPshWcMLoP2fMFMKHPJ4ZeZXYVUhLv1VMrjPC7PW6V"
+
+	tests := []struct {
+		name     string
+		accounts []Account
+		wantErr  bool
+	}{
+		{"Account xpub", []Account{{Path: "m/84'/0'/0'", Xpub: xpub}}, false},
+		{"No account", nil, true},
+		{"Wrong depth", []Account{{Path: "m/84'/0'", Xpub: xpub}}, true},
+		{"Wrong account", []Account{{Path: "m/84'/0'/1'", Xpub: xpub}}, true},
+		{"Invalid path", []Account{{Path: "84'/0'/0'", Xpub: xpub}}, true},
+		{"Invalid xpub", []Account{{Path: "m/84'/0'/0'", Xpub: "xpub"}}, true},
+		{"Private key", []Account{{Path: "m", Xpub: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			s, err := NewWatchOnlyService(tt.accounts...)
+			if tt.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+
+			// First receiving address of the BIP84 test vectors.
+			address, err := s.DeriveBitcoinAddress("m/84'/0'/0'/0/0", BitcoinMainnet)
+			is.NoErr(err)
+			is.Equal(address, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu")
+		})
+	}
+}